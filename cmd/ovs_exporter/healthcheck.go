@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// readyPath is the path of the readiness endpoint of the exporter.
+const readyPath = "/readyz"
+
+// readyHandler reports the exporter ready unless the last collection of a
+// target found OVS unreachable. The handler is only registered once the
+// exporter connected to OVS, so the targets not collected yet are ready.
+func readyHandler(targets []target) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, t := range targets {
+			if snapshot := t.exporter.Snapshot(); snapshot != nil && !snapshot.Up {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				if t.sandbox != "" {
+					fmt.Fprintf(w, "OVS of sandbox %s is down\n", t.sandbox)
+					return
+				}
+				w.Write([]byte("OVS is down\n"))
+				return
+			}
+		}
+		w.Write([]byte("OK\n"))
+	})
+}
+
+// healthcheckURL returns the URL of the readiness endpoint of an exporter
+// listening on the address. Wildcard and empty hosts are reached through
+// the loopback interface.
+func healthcheckURL(listenAddress string) (string, error) {
+	host, port, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return "", fmt.Errorf("malformed listen address %q: %s", listenAddress, err)
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + readyPath, nil
+}
+
+// healthcheck queries the readiness endpoint of a running exporter, e.g.
+// from the HEALTHCHECK directive of a container image without curl, and
+// returns an error unless the exporter is ready.
+func healthcheck(listenAddress string, timeout time.Duration) error {
+	url, err := healthcheckURL(listenAddress)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ovs "github.com/Liquescent-Development/ovs_exporter/pkg/ovs_exporter"
+	"github.com/go-kit/log"
+)
+
+func TestHealthcheckURL(t *testing.T) {
+	for _, test := range []struct {
+		address  string
+		expected string
+	}{
+		{":9475", "http://localhost:9475/readyz"},
+		{"0.0.0.0:9475", "http://localhost:9475/readyz"},
+		{"[::]:9475", "http://localhost:9475/readyz"},
+		{"192.0.2.10:9475", "http://192.0.2.10:9475/readyz"},
+		{"[2001:db8::10]:9475", "http://[2001:db8::10]:9475/readyz"},
+		{"exporter.example.com:9475", "http://exporter.example.com:9475/readyz"},
+	} {
+		url, err := healthcheckURL(test.address)
+		if err != nil {
+			t.Errorf("healthcheckURL(%q) returned error: %v", test.address, err)
+			continue
+		}
+		if url != test.expected {
+			t.Errorf("healthcheckURL(%q) = %q, expected %q", test.address, url, test.expected)
+		}
+	}
+	for _, address := range []string{"9475", "2001:db8::10:9475"} {
+		if _, err := healthcheckURL(address); err == nil {
+			t.Errorf("healthcheckURL(%q) should return error for a malformed address", address)
+		}
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	newTarget := func(sandbox string) target {
+		exporter := ovs.NewExporter(ovs.Options{Timeout: 1, Logger: log.NewNopLogger()})
+		exporter.Client.System.RunDir = t.TempDir()
+		return target{sandbox: sandbox, exporter: exporter}
+	}
+	ready := func(targets []target) (int, string) {
+		rec := httptest.NewRecorder()
+		readyHandler(targets).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, readyPath, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	// Not collected yet.
+	targets := []target{newTarget("a"), newTarget("b")}
+	if code, _ := ready(targets); code != http.StatusOK {
+		t.Errorf("Expected 200 before the first collection, got %d", code)
+	}
+
+	// OVS is not running in the run directory, the collection is down.
+	targets[1].exporter.GatherMetrics()
+	code, body := ready(targets)
+	if code != http.StatusServiceUnavailable || !strings.Contains(body, "sandbox b") {
+		t.Errorf("Expected 503 for sandbox b, got %d %q", code, body)
+	}
+}
+
+func TestHealthcheck(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != readyPath {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	if err := healthcheck(address, time.Second); err != nil {
+		t.Errorf("healthcheck() returned error: %v", err)
+	}
+	status = http.StatusServiceUnavailable
+	if err := healthcheck(address, time.Second); err == nil {
+		t.Error("healthcheck() should return error when the exporter is not ready")
+	}
+}
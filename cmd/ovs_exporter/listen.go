@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// parseListenAddresses parses the comma-separated listen addresses of the
+// exporter, e.g. "127.0.0.1:9475,[::1]:9475". IPv6 literals must be
+// bracketed. An address without host, e.g. ":9475", listens on all the
+// addresses of the host, IPv4 and IPv6.
+func parseListenAddresses(s string) ([]string, error) {
+	var addresses []string
+	for _, address := range strings.Split(s, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("malformed listen address %q: %s", address, err)
+		}
+		addresses = append(addresses, address)
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no listen address")
+	}
+	return addresses, nil
+}
+
+// listenAll listens on each of the addresses. On failure, the listeners
+// already opened are closed.
+func listenAll(addresses []string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(addresses))
+	for _, address := range addresses {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, err
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseListenAddresses(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected []string
+	}{
+		{":9475", []string{":9475"}},
+		{"127.0.0.1:9475,[::1]:9475", []string{"127.0.0.1:9475", "[::1]:9475"}},
+		{" [2001:db8::10]:9475 , 192.0.2.10:9475 ,", []string{"[2001:db8::10]:9475", "192.0.2.10:9475"}},
+	} {
+		addresses, err := parseListenAddresses(test.input)
+		if err != nil {
+			t.Errorf("parseListenAddresses(%q) returned error: %v", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(addresses, test.expected) {
+			t.Errorf("parseListenAddresses(%q) = %v, expected %v", test.input, addresses, test.expected)
+		}
+	}
+	for _, input := range []string{"", " , ", "9475", "::1:9475", "127.0.0.1:9475,[::1]"} {
+		if _, err := parseListenAddresses(input); err == nil {
+			t.Errorf("parseListenAddresses(%q) should return error", input)
+		}
+	}
+}
+
+func TestListenAll(t *testing.T) {
+	listeners, err := listenAll([]string{"127.0.0.1:0", "127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("listenAll() returned error: %v", err)
+	}
+	if len(listeners) != 2 {
+		t.Errorf("Expected 2 listeners, got %d", len(listeners))
+	}
+	for _, l := range listeners {
+		l.Close()
+	}
+
+	// A failure closes the listeners already opened.
+	first, err := listenAll([]string{"127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("listenAll() returned error: %v", err)
+	}
+	defer first[0].Close()
+	busy := first[0].Addr().String()
+	if _, err := listenAll([]string{"127.0.0.1:0", busy}); err == nil {
+		t.Error("listenAll() should return error for an address in use")
+	}
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/go-kit/log/level"
 	ovs "github.com/Liquescent-Development/ovs_exporter/pkg/ovs_exporter"
+	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -18,48 +23,190 @@ func main() {
 	var pollTimeout int
 	var pollInterval int
 	var isShowVersion bool
+	var healthcheckMode bool
 	var logLevel string
+	var logDedupWindow int
 	var systemRunDir string
+	var systemRunDirs string
+	var systemID string
 	var databaseVswitchName string
 	var databaseVswitchSocketRemote string
 	var databaseVswitchFileDataPath string
 	var databaseVswitchFileLogPath string
 	var databaseVswitchFilePidPath string
 	var databaseVswitchFileSystemIDPath string
+	var databaseExtraFileDataPaths string
 	var serviceVswitchdFileLogPath string
 	var serviceVswitchdFilePidPath string
 	var serviceOvnControllerFileLogPath string
 	var serviceOvnControllerFilePidPath string
+	var pmdOverloadThreshold float64
+	var pmdOverloadPolls int
+	var execWrappers string
+	var webUser string
+	var webGroup string
+	var webEnableAdminAPI bool
+	var webEnablePprof bool
+	var webAdminTokenFile string
+	var webCollectMinInterval int
+	var webMaxRequests int
+	var webSystemdSocket bool
+	var datapathFlowAgeEnabled bool
+	var datapathFlowOffloadEnabled bool
+	var ovsdbProbeEnabled bool
+	var vswitchdProbeEnabled bool
+	var bridgeMetersEnabled bool
+	var bridgeOfprotoEnabled bool
+	var tunnelNeighborsEnabled bool
+	var bridgeBondsEnabled bool
+	var vlogEnabled bool
+	var dpdkLogEnabled bool
+	var datapathMasksExpectedMax int
+	var interfaceKeyAllowlist string
+	var dpdkLogLevels string
+	var pollAsync bool
+	var pollJitter int
+	var pollTimestamps bool
+	var coverageRatesEnabled bool
+	var coverageFullPolls int
+	var labelValueReplacement string
+	var labelRedactKeys string
+	var metricsAddHostnameLabel bool
+	var bridgeControllerRttEnabled bool
+	var interfaceKernelEnabled bool
+	var systemSysfsPath string
+	var databaseNorthboundSocketRemote string
+	var databaseSouthboundSocketRemote string
+	var mock bool
+	var interfaceStatisticsExtra string
+	var interfaceStatisticsWrap32 string
+	var interfaceStatisticsInternalSkipZero bool
+	var processCPUAffinity string
+	var processSchedPolicy string
+	var processNice int
+	var interfaceMax int
+	var interfaceInconsistencyPolls int
+	var serviceOvnControllerStatsEnabled bool
+	var serviceOvnControllerCtZonesEnabled bool
+	var serviceOvnControllerFlowsEnabled bool
+	var vlogModulesEnabled bool
+	var serviceOvnMemoryTargets string
+	var fileSDPath string
+	var fileSDAddress string
 
-	flag.StringVar(&listenAddress, "web.listen-address", ":9475", "Address to listen on for web interface and telemetry.")
+	flag.StringVar(&listenAddress, "web.listen-address", ":9475", "Comma-separated list of addresses to listen on for web interface and telemetry, e.g. 127.0.0.1:9475,[::1]:9475. IPv6 literals must be bracketed.")
 	flag.StringVar(&metricsPath, "web.telemetry-path", "/metrics", "Path under which to expose metrics.")
+	flag.StringVar(&webUser, "web.user", "", "The user to switch to after binding the listen address and connecting to OVS.")
+	flag.StringVar(&webGroup, "web.group", "", "The group to switch to after binding the listen address and connecting to OVS. Defaults to the primary group of -web.user.")
+	flag.BoolVar(&webEnableAdminAPI, "web.enable-admin-api", false, "Enable the admin endpoints, e.g. POST /-/collect forcing an immediate collection and GET /api/v1/collectors listing the collectors and their status.")
+	flag.BoolVar(&webEnablePprof, "web.enable-pprof", false, "Serve the Go runtime profiles of the exporter under /debug/pprof/.")
+	flag.BoolVar(&webSystemdSocket, "web.systemd-socket", false, "Use the socket passed by systemd socket activation instead of listening on -web.listen-address.")
+	flag.StringVar(&webAdminTokenFile, "web.admin-token-file", "", "The file holding the bearer token required by the admin endpoints. Enables POST /api/v1/vlog changing the log levels of the OVS daemons.")
+	flag.IntVar(&webMaxRequests, "web.max-requests", 0, "The maximum number of concurrent scrapes. Further scrapes are rejected with 503 Service Unavailable. 0 disables the limit.")
+	flag.IntVar(&webCollectMinInterval, "web.collect.min-interval", 10, "The minimum interval (in seconds) between collections forced with POST /-/collect.")
 	flag.IntVar(&pollTimeout, "ovs.timeout", 2, "Timeout on JSON-RPC requests to OVS.")
-	flag.IntVar(&pollInterval, "ovs.poll-interval", 15, "The minimum interval (in seconds) between collections from OVS server.")
+	flag.IntVar(&pollInterval, "ovs.poll-interval", 15, "The minimum interval (in seconds) between collections from OVS server. 0 collects on every scrape and streams the metrics instead of keeping them in memory.")
+	flag.BoolVar(&pollAsync, "ovs.poll-async", false, "Collect from OVS server in the background every poll interval and serve the latest metrics on scrapes, instead of collecting on scrapes.")
+	flag.IntVar(&pollJitter, "ovs.poll-jitter", 0, "The maximum random delay (in seconds) of the first background collection, staggering the polls of exporters started at the same time. Requires -ovs.poll-async.")
+	flag.BoolVar(&pollTimestamps, "ovs.poll-timestamps", false, "Attach the time of the last collection from OVS to the samples, so that cached data is not attributed to the time of the scrape.")
 	flag.BoolVar(&isShowVersion, "version", false, "version information")
+	flag.BoolVar(&healthcheckMode, "healthcheck", false, "Query the "+readyPath+" endpoint of the exporter running on -web.listen-address and exit with 0 when it is ready, 1 otherwise, e.g. for the HEALTHCHECK directive of container images without curl.")
+	flag.BoolVar(&mock, "mock", false, "Serve synthetic metrics of a bundled fixture without connecting to OVS, e.g. for end-to-end tests of dashboards and alerts in CI.")
 	flag.StringVar(&logLevel, "log.level", "info", "logging severity level")
+	flag.IntVar(&logDedupWindow, "log.dedup.window", 300, "The window (in seconds) within which repeated warnings and errors, e.g. logged on every poll while OVS is down, are not logged again. The next occurrence is logged with the number of repetitions. 0 disables the deduplication.")
+	flag.StringVar(&processCPUAffinity, "process.cpu.affinity", "", "List of CPUs the exporter is pinned to at startup, e.g. 0-1,16, keeping it off the cores isolated for PMD threads. Empty leaves the affinity unchanged.")
+	flag.StringVar(&processSchedPolicy, "process.sched.policy", "", "The scheduling policy of the exporter: other, batch or idle. Empty leaves the policy unchanged.")
+	flag.IntVar(&processNice, "process.nice", 0, "The nice value of the exporter (-20 to 19). Negative values require CAP_SYS_NICE.")
 
 	flag.StringVar(&systemRunDir, "system.run.dir", "/var/run/openvswitch", "OVS default run directory.")
+	flag.StringVar(&systemRunDirs, "system.run.dirs", "", "Comma-separated list of shell patterns of the run directories of several OVS instances, e.g. the sandboxes of ovs-sandbox, each holding the sockets, pid, log and database files of its instance. The metrics of each instance are labeled with sandbox, the base name of its run directory. Overrides the paths of the OVS files.")
+
+	flag.StringVar(&systemID, "system.id", "", "Pins the system_id label of the metrics, keeping the series of the host continuous when the system-id of OVS changes, e.g. after re-provisioning. Empty uses the system-id of OVS.")
+
+	flag.StringVar(&systemSysfsPath, "system.sysfs.path", "/sys", "The mount point of sysfs, e.g. /host/sys in containers.")
 
 	flag.StringVar(&databaseVswitchName, "database.vswitch.name", "Open_vSwitch", "The name of OVS db.")
-	flag.StringVar(&databaseVswitchSocketRemote, "database.vswitch.socket.remote", "unix:/var/run/openvswitch/db.sock", "JSON-RPC unix socket to OVS db.")
+	flag.StringVar(&databaseVswitchSocketRemote, "database.vswitch.socket.remote", "unix:/var/run/openvswitch/db.sock", "JSON-RPC socket to OVS db, e.g. unix:/var/run/openvswitch/db.sock or tcp:[2001:db8::1]:6640.")
 	flag.StringVar(&databaseVswitchFileDataPath, "database.vswitch.file.data.path", "/etc/openvswitch/conf.db", "OVS db file.")
 	flag.StringVar(&databaseVswitchFileLogPath, "database.vswitch.file.log.path", "/var/log/openvswitch/ovsdb-server.log", "OVS db log file.")
 	flag.StringVar(&databaseVswitchFilePidPath, "database.vswitch.file.pid.path", "/var/run/openvswitch/ovsdb-server.pid", "OVS db process id file.")
 	flag.StringVar(&databaseVswitchFileSystemIDPath, "database.vswitch.file.system.id.path", "/etc/openvswitch/system-id.conf", "OVS system id file (fallback if not in database).")
+	flag.StringVar(&databaseNorthboundSocketRemote, "database.northbound.socket.remote", "", "JSON-RPC socket to the OVN_Northbound db, e.g. unix:/var/run/ovn/ovnnb_db.sock. Disabled when empty.")
+	flag.StringVar(&databaseSouthboundSocketRemote, "database.southbound.socket.remote", "", "JSON-RPC socket to the OVN_Southbound db, e.g. unix:/var/run/ovn/ovnsb_db.sock. Disabled when empty.")
+	flag.StringVar(&databaseExtraFileDataPaths, "database.extra.file.data.paths", "", "Comma-separated list of NAME=PATH pairs of additional OVSDB database files served by the same ovsdb-server, e.g. OVN_Northbound=/var/lib/ovn/ovnnb_db.db.")
 
 	flag.StringVar(&serviceVswitchdFileLogPath, "service.vswitchd.file.log.path", "/var/log/openvswitch/ovs-vswitchd.log", "OVS vswitchd daemon log file.")
 	flag.StringVar(&serviceVswitchdFilePidPath, "service.vswitchd.file.pid.path", "/var/run/openvswitch/ovs-vswitchd.pid", "OVS vswitchd daemon process id file.")
 
 	flag.StringVar(&serviceOvnControllerFileLogPath, "service.ovncontroller.file.log.path", "/var/log/openvswitch/ovn-controller.log", "OVN controller daemon log file.")
 	flag.StringVar(&serviceOvnControllerFilePidPath, "service.ovncontroller.file.pid.path", "/var/run/openvswitch/ovn-controller.pid", "OVN controller daemon process id file.")
+	flag.BoolVar(&serviceOvnControllerCtZonesEnabled, "service.ovncontroller.ctzones.enabled", false, "Count the conntrack connections of the zones assigned by ovn-controller to logical ports and routers with ovs-appctl dpctl/dump-conntrack. Dumping conntrack is expensive with large connection tables.")
+	flag.BoolVar(&serviceOvnControllerFlowsEnabled, "service.ovncontroller.flows.enabled", false, "Count the OpenFlow flows of the integration bridge by OVN logical datapath, mapping their cookies to the logical flows of the southbound database. Requires -database.southbound.socket.remote. Dumping the flows is expensive with large flow tables.")
+	flag.StringVar(&serviceOvnMemoryTargets, "service.ovn.memory.targets", "", "Comma-separated list of NAME=TARGET pairs of OVN daemons and their control sockets whose memory/show counters are collected with ovn-appctl, e.g. ovnsb_db=/var/run/ovn/ovnsb_db.ctl,ovn-northd=ovn-northd.")
+	flag.BoolVar(&vlogModulesEnabled, "vlog.modules.enabled", false, "Export the log level of each logging module of ovsdb-server and ovs-vswitchd, in addition to the number of modules at each level. Requires -vlog.enabled.")
+	flag.BoolVar(&serviceOvnControllerStatsEnabled, "service.ovncontroller.stats.enabled", false, "Collect the incremental processing engine statistics of ovn-controller with ovn-appctl and the northbound configuration whose flows it installed.")
+
+	flag.Float64Var(&pmdOverloadThreshold, "pmd.overload.threshold", 0.9, "PMD busy ratio (0-1) above which a PMD thread is considered overloaded.")
+	flag.IntVar(&pmdOverloadPolls, "pmd.overload.polls", 3, "The number of consecutive polls the PMD busy ratio must exceed the threshold before reporting the PMD thread as overloaded.")
+
+	flag.StringVar(&labelValueReplacement, "label.value.replacement", ovs.DefaultLabelValueReplacement, "The replacement of invalid UTF-8 sequences, control characters, e.g. newlines, and quotes in label values. Empty removes them.")
+	flag.BoolVar(&metricsAddHostnameLabel, "metrics.add-hostname-label", false, "Add the hostname of OVS, read at startup, as hostname label to all series, e.g. for federation layers rewriting the instance label.")
+	flag.StringVar(&labelRedactKeys, "label.redact.keys", ovs.DefaultRedactKeys, "Regular expression matching the whole keys of the interface status, options and external_ids pairs whose values are exported as REDACTED, e.g. psk|tenant_.*. Empty disables redaction.")
+	flag.BoolVar(&coverageRatesEnabled, "coverage.rates.enabled", false, "Export per-minute rates of the coverage counters computed from consecutive polls, for dashboards without recording rules.")
+	flag.IntVar(&coverageFullPolls, "coverage.full.polls", 1, "The number of polls between the refreshes of the full set of coverage counters of ovs-vswitchd and ovsdb-server, served from a cache in between to reduce the load of coverage/show. The drop counters are refreshed on every poll.")
+
+	flag.StringVar(&interfaceKeyAllowlist, "interface.key.allowlist", "", "Comma-separated list of FAMILY:KEY pairs restricting the keys exported by the interface status, options and external_ids metrics, e.g. options:remote_ip,status:tunnel_egress_iface. Families without entries export all keys.")
+	flag.StringVar(&interfaceStatisticsWrap32, "interface.statistics.wrap32", "", "Comma-separated list of shell patterns of the Interface:statistics keys reported as 32-bit counters by some drivers, e.g. rx_crc_err,rx_q*_errors. Their wraps are counted and compensated.")
+	flag.BoolVar(&interfaceStatisticsInternalSkipZero, "interface.statistics.internal.skip-zero", false, "Skip the statistics of the internal interfaces, e.g. the local ports of the bridges, after the first poll as long as they are all zero, to reduce the size of the scrapes on large hypervisors.")
+	flag.StringVar(&interfaceStatisticsExtra, "interface.statistics.extra", "", "Comma-separated list of KEY[=TYPE] pairs of additional Interface:statistics keys to export as ovs_interface_stat_KEY, e.g. rx_q0_good_packets,rx_q0_errors=gauge. TYPE is counter (default) or gauge.")
+	flag.IntVar(&interfaceInconsistencyPolls, "interface.inconsistency.polls", 3, "The number of consecutive polls an interface must be in an inconsistent state, e.g. enabled without link, before ovs_interface_inconsistent reports it.")
+	flag.IntVar(&interfaceMax, "interface.max", 0, "The maximum number of interfaces exported on each poll, in the order of their names. Only the names and UUIDs of the other interfaces are read, protecting the exporter from runaway numbers of interfaces. 0 disables the limit.")
+	flag.BoolVar(&interfaceKernelEnabled, "interface.kernel.enabled", false, "Read the carrier changes, operational state and speed of system interfaces from the kernel as a cross-check of the Interface table.")
+	flag.BoolVar(&bridgeControllerRttEnabled, "bridge.controller.rtt.enabled", false, "Measure the OpenFlow echo round-trip time of the tcp: and unix: controllers of the bridges by connecting to them on each poll as an OpenFlow 1.3 switch. The exporter answers no FEATURES_REQUEST, so controllers may log protocol errors or briefly register a phantom datapath on each poll. The time is the one of the path from the exporter to the controller, not of the connection of ovs-vswitchd, which OVS does not report.")
+	flag.BoolVar(&datapathFlowAgeEnabled, "datapath.flow.age.enabled", false, "Sample the time since datapath flows were last used with ovs-appctl dpctl/dump-flows. Dumping flows is expensive with large flow tables.")
+	flag.BoolVar(&ovsdbProbeEnabled, "ovsdb.probe.enabled", false, "Time a read transaction against ovsdb-server on each poll, recorded in the ovs_ovsdb_probe_duration_seconds histogram.")
+	flag.BoolVar(&vswitchdProbeEnabled, "vswitchd.probe.enabled", false, "Time the version unixctl command of ovs-vswitchd on each poll, recorded in the ovs_vswitchd_probe_duration_seconds histogram. Runs ovs-appctl on each poll.")
+	flag.BoolVar(&bridgeMetersEnabled, "bridge.meters.enabled", false, "Export the configuration and statistics of the OpenFlow meters of the bridges with ovs-ofctl dump-meters and meter-stats. Runs two ovs-ofctl commands per bridge on each poll.")
+	flag.BoolVar(&bridgeOfprotoEnabled, "bridge.ofproto.enabled", false, "Export the datapath backing the OpenFlow switch of each bridge with ovs-appctl ofproto/list. Runs ovs-appctl on each poll.")
+	flag.BoolVar(&tunnelNeighborsEnabled, "tunnel.neighbors.enabled", false, "Count the entries of the tunnel neighbor cache of ovs-vswitchd with ovs-appctl tnl/neigh/show, or tnl/arp/show on older releases. Runs ovs-appctl on each poll.")
+	flag.BoolVar(&bridgeBondsEnabled, "bridge.bonds.enabled", false, "Export the active member of the bonds and count its changes with ovs-appctl bond/show. Runs ovs-appctl on each poll.")
+	flag.BoolVar(&vlogEnabled, "vlog.enabled", false, "Export the number of logging modules of ovsdb-server and ovs-vswitchd at each log level with ovs-appctl vlog/list. Runs ovs-appctl twice on each poll.")
+	flag.BoolVar(&dpdkLogEnabled, "dpdk.log.enabled", false, "Export the levels of the DPDK log types with ovs-appctl dpdk/log-list, and their drift from -dpdk.log.levels. Runs ovs-appctl on each poll.")
+	flag.BoolVar(&datapathFlowOffloadEnabled, "datapath.flow.offload.enabled", false, "Count the datapath flows, and their packets and bytes, by offload state with ovs-appctl dpctl/dump-flows -m. Dumping flows is expensive with large flow tables.")
+	flag.IntVar(&datapathMasksExpectedMax, "datapath.masks.expected-max", 0, "The expected maximum number of masks of a datapath, which ovs_dp_masks_usage_ratio is relative to, for alerting on mask explosions. 0 disables the ratio.")
+
+	flag.StringVar(&dpdkLogLevels, "dpdk.log.levels", "", "Comma-separated list of PATTERN=LEVEL pairs with the desired levels of DPDK log types, e.g. global=info,pmd.net.*=notice. Mismatches are reported by ovs_dpdk_log_level_drift. Requires -dpdk.log.enabled.")
+
+	flag.StringVar(&fileSDPath, "file-sd.path", "-", "The file the file-sd command writes the Prometheus file_sd_configs target of the exporter to. - writes to the standard output.")
+	flag.StringVar(&fileSDAddress, "file-sd.address", "", "The address of the target written by the file-sd command. Defaults to -web.listen-address, with the hostname of OVS as host when the listen address has none.")
+
+	flag.StringVar(&execWrappers, "exec.wrappers", "", "Comma-separated list of COMMAND=WRAPPER pairs prefixing external commands, e.g. ovs-appctl=sudo -n. Use * as COMMAND to wrap all commands.")
 
 	var usageHelp = func() {
 		fmt.Fprintf(os.Stderr, "\n%s - Prometheus Exporter for Open Virtual Switch (OVS)\n\n", ovs.GetExporterName())
-		fmt.Fprintf(os.Stderr, "Usage: %s [arguments]\n\n", ovs.GetExporterName())
+		fmt.Fprintf(os.Stderr, "Usage: %s [command] [arguments]\n\n", ovs.GetExporterName())
+		fmt.Fprintf(os.Stderr, "Commands:\n")
+		fmt.Fprintf(os.Stderr, "  check-config\tvalidate the configuration and print the active collectors\n")
+		fmt.Fprintf(os.Stderr, "  doctor\trun each collector once and report the failures with suggested remedies\n")
+		fmt.Fprintf(os.Stderr, "  file-sd\twrite the Prometheus file_sd_configs target of the exporter to -file-sd.path\n\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nDocumentation: https://github.com/greenpau/ovs_exporter/\n\n")
 	}
 	flag.Usage = usageHelp
+
+	var command string
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		command = os.Args[1]
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+	switch command {
+	case "", "check-config", "doctor", "file-sd":
+	default:
+		fmt.Fprintf(os.Stderr, "unsupported command: %s\n", command)
+		flag.Usage()
+		os.Exit(1)
+	}
+
 	flag.Parse()
 
 	if isShowVersion {
@@ -72,6 +219,21 @@ func main() {
 		os.Exit(0)
 	}
 
+	listenAddresses, err := parseListenAddresses(listenAddress)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if healthcheckMode {
+		if err := healthcheck(listenAddresses[0], 5*time.Second); err != nil {
+			fmt.Fprintf(os.Stderr, "unhealthy: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stdout, "ok")
+		os.Exit(0)
+	}
+
 	logger, err := ovs.NewLogger(logLevel)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed initializing logger: %v", err)
@@ -85,42 +247,363 @@ func main() {
 		"build_context", ovs.GetVersionBuildContext(),
 	)
 
+	if err := setScheduling(processCPUAffinity, processSchedPolicy, processNice); err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to set scheduling",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	databaseFilePaths, err := ovs.ParseDatabaseFilePaths(databaseExtraFileDataPaths)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse database file paths",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	commandWrappers, err := ovs.ParseCommandWrappers(execWrappers)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse command wrappers",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	keyAllowlists, err := ovs.ParseKeyAllowlists(interfaceKeyAllowlist)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse interface key allowlist",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	interfaceStats, err := ovs.ParseInterfaceStats(interfaceStatisticsExtra)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse additional interface statistics",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	counter32Keys, err := ovs.ParseCounterWrapKeys(interfaceStatisticsWrap32)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse 32-bit interface statistics keys",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	redactKeys, err := ovs.ParseRedactKeys(labelRedactKeys)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse redaction keys",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	desiredDpdkLogLevels, err := ovs.ParseDpdkLogLevels(dpdkLogLevels)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse DPDK log levels",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	ovnMemoryTargets, err := ovs.ParseOvnMemoryTargets(serviceOvnMemoryTargets)
+	if err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to parse OVN memory targets",
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+
+	for _, remote := range []string{databaseVswitchSocketRemote, databaseNorthboundSocketRemote, databaseSouthboundSocketRemote} {
+		if remote == "" {
+			continue
+		}
+		if _, _, err := ovs.ParseRemote(remote); err != nil {
+			level.Error(logger).Log(
+				"msg", "invalid database remote",
+				"error", err.Error(),
+			)
+			os.Exit(1)
+		}
+	}
+
+	if serviceOvnControllerFlowsEnabled && databaseSouthboundSocketRemote == "" {
+		level.Error(logger).Log(
+			"msg", "-service.ovncontroller.flows.enabled requires -database.southbound.socket.remote",
+		)
+		os.Exit(1)
+	}
+
+	if systemID != "" && systemRunDirs != "" {
+		level.Error(logger).Log(
+			"msg", "-system.id cannot be used with -system.run.dirs",
+		)
+		os.Exit(1)
+	}
+
 	opts := ovs.Options{
-		Timeout: pollTimeout,
-		Logger:  logger,
+		Timeout:           pollTimeout,
+		Logger:            logger,
+		DatabaseFilePaths: databaseFilePaths,
+
+		PmdOverloadThreshold: pmdOverloadThreshold,
+		PmdOverloadPolls:     pmdOverloadPolls,
+		CommandWrappers:      commandWrappers,
+		MegaflowAgeEnabled:   datapathFlowAgeEnabled,
+		FlowOffloadEnabled:   datapathFlowOffloadEnabled,
+		OvsdbProbeEnabled:    ovsdbProbeEnabled,
+		VswitchdProbeEnabled: vswitchdProbeEnabled,
+		MetersEnabled:        bridgeMetersEnabled,
+		OfprotoEnabled:       bridgeOfprotoEnabled,
+		TunnelNeighEnabled:   tunnelNeighborsEnabled,
+		BondsEnabled:         bridgeBondsEnabled,
+		VlogEnabled:          vlogEnabled,
+		DpdkLogEnabled:       dpdkLogEnabled,
+		DpMasksExpectedMax:   datapathMasksExpectedMax,
+		KeyAllowlists:        keyAllowlists,
+		DpdkLogLevels:        desiredDpdkLogLevels,
+		ControllerRttEnabled: bridgeControllerRttEnabled,
+		SysfsPath:            systemSysfsPath,
+
+		KernelInterfaceEnabled: interfaceKernelEnabled,
+		OvnNorthboundSocket:    databaseNorthboundSocketRemote,
+		OvnSouthboundSocket:    databaseSouthboundSocketRemote,
+		MetricTimestamps:       pollTimestamps,
+		CoverageRatesEnabled:   coverageRatesEnabled,
+		CoverageFullPolls:      coverageFullPolls,
+		LabelValueReplacement:  labelValueReplacement,
+		RedactKeys:             redactKeys,
+		InterfaceStats:         interfaceStats,
+		Counter32Keys:          counter32Keys,
+		InternalZeroStatsSkip:  interfaceStatisticsInternalSkipZero,
+		MaxInterfaces:          interfaceMax,
+
+		InterfaceInconsistencyPolls: interfaceInconsistencyPolls,
+		OvnControllerEnabled:        serviceOvnControllerStatsEnabled,
+		OvnCtZonesEnabled:           serviceOvnControllerCtZonesEnabled,
+		OvnLogicalFlowsEnabled:      serviceOvnControllerFlowsEnabled,
+		VlogModulesEnabled:          vlogModulesEnabled,
+		OvnMemoryTargets:            ovnMemoryTargets,
+		LogDedupWindow:              time.Duration(logDedupWindow) * time.Second,
+		Registry:                    prometheus.DefaultRegisterer,
+		SystemID:                    systemID,
+		HostnameLabel:               metricsAddHostnameLabel,
 	}
 
-	exporter := ovs.NewExporter(opts)
+	newExporter := func() *ovs.Exporter {
+		exporter := ovs.NewExporter(opts)
+
+		exporter.Client.System.RunDir = systemRunDir
+
+		exporter.Client.Database.Vswitch.Name = databaseVswitchName
+		exporter.Client.Database.Vswitch.Socket.Remote = databaseVswitchSocketRemote
+		exporter.Client.Database.Vswitch.File.Data.Path = databaseVswitchFileDataPath
+		exporter.Client.Database.Vswitch.File.Log.Path = databaseVswitchFileLogPath
+		exporter.Client.Database.Vswitch.File.Pid.Path = databaseVswitchFilePidPath
+		exporter.Client.Database.Vswitch.File.SystemID.Path = databaseVswitchFileSystemIDPath
+
+		exporter.Client.Service.Vswitchd.File.Log.Path = serviceVswitchdFileLogPath
+		exporter.Client.Service.Vswitchd.File.Pid.Path = serviceVswitchdFilePidPath
 
-	exporter.Client.System.RunDir = systemRunDir
+		exporter.Client.Service.OvnController.File.Log.Path = serviceOvnControllerFileLogPath
+		exporter.Client.Service.OvnController.File.Pid.Path = serviceOvnControllerFilePidPath
+		return exporter
+	}
 
-	exporter.Client.Database.Vswitch.Name = databaseVswitchName
-	exporter.Client.Database.Vswitch.Socket.Remote = databaseVswitchSocketRemote
-	exporter.Client.Database.Vswitch.File.Data.Path = databaseVswitchFileDataPath
-	exporter.Client.Database.Vswitch.File.Log.Path = databaseVswitchFileLogPath
-	exporter.Client.Database.Vswitch.File.Pid.Path = databaseVswitchFilePidPath
-	exporter.Client.Database.Vswitch.File.SystemID.Path = databaseVswitchFileSystemIDPath
+	targets := []target{{exporter: newExporter()}}
+	if systemRunDirs != "" {
+		runDirs, err := ovs.ParseRunDirs(systemRunDirs)
+		if err != nil {
+			level.Error(logger).Log(
+				"msg", "failed to parse run directories",
+				"error", err.Error(),
+			)
+			os.Exit(1)
+		}
+		targets = sandboxTargets(runDirs, newExporter)
+	}
 
-	exporter.Client.Service.Vswitchd.File.Log.Path = serviceVswitchdFileLogPath
-	exporter.Client.Service.Vswitchd.File.Pid.Path = serviceVswitchdFilePidPath
+	if command == "check-config" || command == "doctor" {
+		failed := false
+		for _, t := range targets {
+			if t.sandbox != "" {
+				fmt.Fprintf(os.Stdout, "== sandbox %s ==\n", t.sandbox)
+			}
+			check, failure := t.exporter.CheckConfig, "configuration check failed"
+			if command == "doctor" {
+				check, failure = t.exporter.Doctor, "self-check failed"
+			}
+			if err := check(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", failure, err)
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if command == "file-sd" {
+		if err := writeFileSD(targets, listenAddresses[0], fileSDAddress, fileSDPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write the file_sd target: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
-	exporter.Client.Service.OvnController.File.Log.Path = serviceOvnControllerFileLogPath
-	exporter.Client.Service.OvnController.File.Pid.Path = serviceOvnControllerFilePidPath
-	if err := exporter.Connect(); err != nil {
+	var listeners []net.Listener
+	if webSystemdSocket {
+		level.Info(logger).Log("msg", "listening on the socket passed by systemd")
+		var listener net.Listener
+		listener, err = systemdListener()
+		listeners = append(listeners, listener)
+	} else {
+		level.Info(logger).Log("listen_on ", strings.Join(listenAddresses, ","))
+		listeners, err = listenAll(listenAddresses)
+	}
+	if err != nil {
 		level.Error(logger).Log(
-			"msg", "failed to init properly",
+			"msg", "listener failed",
 			"error", err.Error(),
 		)
 		os.Exit(1)
 	}
 
-	level.Info(logger).Log("ovs_system_id", exporter.Client.System.ID)
+	var mockCollector *ovs.MockCollector
+	if mock {
+		mockCollector, err = ovs.NewMockCollector()
+		if err != nil {
+			level.Error(logger).Log(
+				"msg", "failed to init mock backend",
+				"error", err.Error(),
+			)
+			os.Exit(1)
+		}
+		level.Warn(logger).Log("msg", "serving synthetic metrics of the mock backend, OVS is not queried")
+	} else {
+		// A sandbox failing to connect is skipped, while the others are
+		// collected.
+		connected := targets[:0]
+		for _, t := range targets {
+			if err := t.exporter.Connect(); err != nil {
+				level.Error(logger).Log(
+					"msg", "failed to init properly",
+					"sandbox", t.sandbox,
+					"error", err.Error(),
+				)
+				continue
+			}
+			level.Info(logger).Log("ovs_system_id", t.exporter.Client.System.ID, "sandbox", t.sandbox)
+			connected = append(connected, t)
+		}
+		if len(connected) == 0 {
+			os.Exit(1)
+		}
+		targets = connected
+	}
 
-	exporter.SetPollInterval(int64(pollInterval))
-	prometheus.MustRegister(exporter)
+	// The paths must be found before dropping the privileges, which may
+	// prevent listing the run directory.
+	var paths []privilegedPath
+	if !mock {
+		for _, t := range targets {
+			paths = append(paths, privilegedPaths(t.exporter)...)
+		}
+	}
+	if err := dropPrivileges(webUser, webGroup); err != nil {
+		level.Error(logger).Log(
+			"msg", "failed to drop privileges",
+			"user", webUser,
+			"group", webGroup,
+			"error", err.Error(),
+		)
+		os.Exit(1)
+	}
+	if webUser != "" || webGroup != "" {
+		level.Info(logger).Log(
+			"msg", "dropped privileges",
+			"uid", os.Getuid(),
+			"gid", os.Getgid(),
+		)
+		if denied := inaccessiblePaths(paths); len(denied) > 0 {
+			level.Error(logger).Log(
+				"msg", "files read on every collection are not accessible after dropping privileges, grant -web.user or -web.group access to them",
+				"paths", strings.Join(denied, ","),
+			)
+			os.Exit(1)
+		}
+	}
+
+	if mock {
+		prometheus.MustRegister(mockCollector)
+	} else {
+		for _, t := range targets {
+			t.exporter.SetPollInterval(int64(pollInterval))
+			if pollAsync {
+				t.exporter.SetPollJitter(time.Duration(pollJitter) * time.Second)
+				t.exporter.StartBackgroundCollection(context.Background())
+			}
+		}
+		registerTargets(targets, metricsAddHostnameLabel)
+	}
 
-	http.Handle(metricsPath, promhttp.Handler())
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	// Compressed responses are negotiated with Accept-Encoding, while the
+	// OpenMetrics format, carrying exemplars and created timestamps, is
+	// negotiated with the Accept header.
+	mux.Handle(metricsPath, promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics:   true,
+			MaxRequestsInFlight: webMaxRequests,
+		}),
+	))
+	if webEnableAdminAPI && !mock {
+		admin := func(h http.Handler) http.Handler { return h }
+		if webAdminTokenFile != "" {
+			token, err := os.ReadFile(webAdminTokenFile)
+			if err != nil || strings.TrimSpace(string(token)) == "" {
+				level.Error(logger).Log(
+					"msg", "failed to read the admin token",
+					"file", webAdminTokenFile,
+					"error", fmt.Sprint(err),
+				)
+				os.Exit(1)
+			}
+			admin = func(h http.Handler) http.Handler {
+				return ovs.RequireBearerToken(strings.TrimSpace(string(token)), h)
+			}
+			// Changing the log levels of OVS requires authentication.
+			mux.Handle("/api/v1/vlog", admin(targetHandler(targets, (*ovs.Exporter).VlogHandler)))
+		}
+		mux.Handle("/-/collect", admin(targetHandler(targets, func(e *ovs.Exporter) http.Handler {
+			return e.CollectHandler(time.Duration(webCollectMinInterval) * time.Second)
+		})))
+		mux.Handle("/api/v1/collectors", admin(targetHandler(targets, (*ovs.Exporter).CollectorsHandler)))
+	}
+	mux.Handle(readyPath, readyHandler(targets))
+	if webEnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
              <head><title>OVS Exporter</title></head>
              <body>
@@ -130,8 +613,13 @@ func main() {
              </html>`))
 	})
 
-	level.Info(logger).Log("listen_on ", listenAddress)
-	if err := http.ListenAndServe(listenAddress, nil); err != nil {
+	serveErrors := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			serveErrors <- http.Serve(listener, mux)
+		}(listener)
+	}
+	if err := <-serveErrors; err != nil {
 		level.Error(logger).Log(
 			"msg", "listener failed",
 			"error", err.Error(),
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	ovs "github.com/Liquescent-Development/ovs_exporter/pkg/ovs_exporter"
+)
+
+// lookupCredentials resolves the user and group to switch to. When the
+// group is empty, the primary group of the user is used. An empty user
+// is returned as uid -1.
+func lookupCredentials(userName, groupName string) (int, int, error) {
+	uid, gid := -1, -1
+	if userName != "" {
+		u, err := user.Lookup(userName)
+		if err != nil {
+			return -1, -1, err
+		}
+		if uid, err = strconv.Atoi(u.Uid); err != nil {
+			return -1, -1, fmt.Errorf("invalid uid %q for user %s", u.Uid, userName)
+		}
+		if gid, err = strconv.Atoi(u.Gid); err != nil {
+			return -1, -1, fmt.Errorf("invalid gid %q for user %s", u.Gid, userName)
+		}
+	}
+	if groupName != "" {
+		g, err := user.LookupGroup(groupName)
+		if err != nil {
+			return -1, -1, err
+		}
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return -1, -1, fmt.Errorf("invalid gid %q for group %s", g.Gid, groupName)
+		}
+	}
+	return uid, gid, nil
+}
+
+// dropPrivileges switches the process to the provided user and group.
+// When the group is empty, the primary group of the user is used. File
+// descriptors opened before the call, e.g. the listening socket and the
+// connection to the OVS database, remain usable.
+func dropPrivileges(userName, groupName string) error {
+	if userName == "" && groupName == "" {
+		return nil
+	}
+	uid, gid, err := lookupCredentials(userName, groupName)
+	if err != nil {
+		return err
+	}
+
+	// The group must be changed first, because changing the user gives up
+	// the privilege to do so.
+	if err := syscall.Setgroups([]int{gid}); err != nil {
+		return fmt.Errorf("setgroups(%d) failed: %s", gid, err)
+	}
+	if err := syscall.Setgid(gid); err != nil {
+		return fmt.Errorf("setgid(%d) failed: %s", gid, err)
+	}
+	if uid >= 0 {
+		if err := syscall.Setuid(uid); err != nil {
+			return fmt.Errorf("setuid(%d) failed: %s", uid, err)
+		}
+	}
+	return nil
+}
+
+// privilegedPath is a path that the exporter reopens on every collection
+// and the access it requires.
+type privilegedPath struct {
+	path string
+	mode uint32
+}
+
+// privilegedPaths returns the existing log, pid and database files of an
+// exporter and the control sockets of its run directory. They are opened
+// on every collection, after the privileges were dropped.
+func privilegedPaths(e *ovs.Exporter) []privilegedPath {
+	const read, write, exec = 4, 2, 1
+	var paths []privilegedPath
+	for _, path := range []string{
+		e.Client.Database.Vswitch.File.Data.Path,
+		e.Client.Database.Vswitch.File.Log.Path,
+		e.Client.Database.Vswitch.File.Pid.Path,
+		e.Client.Service.Vswitchd.File.Log.Path,
+		e.Client.Service.Vswitchd.File.Pid.Path,
+		e.Client.Service.OvnController.File.Log.Path,
+		e.Client.Service.OvnController.File.Pid.Path,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, privilegedPath{path, read})
+		}
+	}
+	if rundir := e.Client.System.RunDir; rundir != "" {
+		if _, err := os.Stat(rundir); err == nil {
+			paths = append(paths, privilegedPath{rundir, read | exec})
+		}
+		sockets, _ := filepath.Glob(filepath.Join(rundir, "*.ctl"))
+		for _, socket := range sockets {
+			paths = append(paths, privilegedPath{socket, read | write})
+		}
+	}
+	return paths
+}
+
+// inaccessiblePaths returns the paths that the process cannot access with
+// its current user and group.
+func inaccessiblePaths(paths []privilegedPath) []string {
+	var denied []string
+	for _, p := range paths {
+		if err := syscall.Access(p.path, p.mode); err != nil {
+			denied = append(denied, p.path)
+		}
+	}
+	return denied
+}
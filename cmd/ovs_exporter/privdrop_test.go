@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	ovs "github.com/Liquescent-Development/ovs_exporter/pkg/ovs_exporter"
+)
+
+func TestLookupCredentials(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("current user unknown: %v", err)
+	}
+	group, err := user.LookupGroupId(current.Gid)
+	if err != nil {
+		t.Skipf("primary group unknown: %v", err)
+	}
+	wantUID, _ := strconv.Atoi(current.Uid)
+	wantGID, _ := strconv.Atoi(current.Gid)
+
+	uid, gid, err := lookupCredentials(current.Username, "")
+	if err != nil {
+		t.Fatalf("lookupCredentials() returned error: %v", err)
+	}
+	if uid != wantUID || gid != wantGID {
+		t.Errorf("Expected the user and its primary group %d:%d, got %d:%d", wantUID, wantGID, uid, gid)
+	}
+
+	uid, gid, err = lookupCredentials("", group.Name)
+	if err != nil {
+		t.Fatalf("lookupCredentials() returned error: %v", err)
+	}
+	if uid != -1 || gid != wantGID {
+		t.Errorf("Expected only the group %d, got %d:%d", wantGID, uid, gid)
+	}
+
+	if _, _, err := lookupCredentials("ovs-exporter-no-such-user", ""); err == nil {
+		t.Error("lookupCredentials() should return error for an unknown user")
+	}
+	if _, _, err := lookupCredentials("", "ovs-exporter-no-such-group"); err == nil {
+		t.Error("lookupCredentials() should return error for an unknown group")
+	}
+}
+
+func TestDropPrivileges(t *testing.T) {
+	if err := dropPrivileges("", ""); err != nil {
+		t.Errorf("Expected no change without user and group, got %v", err)
+	}
+	if err := dropPrivileges("ovs-exporter-no-such-user", ""); err == nil {
+		t.Error("dropPrivileges() should return error for an unknown user")
+	}
+}
+
+func TestPrivilegedPaths(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "ovs-vswitchd.log")
+	socket := filepath.Join(dir, "ovs-vswitchd.4242.ctl")
+	for _, path := range []string{logFile, socket} {
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := ovs.NewExporter(ovs.Options{})
+	e.Client.System.RunDir = dir
+	e.Client.Service.Vswitchd.File.Log.Path = logFile
+	e.Client.Service.Vswitchd.File.Pid.Path = filepath.Join(dir, "ovs-vswitchd.pid")
+
+	paths := privilegedPaths(e)
+	found := make(map[string]bool)
+	for _, p := range paths {
+		found[p.path] = true
+	}
+	for _, path := range []string{logFile, dir, socket} {
+		if !found[path] {
+			t.Errorf("Expected %s to be checked, got %v", path, paths)
+		}
+	}
+	if found[e.Client.Service.Vswitchd.File.Pid.Path] {
+		t.Error("Expected a missing pid file not to be checked")
+	}
+
+	if denied := inaccessiblePaths(paths); len(denied) != 0 {
+		t.Errorf("Expected the files of the current user to be accessible, got %v", denied)
+	}
+	missing := []privilegedPath{{filepath.Join(dir, "missing"), 4}}
+	if denied := inaccessiblePaths(missing); len(denied) != 1 {
+		t.Errorf("Expected a missing file to be reported, got %v", denied)
+	}
+}
@@ -0,0 +1,168 @@
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// schedPolicies maps the values of -process.sched.policy to the scheduling
+// policies. Real-time policies are not supported: the exporter must never
+// preempt the PMD threads.
+var schedPolicies = map[string]uint32{
+	"other": unix.SCHED_NORMAL,
+	"batch": unix.SCHED_BATCH,
+	"idle":  unix.SCHED_IDLE,
+}
+
+// parseCPUList parses a list of CPUs in the format of cpuset(7), e.g.
+// "0-3,8,10-11".
+func parseCPUList(s string) (*unix.CPUSet, error) {
+	set := &unix.CPUSet{}
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		bounds := strings.SplitN(r, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("malformed CPU %q", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+				return nil, fmt.Errorf("malformed CPU range %q", r)
+			}
+		}
+		if last >= len(set)*64 {
+			return nil, fmt.Errorf("CPU %d is out of range", last)
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			set.Set(cpu)
+		}
+	}
+	if set.Count() == 0 {
+		return nil, fmt.Errorf("empty CPU list %q", s)
+	}
+	return set, nil
+}
+
+// hasCapability returns whether the effective capabilities of the process
+// include the capability.
+func hasCapability(capability uint) (bool, error) {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		value, found := strings.CutPrefix(scanner.Text(), "CapEff:")
+		if !found {
+			continue
+		}
+		caps, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		if err != nil {
+			return false, fmt.Errorf("malformed CapEff %q", value)
+		}
+		return caps&(1<<capability) != 0, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+	return false, fmt.Errorf("CapEff not found in /proc/self/status")
+}
+
+// forEachThread calls fn with the id of every thread of the process. The
+// CPU affinity and the scheduling attributes are per thread on Linux, and
+// threads created later inherit them from their creator, so the threads
+// are listed until no new thread shows up.
+func forEachThread(fn func(tid int) error) error {
+	done := make(map[int]bool)
+	for {
+		entries, err := os.ReadDir("/proc/self/task")
+		if err != nil {
+			return err
+		}
+		var found bool
+		for _, entry := range entries {
+			tid, err := strconv.Atoi(entry.Name())
+			if err != nil || done[tid] {
+				continue
+			}
+			found = true
+			// Threads may exit while being listed.
+			if err := fn(tid); err != nil && err != unix.ESRCH {
+				return err
+			}
+			done[tid] = true
+		}
+		if !found {
+			return nil
+		}
+	}
+}
+
+// setScheduling pins the exporter to the CPUs of the list and applies the
+// scheduling policy and the nice value, e.g. to keep it off the cores
+// isolated for the PMD threads of DPDK hosts. Empty values leave the
+// corresponding setting unchanged.
+func setScheduling(cpuList, policy string, nice int) error {
+	if cpuList != "" {
+		set, err := parseCPUList(cpuList)
+		if err != nil {
+			return err
+		}
+		allowed := &unix.CPUSet{}
+		if err := unix.SchedGetaffinity(0, allowed); err != nil {
+			return fmt.Errorf("sched_getaffinity failed: %s", err)
+		}
+		for cpu := 0; cpu < len(set)*64; cpu++ {
+			if set.IsSet(cpu) && !allowed.IsSet(cpu) {
+				return fmt.Errorf("CPU %d is not in the CPUs allowed for the process", cpu)
+			}
+		}
+		if err := forEachThread(func(tid int) error {
+			return unix.SchedSetaffinity(tid, set)
+		}); err != nil {
+			return fmt.Errorf("sched_setaffinity failed: %s", err)
+		}
+	}
+
+	if policy == "" && nice == 0 {
+		return nil
+	}
+	attr := unix.SchedAttr{Policy: unix.SCHED_NORMAL, Nice: int32(nice)}
+	if policy != "" {
+		p, exists := schedPolicies[policy]
+		if !exists {
+			return fmt.Errorf("unsupported scheduling policy %q, expected other, batch or idle", policy)
+		}
+		attr.Policy = p
+	}
+	if nice < -20 || nice > 19 {
+		return fmt.Errorf("nice value %d is out of range -20 to 19", nice)
+	}
+	if nice < 0 {
+		capable, err := hasCapability(unix.CAP_SYS_NICE)
+		if err != nil {
+			return fmt.Errorf("failed checking capabilities: %s", err)
+		}
+		if !capable {
+			return fmt.Errorf("negative nice value %d requires CAP_SYS_NICE", nice)
+		}
+	}
+	if err := forEachThread(func(tid int) error {
+		return unix.SchedSetAttr(tid, &attr, 0)
+	}); err != nil {
+		return fmt.Errorf("sched_setattr failed: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+// setScheduling is only supported on Linux.
+func setScheduling(cpuList, policy string, nice int) error {
+	if cpuList != "" || policy != "" || nice != 0 {
+		return fmt.Errorf("CPU affinity and scheduling settings are only supported on Linux")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// listenFdsStart is the first file descriptor passed by systemd.
+const listenFdsStart = 3
+
+// systemdListener returns the socket passed by systemd socket activation,
+// as described by the LISTEN_PID and LISTEN_FDS environment variables. The
+// variables are unset so that they are not inherited by the commands run
+// by the exporter.
+func systemdListener() (net.Listener, error) {
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+	defer os.Unsetenv("LISTEN_FDNAMES")
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil {
+		return nil, fmt.Errorf("no socket passed by systemd: malformed or missing LISTEN_PID")
+	}
+	if pid != os.Getpid() {
+		return nil, fmt.Errorf("no socket passed by systemd: LISTEN_PID %d is not the pid of the exporter", pid)
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil {
+		return nil, fmt.Errorf("no socket passed by systemd: malformed or missing LISTEN_FDS")
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("expected a single socket passed by systemd, got %d", n)
+	}
+
+	syscall.CloseOnExec(listenFdsStart)
+	f := os.NewFile(listenFdsStart, "LISTEN_FD_3")
+	defer f.Close()
+	listener, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("the socket passed by systemd is not a listening socket: %s", err)
+	}
+	return listener, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+
+	ovs "github.com/Liquescent-Development/ovs_exporter/pkg/ovs_exporter"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// target is an OVS instance collected by the exporter. When several
+// instances are collected, e.g. the sandboxes of a CI host, each is named
+// after its sandbox.
+type target struct {
+	sandbox  string
+	exporter *ovs.Exporter
+}
+
+// sandboxTargets returns the targets of the run directories, keyed by
+// sandbox name, in name order.
+func sandboxTargets(dirs map[string]string, newExporter func() *ovs.Exporter) []target {
+	names := make([]string, 0, len(dirs))
+	for name := range dirs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	targets := make([]target, 0, len(names))
+	for _, name := range names {
+		exporter := newExporter()
+		exporter.UseRunDir(dirs[name])
+		targets = append(targets, target{sandbox: name, exporter: exporter})
+	}
+	return targets
+}
+
+// registerTargets registers the exporters of the targets, adding the
+// sandbox label to the metrics of the sandboxes and, with hostnameLabel,
+// the hostname label of their OVS instance.
+func registerTargets(targets []target, hostnameLabel bool) {
+	for _, t := range targets {
+		labels := prometheus.Labels{}
+		if t.sandbox != "" {
+			labels["sandbox"] = t.sandbox
+		}
+		if hostnameLabel {
+			labels["hostname"] = t.exporter.Hostname()
+		}
+		if len(labels) == 0 {
+			prometheus.MustRegister(t.exporter)
+			continue
+		}
+		prometheus.WrapRegistererWith(labels, prometheus.DefaultRegisterer).MustRegister(t.exporter)
+	}
+}
+
+// targetHandler returns the handler dispatching the requests of an admin
+// endpoint to the exporter of the sandbox selected with the sandbox query
+// parameter, which is required when sandboxes are collected.
+func targetHandler(targets []target, handler func(*ovs.Exporter) http.Handler) http.Handler {
+	handlers := make(map[string]http.Handler, len(targets))
+	for _, t := range targets {
+		handlers[t.sandbox] = handler(t.exporter)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sandbox := r.URL.Query().Get("sandbox")
+		h, exists := handlers[sandbox]
+		if !exists {
+			http.Error(w, fmt.Sprintf("unknown sandbox %q", sandbox), http.StatusNotFound)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// writeFileSD writes the file_sd_configs target of the exporter, labeled
+// with the system-id and the hostname of OVS. The system-id is left out
+// when sandboxes are collected, as they share the target.
+func writeFileSD(targets []target, listenAddress, address, path string) error {
+	for _, t := range targets {
+		if err := t.exporter.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to OVS: %v", err)
+		}
+	}
+	labels := targets[0].exporter.FileSDLabels()
+	if len(targets) > 1 || targets[0].sandbox != "" {
+		delete(labels, "system_id")
+	}
+	if address == "" {
+		var err error
+		if address, err = ovs.FileSDAddress(listenAddress, labels["hostname"]); err != nil {
+			return err
+		}
+	}
+	groups := []ovs.FileSDGroup{{Targets: []string{address}, Labels: labels}}
+	return ovs.WriteFileSD(path, os.Stdout, groups)
+}
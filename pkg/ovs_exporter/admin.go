@@ -0,0 +1,138 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// ErrCollectRateLimited is returned by ForceCollection when the previous
+// forced collection is too recent.
+var ErrCollectRateLimited = errors.New("forced collection is rate limited")
+
+// ForceCollection collects from OVS immediately, regardless of the poll
+// interval. A call within minInterval of the previous forced collection
+// returns ErrCollectRateLimited without collecting.
+func (e *Exporter) ForceCollection(minInterval time.Duration) error {
+	e.forcedCollectionMu.Lock()
+	if !e.lastForcedCollection.IsZero() && time.Since(e.lastForcedCollection) < minInterval {
+		e.forcedCollectionMu.Unlock()
+		return ErrCollectRateLimited
+	}
+	e.lastForcedCollection = time.Now()
+	e.forcedCollectionMu.Unlock()
+
+	e.Lock()
+	atomic.StoreInt64(&e.nextCollectionTicker, 0)
+	e.Unlock()
+	e.GatherMetrics()
+	return nil
+}
+
+// CollectHandler returns the handler of the POST /-/collect endpoint,
+// which forces a collection outside the poll schedule, e.g. when the
+// cached metrics are too stale during incident response.
+func (e *Exporter) CollectHandler(minInterval time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		start := time.Now()
+		if err := e.ForceCollection(minInterval); err != nil {
+			w.Header().Set("Retry-After", fmt.Sprintf("%.0f", minInterval.Seconds()))
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		level.Info(e.logger).Log(
+			"msg", "forced collection",
+			"system_id", e.Client.System.ID,
+			"remote_addr", r.RemoteAddr,
+			"duration", time.Since(start),
+		)
+		fmt.Fprintf(w, "collected in %s\n", time.Since(start).Round(time.Millisecond))
+	})
+}
+
+// RequireBearerToken returns a handler rejecting the requests without the
+// token in their Authorization header, e.g. "Authorization: Bearer TOKEN".
+func RequireBearerToken(token string, h http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// VlogHandler returns the handler of the POST /api/v1/vlog endpoint, which
+// changes the log levels of an OVS daemon with vlog/set, e.g. to raise the
+// verbosity during incidents. The component, i.e. ovsdb-server or
+// vswitchd-service, and the specification, e.g. netdev_dpdk:file:dbg, are
+// passed as the component and spec form values. Every change is logged.
+func (e *Exporter) VlogHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		component, spec := r.FormValue("component"), r.FormValue("spec")
+		known := false
+		for _, c := range vlogComponents {
+			known = known || c == component
+		}
+		if !known {
+			http.Error(w, fmt.Sprintf("unsupported component %q, expected one of %v", component, vlogComponents), http.StatusBadRequest)
+			return
+		}
+		output, err := e.SetVlogLevel(component, spec)
+		if err != nil {
+			level.Warn(e.logger).Log(
+				"msg", "failed to change log levels",
+				"system_id", e.Client.System.ID,
+				"remote_addr", r.RemoteAddr,
+				"component", component,
+				"spec", spec,
+				"error", err.Error(),
+			)
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrInvalidVlogSpec) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		level.Info(e.logger).Log(
+			"msg", "changed log levels",
+			"system_id", e.Client.System.ID,
+			"remote_addr", r.RemoteAddr,
+			"component", component,
+			"spec", spec,
+		)
+		fmt.Fprint(w, output)
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+)
+
+func TestCollectHandler(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	handler := e.CollectHandler(time.Minute)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/-/collect", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d for GET, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	e.lastForcedCollection = time.Now()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/-/collect", nil))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d within the minimum interval, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "60" {
+		t.Errorf("Expected Retry-After of 60, got %q", rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	handler := RequireBearerToken("s3cret", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for header, expected := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"s3cret":        http.StatusUnauthorized,
+		"Bearer s3cret": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/vlog", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != expected {
+			t.Errorf("Expected status %d for Authorization %q, got %d", expected, header, rec.Code)
+		}
+	}
+}
+
+func TestVlogHandler(t *testing.T) {
+	e := &Exporter{
+		Client:          ovsdb.NewOvsClient(),
+		logger:          log.NewNopLogger(),
+		commandWrappers: map[string][]string{"*": {"echo"}},
+	}
+	e.Client.System.RunDir = "/var/run/openvswitch"
+	e.Client.Service.Vswitchd.Process.ID = 42
+	handler := e.VlogHandler()
+
+	for _, tc := range []struct {
+		query    string
+		expected int
+	}{
+		{"component=vswitchd-service&spec=netdev_dpdk:file:dbg", http.StatusOK},
+		{"component=ovn-northd&spec=netdev_dpdk:file:dbg", http.StatusBadRequest},
+		{"component=vswitchd-service&spec=PATTERN:file:%25m", http.StatusBadRequest},
+		{"component=vswitchd-service&spec=netdev_dpdk;reboot", http.StatusBadRequest},
+		{"component=ovsdb-server&spec=dbg", http.StatusInternalServerError},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/vlog?"+tc.query, nil))
+		if rec.Code != tc.expected {
+			t.Errorf("Expected status %d for %s, got %d: %s", tc.expected, tc.query, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/vlog?component=vswitchd-service&spec=bfd:dbg", nil))
+	if expected := "ovs-appctl -t /var/run/openvswitch/ovs-vswitchd.42.ctl vlog/set bfd:dbg\n"; rec.Body.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, rec.Body.String())
+	}
+}
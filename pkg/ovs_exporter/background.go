@@ -0,0 +1,89 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// StartBackgroundCollection runs GatherMetrics in a background goroutine
+// every poll interval until the context is cancelled. Afterwards, Collect
+// serves the latest collected metrics without triggering a collection,
+// so that scrapes never block on a full collection from OVS. The first
+// collection is delayed by a random duration up to the poll jitter.
+func (e *Exporter) StartBackgroundCollection(ctx context.Context) {
+	e.backgroundCollection.Store(true)
+	interval := time.Duration(e.pollInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+	level.Info(e.logger).Log(
+		"msg", "starting background collection",
+		"system_id", e.Client.System.ID,
+		"interval", interval,
+	)
+	go func() {
+		// Stagger the start of the loop so that exporters deployed at the
+		// same time do not poll ovsdb-server in lockstep.
+		if e.pollJitter > 0 {
+			delay := rand.N(e.pollJitter)
+			level.Debug(e.logger).Log(
+				"msg", "delaying background collection",
+				"system_id", e.Client.System.ID,
+				"delay", delay,
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			e.backgroundGather()
+			select {
+			case <-ctx.Done():
+				level.Info(e.logger).Log(
+					"msg", "stopped background collection",
+					"system_id", e.Client.System.ID,
+				)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// backgroundGather collects regardless of the poll gate. The gate is set
+// one interval after the end of the previous collection, so a tick of the
+// background loop would otherwise find it closed and skip every other poll.
+func (e *Exporter) backgroundGather() {
+	e.Lock()
+	atomic.StoreInt64(&e.nextCollectionTicker, 0)
+	e.Unlock()
+	e.GatherMetrics()
+}
+
+// SetPollJitter sets the maximum random delay of the first background
+// collection.
+func (e *Exporter) SetPollJitter(d time.Duration) {
+	e.pollJitter = d
+}
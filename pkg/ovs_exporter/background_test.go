@@ -0,0 +1,47 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+)
+
+func TestStartBackgroundCollectionEveryTick(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger(), pollInterval: 1}
+	// A previous collection that ended late leaves the gate closed past the
+	// next tick.
+	atomic.StoreInt64(&e.nextCollectionTicker, time.Now().Add(time.Hour).Unix())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e.StartBackgroundCollection(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for e.stats.cacheMisses.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := e.stats.cacheMisses.Load(); got < 2 {
+		t.Errorf("Expected a collection on the start and on the first tick, got %d", got)
+	}
+	if got := e.stats.cacheHits.Load(); got != 0 {
+		t.Errorf("Expected no background collection to be skipped, got %d", got)
+	}
+}
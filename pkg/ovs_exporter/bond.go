@@ -0,0 +1,147 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Bond is a bond reported by ovs-appctl bond/show with its active member,
+// which is empty when no member is enabled. OVS releases before 2.16 call
+// the members slaves.
+type Bond struct {
+	Name   string
+	Mode   string
+	Active string
+}
+
+var (
+	bondHeaderRegex = regexp.MustCompile(`^---- (.+) ----$`)
+	bondMemberRegex = regexp.MustCompile(`^(?:member|slave) ([^:]+): `)
+)
+
+// GetBonds returns the bonds of ovs-vswitchd using ovs-appctl bond/show.
+func (e *Exporter) GetBonds() ([]Bond, error) {
+	output, err := e.vswitchdAppctl("bond/show").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute bond/show: %w", err)
+	}
+	return parseBonds(string(output)), nil
+}
+
+// parseBonds parses the output of bond/show, e.g.:
+//
+//	---- bond0 ----
+//	bond_mode: active-backup
+//	...
+//	member eth1: enabled
+//	  active member
+//	  may_enable: true
+//
+//	member eth2: enabled
+//	  may_enable: true
+func parseBonds(output string) []Bond {
+	var bonds []Bond
+	var member string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if m := bondHeaderRegex.FindStringSubmatch(line); m != nil {
+			bonds = append(bonds, Bond{Name: m[1]})
+			member = ""
+			continue
+		}
+		if len(bonds) == 0 {
+			continue
+		}
+		bond := &bonds[len(bonds)-1]
+		switch {
+		case strings.HasPrefix(line, "bond_mode: "):
+			bond.Mode = strings.TrimPrefix(line, "bond_mode: ")
+		case bondMemberRegex.MatchString(line):
+			member = bondMemberRegex.FindStringSubmatch(line)[1]
+		case line == "active member" || line == "active slave":
+			bond.Active = member
+		}
+	}
+	return bonds
+}
+
+// bondActiveTracker counts the changes of the active member of the bonds
+// between polls.
+type bondActiveTracker struct {
+	active  map[string]string
+	changes map[string]float64
+}
+
+// observe records the active member of the bonds of a poll and returns the
+// changes of their active member since they appeared. The bonds that
+// disappeared are forgotten.
+func (t *bondActiveTracker) observe(bonds []Bond) map[string]float64 {
+	active := make(map[string]string, len(bonds))
+	changes := make(map[string]float64, len(bonds))
+	for _, bond := range bonds {
+		active[bond.Name] = bond.Active
+		changes[bond.Name] = t.changes[bond.Name]
+		if previous, exists := t.active[bond.Name]; exists && previous != bond.Active {
+			changes[bond.Name]++
+		}
+	}
+	t.active = active
+	t.changes = changes
+	return changes
+}
+
+// collectBondMetrics exports the active member of the bonds and the number
+// of its changes, e.g. caused by flapping uplinks.
+func (e *Exporter) collectBondMetrics() {
+	e.IncrementRequestCounter()
+	bonds, err := e.GetBonds()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetBonds() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetBonds() failed", err)
+		return
+	}
+	changes := e.bondActive.observe(bonds)
+	for _, bond := range bonds {
+		e.emit(e.newConstMetric(
+			bondActiveSlaveChanges,
+			prometheus.CounterValue,
+			changes[bond.Name],
+			e.Client.System.ID,
+			bond.Name,
+		))
+		if bond.Active == "" {
+			continue
+		}
+		e.emit(e.newConstMetric(
+			bondActiveSlaveInfo,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID,
+			bond.Name,
+			bond.Mode,
+			bond.Active,
+		))
+	}
+}
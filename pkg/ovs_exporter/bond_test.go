@@ -0,0 +1,78 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBonds(t *testing.T) {
+	output := `---- bond0 ----
+bond_mode: active-backup
+bond may use recirculation: no, Recirc-ID : -1
+bond-hash-basis: 0
+lb_output action: disabled, bond-id: -1
+updelay: 0 ms
+downdelay: 0 ms
+lacp_status: off
+lacp_fallback_ab: false
+active-backup primary: <none>
+active member mac: 52:54:00:aa:bb:02(eth2)
+
+member eth1: disabled
+  may_enable: false
+
+member eth2: enabled
+  active member
+  may_enable: true
+
+---- bond1 ----
+bond_mode: balance-slb
+updelay: 0 ms
+downdelay: 0 ms
+lacp_status: off
+active slave mac: 00:00:00:00:00:00(none)
+
+slave eth3: disabled
+	may_enable: false
+`
+	expected := []Bond{
+		{Name: "bond0", Mode: "active-backup", Active: "eth2"},
+		{Name: "bond1", Mode: "balance-slb", Active: ""},
+	}
+	if bonds := parseBonds(output); !reflect.DeepEqual(bonds, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, bonds)
+	}
+}
+
+func TestBondActiveTracker(t *testing.T) {
+	var tracker bondActiveTracker
+	for i, tc := range []struct {
+		bonds    []Bond
+		expected map[string]float64
+	}{
+		{[]Bond{{Name: "bond0", Active: "eth1"}}, map[string]float64{"bond0": 0}},
+		{[]Bond{{Name: "bond0", Active: "eth2"}}, map[string]float64{"bond0": 1}},
+		{[]Bond{{Name: "bond0", Active: ""}, {Name: "bond1", Active: "eth3"}}, map[string]float64{"bond0": 2, "bond1": 0}},
+		{[]Bond{{Name: "bond0", Active: ""}}, map[string]float64{"bond0": 2}},
+		// A bond that disappeared starts over.
+		{[]Bond{{Name: "bond0", Active: ""}, {Name: "bond1", Active: "eth4"}}, map[string]float64{"bond0": 2, "bond1": 0}},
+	} {
+		if changes := tracker.observe(tc.bonds); !reflect.DeepEqual(changes, tc.expected) {
+			t.Errorf("Poll %d: expected %v, got %v", i, tc.expected, changes)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultBridgeProtocols is reported for bridges with an empty protocols
+// column, i.e. using the OpenFlow versions enabled by ovs-vswitchd by
+// default.
+const defaultBridgeProtocols = "default"
+
+// GetBridgeProtocols returns the OpenFlow versions enabled on each bridge.
+func (e *Exporter) GetBridgeProtocols() (map[string][]string, error) {
+	query := "SELECT name, protocols FROM Bridge"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseBridgeProtocols(result), nil
+}
+
+// parseBridgeProtocols extracts the protocols column of the bridges.
+func parseBridgeProtocols(result ovsdb.Result) map[string][]string {
+	protocols := make(map[string][]string)
+	for _, row := range result.Rows {
+		name, dt, err := row.GetColumnValue("name", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		r, dt, err := row.GetColumnValue("protocols", result.Columns)
+		if err != nil {
+			continue
+		}
+		// A set with a single string is returned as a string.
+		var versions []string
+		switch dt {
+		case "string":
+			versions = []string{r.(string)}
+		case "[]string":
+			versions = append(versions, r.([]string)...)
+		}
+		if len(versions) == 0 {
+			versions = []string{defaultBridgeProtocols}
+		}
+		sort.Strings(versions)
+		protocols[name.(string)] = versions
+	}
+	return protocols
+}
+
+// collectBridgeProtocolMetrics exports the OpenFlow versions enabled on
+// each bridge, so that fleets mixing versions can be audited centrally.
+func (e *Exporter) collectBridgeProtocolMetrics() {
+	e.IncrementRequestCounter()
+	protocols, err := e.GetBridgeProtocols()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetBridgeProtocols() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetBridgeProtocols() failed", err)
+		return
+	}
+	for bridge, versions := range protocols {
+		for _, version := range versions {
+			e.emit(e.newConstMetric(
+				bridgeOpenFlowProtocol,
+				prometheus.GaugeValue,
+				1,
+				e.Client.System.ID, bridge, version,
+			))
+		}
+	}
+}
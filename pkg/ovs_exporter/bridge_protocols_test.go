@@ -0,0 +1,42 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseBridgeProtocols(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"name": "string", "protocols": "[]string"},
+		Rows: []ovsdb.Row{
+			{"name": "br-int", "protocols": []interface{}{"set", []interface{}{"OpenFlow15", "OpenFlow13"}}},
+			{"name": "br-ex", "protocols": "OpenFlow13"},
+			{"name": "br-tun", "protocols": []interface{}{"set", []interface{}{}}},
+		},
+	}
+
+	expected := map[string][]string{
+		"br-int": {"OpenFlow13", "OpenFlow15"},
+		"br-ex":  {"OpenFlow13"},
+		"br-tun": {defaultBridgeProtocols},
+	}
+	if protocols := parseBridgeProtocols(result); !reflect.DeepEqual(protocols, expected) {
+		t.Errorf("Expected %v, got %v", expected, protocols)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// userspaceDatapath is the name of the userspace datapath, as reported by
+// dpif/show, which the PMD threads belong to.
+const userspaceDatapath = "netdev@ovs-netdev"
+
+// flowCacheLevels are the levels of the flow cache hierarchy of the
+// userspace datapath, in lookup order. Packets missing all caches result in
+// upcalls.
+var flowCacheLevels = []string{"emc", "smc", "megaflow", "upcall"}
+
+// cacheDistribution returns the share of the packets handled by each level
+// of the flow cache hierarchy across all PMD threads. It returns nil when
+// no packets were looked up.
+func cacheDistribution(metrics []EnhancedPmdMetrics) map[string]float64 {
+	counts := make(map[string]uint64, len(flowCacheLevels))
+	var total uint64
+	for _, pmd := range metrics {
+		upcalls := pmd.MissUpcalls
+		if upcalls == 0 {
+			upcalls = pmd.Miss
+		}
+		counts["emc"] += pmd.EMCHits
+		counts["smc"] += pmd.SMCHits
+		counts["megaflow"] += pmd.MegaflowHits
+		counts["upcall"] += upcalls
+		total += pmd.EMCHits + pmd.SMCHits + pmd.MegaflowHits + upcalls
+	}
+	if total == 0 {
+		return nil
+	}
+	distribution := make(map[string]float64, len(flowCacheLevels))
+	for _, level := range flowCacheLevels {
+		distribution[level] = float64(counts[level]) / float64(total)
+	}
+	return distribution
+}
+
+// collectCacheDistribution exports the flow cache hierarchy distribution of
+// the userspace datapath.
+func (e *Exporter) collectCacheDistribution(metrics []EnhancedPmdMetrics) {
+	for level, ratio := range cacheDistribution(metrics) {
+		e.emit(e.newConstMetric(
+			dpCacheDistribution,
+			prometheus.GaugeValue,
+			ratio,
+			e.Client.System.ID, userspaceDatapath, level,
+		))
+	}
+}
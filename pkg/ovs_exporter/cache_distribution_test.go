@@ -0,0 +1,37 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestCacheDistribution(t *testing.T) {
+	metrics := []EnhancedPmdMetrics{
+		{EMCHits: 500, SMCHits: 100, MegaflowHits: 300, MissUpcalls: 100, Miss: 7},
+		{EMCHits: 100, MegaflowHits: 800, Miss: 100},
+	}
+	distribution := cacheDistribution(metrics)
+	expected := map[string]float64{"emc": 0.3, "smc": 0.05, "megaflow": 0.55, "upcall": 0.1}
+	for level, ratio := range expected {
+		if distribution[level] != ratio {
+			t.Errorf("Expected %s ratio %v, got %v", level, ratio, distribution[level])
+		}
+	}
+
+	if distribution := cacheDistribution([]EnhancedPmdMetrics{{}}); distribution != nil {
+		t.Errorf("Expected no distribution without packets, got %v", distribution)
+	}
+}
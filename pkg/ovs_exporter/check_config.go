@@ -0,0 +1,140 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"time"
+)
+
+// EnabledCollectors returns the names of the collectors run by
+// GatherMetrics with the current configuration.
+func (e *Exporter) EnabledCollectors() []string {
+	var names []string
+	for _, c := range collectorRegistry {
+		if c.isEnabled(e) {
+			names = append(names, c.name)
+		}
+	}
+	return names
+}
+
+// checkReadableFile verifies that a regular file exists and can be opened
+// for reading by the current user.
+func checkReadableFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return nil
+}
+
+// checkSocket verifies that the JSON-RPC remote of a database is reachable
+// by the current user.
+func checkSocket(remote string, timeout int) error {
+	network, address, err := ParseRemote(remote)
+	if err != nil {
+		return err
+	}
+	if network == "unix" {
+		if _, err := os.Stat(address); err != nil {
+			return err
+		}
+	}
+	conn, err := net.DialTimeout(network, address, time.Duration(timeout)*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// CheckConfig validates the exporter configuration: it verifies that the
+// configured sockets, log, pid and database files exist and are accessible
+// by the current user, and prints the collectors that would be active.
+// It returns an error when at least one mandatory check fails.
+func (e *Exporter) CheckConfig(w io.Writer) error {
+	failed := 0
+	report := func(name, path string, err error, optional bool) {
+		switch {
+		case err == nil:
+			fmt.Fprintf(w, "[ OK ] %s: %s\n", name, path)
+		case optional:
+			fmt.Fprintf(w, "[WARN] %s: %v\n", name, err)
+		default:
+			failed++
+			fmt.Fprintf(w, "[FAIL] %s: %v\n", name, err)
+		}
+	}
+
+	remote := e.Client.Database.Vswitch.Socket.Remote
+	report("database socket", remote, checkSocket(remote, e.timeout), false)
+
+	if fi, err := os.Stat(e.Client.System.RunDir); err != nil {
+		report("run directory", e.Client.System.RunDir, err, false)
+	} else if !fi.IsDir() {
+		report("run directory", e.Client.System.RunDir, fmt.Errorf("%s is not a directory", e.Client.System.RunDir), false)
+	} else {
+		report("run directory", e.Client.System.RunDir, nil, false)
+	}
+
+	type fileCheck struct {
+		name     string
+		path     string
+		optional bool
+	}
+	files := []fileCheck{
+		{"database file", e.Client.Database.Vswitch.File.Data.Path, false},
+		{"database log file", e.Client.Database.Vswitch.File.Log.Path, false},
+		{"database pid file", e.Client.Database.Vswitch.File.Pid.Path, false},
+		{"system id file", e.Client.Database.Vswitch.File.SystemID.Path, true},
+		{"vswitchd log file", e.Client.Service.Vswitchd.File.Log.Path, false},
+		{"vswitchd pid file", e.Client.Service.Vswitchd.File.Pid.Path, false},
+		{"ovn-controller log file", e.Client.Service.OvnController.File.Log.Path, true},
+		{"ovn-controller pid file", e.Client.Service.OvnController.File.Pid.Path, true},
+	}
+	names := make([]string, 0, len(e.databaseFilePaths))
+	for name := range e.databaseFilePaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files = append(files, fileCheck{name + " database file", e.databaseFilePaths[name], false})
+	}
+	for _, f := range files {
+		report(f.name, f.path, checkReadableFile(f.path), f.optional)
+	}
+
+	fmt.Fprintf(w, "\nActive collectors:\n")
+	for _, name := range e.EnabledCollectors() {
+		fmt.Fprintf(w, "  - %s\n", name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d configuration check(s) failed", failed)
+	}
+	return nil
+}
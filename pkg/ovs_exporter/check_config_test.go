@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckReadableFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "ovs-vswitchd.log")
+	if err := os.WriteFile(tmpFile, []byte("log"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if err := checkReadableFile(tmpFile); err != nil {
+		t.Errorf("checkReadableFile() returned error: %v", err)
+	}
+	if err := checkReadableFile(tmpDir); err == nil {
+		t.Error("checkReadableFile() should return error for a directory")
+	}
+	if err := checkReadableFile(filepath.Join(tmpDir, "missing.log")); err == nil {
+		t.Error("checkReadableFile() should return error for non-existent file")
+	}
+}
+
+func TestCheckSocket(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "db.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatalf("Failed to create test socket: %v", err)
+	}
+	defer l.Close()
+
+	if err := checkSocket("unix:"+sock, 1); err != nil {
+		t.Errorf("checkSocket() returned error: %v", err)
+	}
+	if err := checkSocket("unix:"+sock+".missing", 1); err == nil {
+		t.Error("checkSocket() should return error for non-existent socket")
+	}
+	if err := checkSocket("ssl:127.0.0.1:6640", 1); err == nil {
+		t.Error("checkSocket() should return error for unsupported remote type")
+	}
+}
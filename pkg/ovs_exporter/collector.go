@@ -0,0 +1,135 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures the collector returned by NewCollector.
+type Option func(*collectorConfig)
+
+// collectorConfig holds the options of the exporter and the settings
+// applied once it is created.
+type collectorConfig struct {
+	options Options
+	setup   []func(*Exporter)
+}
+
+// defaultOptions returns the options of NewCollector, matching the defaults
+// of the flags of the ovs-exporter command.
+func defaultOptions() Options {
+	return Options{
+		Timeout:                     2,
+		Logger:                      log.NewNopLogger(),
+		PmdOverloadThreshold:        0.9,
+		PmdOverloadPolls:            3,
+		InterfaceInconsistencyPolls: 3,
+		SysfsPath:                   "/sys",
+		LabelValueReplacement:       DefaultLabelValueReplacement,
+	}
+}
+
+// WithOptions replaces all the options of the exporter, e.g. to enable
+// optional collectors. The options of the other Option functions are
+// applied in order, so WithOptions usually comes first.
+func WithOptions(opts Options) Option {
+	return func(c *collectorConfig) {
+		c.options = opts
+	}
+}
+
+// WithLogger sets the logger of the exporter. By default, nothing is
+// logged.
+func WithLogger(logger log.Logger) Option {
+	return func(c *collectorConfig) {
+		c.options.Logger = logger
+	}
+}
+
+// WithTimeout sets the timeout, in seconds, of the requests to OVS.
+func WithTimeout(seconds int) Option {
+	return func(c *collectorConfig) {
+		c.options.Timeout = seconds
+	}
+}
+
+// WithCommandWrappers sets the command prefixes the external commands are
+// run with, as returned by ParseCommandWrappers.
+func WithCommandWrappers(wrappers map[string][]string) Option {
+	return func(c *collectorConfig) {
+		c.options.CommandWrappers = wrappers
+	}
+}
+
+// WithRegistry registers the build information of the exporter, i.e.
+// ovs_exporter_build_info, with the registry.
+func WithRegistry(registry prometheus.Registerer) Option {
+	return func(c *collectorConfig) {
+		c.options.Registry = registry
+	}
+}
+
+// WithPollInterval sets the minimum interval, in seconds, between
+// collections from OVS. By default, every Collect() call collects from
+// OVS.
+func WithPollInterval(seconds int64) Option {
+	return func(c *collectorConfig) {
+		c.setup = append(c.setup, func(e *Exporter) {
+			e.SetPollInterval(seconds)
+		})
+	}
+}
+
+// WithRunDir points the exporter to an OVS instance whose sockets, pid,
+// log and database files are all in dir, see UseRunDir.
+func WithRunDir(dir string) Option {
+	return func(c *collectorConfig) {
+		c.setup = append(c.setup, func(e *Exporter) {
+			e.UseRunDir(dir)
+		})
+	}
+}
+
+// WithDatabaseSocket sets the JSON-RPC socket of the Open_vSwitch
+// database, e.g. unix:/var/run/openvswitch/db.sock.
+func WithDatabaseSocket(remote string) Option {
+	return func(c *collectorConfig) {
+		c.setup = append(c.setup, func(e *Exporter) {
+			e.Client.Database.Vswitch.Socket.Remote = remote
+		})
+	}
+}
+
+// NewCollector returns an exporter configured with the options, for
+// embedding the collection from OVS into other programs. The exporter
+// implements prometheus.Collector and keeps no global state, so several
+// exporters can be registered with different registries. It must be
+// connected with Connect() before it is collected.
+func NewCollector(opts ...Option) *Exporter {
+	c := collectorConfig{options: defaultOptions()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.options.Logger == nil {
+		c.options.Logger = log.NewNopLogger()
+	}
+	e := NewExporter(c.options)
+	for _, setup := range c.setup {
+		setup(e)
+	}
+	return e
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// runCollector runs a collector, or a part of it, recovering from its
+// panics, e.g. a parser indexing unexpected output, so that a panic fails
+// the running collector instead of killing the exporter. The collection
+// resumes with the next collector.
+func (e *Exporter) runCollector(collect func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.recordCollectorPanic(r, debug.Stack())
+		}
+	}()
+	collect()
+}
+
+// recordCollectorPanic counts a panic of the running collector and logs it
+// with the stack of the goroutine, which is recorded as the last error of
+// the collector.
+func (e *Exporter) recordCollectorPanic(r interface{}, stack []byte) {
+	e.collectorsMu.Lock()
+	name := e.runningCollector
+	if name != "" {
+		e.collectorRuns[name].panics++
+	}
+	e.collectorsMu.Unlock()
+	level.Error(e.logger).Log(
+		"msg", "collector panicked",
+		"system_id", e.Client.System.ID,
+		"collector", name,
+		"error", fmt.Sprint(r),
+		"stack", string(stack),
+	)
+	e.recordCollectorError("collector panicked", fmt.Errorf("%v", r))
+}
+
+// collectCollectorPanicMetrics sends the number of panics of the collectors
+// run since the exporter started. The caller must hold the exporter mutex.
+func (e *Exporter) collectCollectorPanicMetrics(ch chan<- prometheus.Metric) {
+	e.collectorsMu.Lock()
+	defer e.collectorsMu.Unlock()
+	for name, run := range e.collectorRuns {
+		ch <- e.newConstMetric(
+			collectorPanics,
+			prometheus.CounterValue,
+			float64(run.panics),
+			e.Client.System.ID,
+			name,
+		)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestRunCollectorRecoversPanics(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger(), metersEnabled: true, bondsEnabled: true}
+	e.lastCollection = time.Now()
+
+	e.startCollector("meter")
+	e.runCollector(func() {
+		var bands []string
+		_ = bands[1]
+	})
+	ran := false
+	e.startCollector("bond")
+	e.runCollector(func() { ran = true })
+	e.stopCollector()
+	if !ran {
+		t.Error("Expected the collector after the panic to run")
+	}
+
+	for _, status := range e.CollectorStatuses() {
+		switch status.Name {
+		case "meter":
+			if status.Panics != 1 || status.LastRunErrors != 1 || !strings.HasPrefix(status.LastError, "collector panicked: runtime error: index out of range") {
+				t.Errorf("Unexpected meter status %+v", status)
+			}
+		case "bond":
+			if status.Panics != 0 || status.LastRunErrors != 0 {
+				t.Errorf("Unexpected bond status %+v", status)
+			}
+		}
+	}
+	if e.errors != 1 {
+		t.Errorf("Expected the panic to count as an error, got %d errors", e.errors)
+	}
+
+	ch := make(chan prometheus.Metric, len(e.collectorRuns))
+	e.collectCollectorPanicMetrics(ch)
+	close(ch)
+	panics := make(map[string]float64)
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range pb.GetLabel() {
+			if label.GetName() == "collector" {
+				panics[label.GetValue()] = pb.GetCounter().GetValue()
+			}
+		}
+	}
+	if panics["meter"] != 1 || panics["bond"] != 0 || len(panics) != 2 {
+		t.Errorf("Unexpected panic counters %v", panics)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// collector is a collector run by GatherMetrics.
+type collector struct {
+	name string
+	// enabled returns whether the collector runs with the configuration of
+	// the exporter. A nil enabled means the collector always runs.
+	enabled func(e *Exporter) bool
+	// collect runs the collector. It is nil for the collectors run as part
+	// of an earlier one, which records their status under their name, or
+	// under the one of reportedBy.
+	collect    func(e *Exporter)
+	reportedBy string
+}
+
+// isEnabled returns whether the collector runs with the configuration of
+// the exporter.
+func (c collector) isEnabled(e *Exporter) bool {
+	return c.enabled == nil || c.enabled(e)
+}
+
+// collectorRegistry lists the collectors in the order GatherMetrics runs
+// them. It also drives the collectors listed by check-config and by
+// GET /api/v1/collectors.
+var collectorRegistry = []collector{
+	{name: "system", collect: (*Exporter).collectSystemInfo},
+	{name: "process", collect: (*Exporter).collectProcessMetrics},
+	{name: "log", collect: (*Exporter).collectLogMetrics},
+	{name: "coverage", collect: (*Exporter).collectAppctlMetrics},
+	{
+		name:       "coverage_rate",
+		enabled:    func(e *Exporter) bool { return e.coverageRates != nil },
+		reportedBy: "coverage",
+	},
+	// The memory of the OVS daemons is collected by the coverage collector,
+	// the one of the OVN daemons by collectOvnMemoryMetrics.
+	{name: "memory", collect: (*Exporter).collectOvnMemoryMetrics},
+	{name: "datapath"},
+	{name: "interface", collect: (*Exporter).collectInterfaceMetrics},
+	{name: "network_port", collect: (*Exporter).collectNetworkPortMetrics},
+	{
+		name:    "ovsdb_probe",
+		enabled: func(e *Exporter) bool { return e.ovsdbProbeEnabled },
+		collect: (*Exporter).collectOvsdbProbeMetrics,
+	},
+	{
+		name:    "vswitchd_probe",
+		enabled: func(e *Exporter) bool { return e.vswitchdProbeEnabled },
+		collect: (*Exporter).collectVswitchdProbeMetrics,
+	},
+	{name: "schema_feature", collect: (*Exporter).collectSchemaFeatureMetrics},
+	{name: "vswitchd_config", collect: (*Exporter).collectVswitchdConfigMetrics},
+	{name: "database", collect: (*Exporter).collectDatabaseMetrics},
+	{name: "inventory", collect: (*Exporter).collectInventoryMetrics},
+	{name: "logical_port_binding", collect: (*Exporter).collectLogicalPortBindingMetrics},
+	{name: "bridge_protocol", collect: (*Exporter).collectBridgeProtocolMetrics},
+	{name: "patch_port", collect: (*Exporter).collectPatchPortMetrics},
+	{name: "flow_cache_config", collect: (*Exporter).collectFlowCacheConfigMetrics},
+	{name: "supported_type", collect: (*Exporter).collectSupportedTypeMetrics},
+	{name: "system_statistics", collect: (*Exporter).collectSystemStatisticsMetrics},
+	{name: "kernel_module", collect: (*Exporter).collectKernelModuleMetrics},
+	{
+		name:    "tunnel_neighbor",
+		enabled: func(e *Exporter) bool { return e.tnlNeighEnabled },
+		collect: (*Exporter).collectTunnelNeighborMetrics,
+	},
+	{
+		name:    "meter",
+		enabled: func(e *Exporter) bool { return e.metersEnabled },
+		collect: (*Exporter).collectMeterMetrics,
+	},
+	{
+		name:    "bond",
+		enabled: func(e *Exporter) bool { return e.bondsEnabled },
+		collect: (*Exporter).collectBondMetrics,
+	},
+	{name: "sampling", collect: (*Exporter).collectSamplingMetrics},
+	{
+		name:    "ofproto",
+		enabled: func(e *Exporter) bool { return e.ofprotoEnabled },
+		collect: (*Exporter).collectOfprotoMetrics,
+	},
+	{
+		name:    "dpdk_log",
+		enabled: func(e *Exporter) bool { return e.dpdkLogEnabled },
+		collect: (*Exporter).collectDpdkLogMetrics,
+	},
+	{
+		name:    "vlog",
+		enabled: func(e *Exporter) bool { return e.vlogEnabled },
+		collect: (*Exporter).collectVlogMetrics,
+	},
+	{
+		name:    "ovn_northbound",
+		enabled: func(e *Exporter) bool { return e.ovnNorthbound != nil },
+		collect: func(e *Exporter) { e.collectOvnDatabaseMetrics(e.ovnNorthbound) },
+	},
+	{
+		name:    "ovn_southbound",
+		enabled: func(e *Exporter) bool { return e.ovnSouthbound != nil },
+		collect: func(e *Exporter) { e.collectOvnDatabaseMetrics(e.ovnSouthbound) },
+	},
+	{
+		name:    "interface_kernel",
+		enabled: func(e *Exporter) bool { return e.kernelIntfEnabled },
+		collect: (*Exporter).collectKernelInterfaceMetrics,
+	},
+	{
+		name:    "controller_rtt",
+		enabled: func(e *Exporter) bool { return e.controllerRttEnabled },
+		collect: (*Exporter).collectControllerRttMetrics,
+	},
+	{
+		name:    "megaflow_age",
+		enabled: func(e *Exporter) bool { return e.megaflowAgeEnabled },
+		collect: (*Exporter).collectMegaflowAgeMetrics,
+	},
+	{
+		name:    "flow_offload",
+		enabled: func(e *Exporter) bool { return e.flowOffloadEnabled },
+		collect: (*Exporter).collectFlowOffloadMetrics,
+	},
+	{
+		name:    "ovn_controller",
+		enabled: func(e *Exporter) bool { return e.ovnControllerEnabled },
+		collect: (*Exporter).collectOvnControllerMetrics,
+	},
+	{
+		name:    "ovn_ct_zones",
+		enabled: func(e *Exporter) bool { return e.ovnCtZonesEnabled },
+		collect: (*Exporter).collectOvnCtZoneMetrics,
+	},
+	{
+		name:    "ovn_logical_flows",
+		enabled: func(e *Exporter) bool { return e.logicalFlowsEnabled && e.ovnSouthbound != nil },
+		collect: (*Exporter).collectOvnLogicalFlowMetrics,
+	},
+	{name: "pmd", collect: (*Exporter).CollectPMDMetrics},
+	{name: "pmd_thread", collect: (*Exporter).collectPmdThreadMetrics},
+}
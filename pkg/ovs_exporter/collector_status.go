@@ -0,0 +1,220 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorRun is the status of the last run of a collector. A collector
+// may run several times per collection, e.g. once per component, in which
+// case the durations and errors add up. The panics are counted since the
+// exporter started.
+type collectorRun struct {
+	start         time.Time
+	duration      time.Duration
+	errors        int64
+	lastError     string
+	errorClass    string
+	lastErrorTime time.Time
+	panics        uint64
+}
+
+// collectorErrorClasses are the classes of the errors of the collectors,
+// matched in order against the lowercased error messages. The messages
+// themselves are too variable to be used as label values.
+var collectorErrorClasses = []struct {
+	class    string
+	patterns []string
+}{
+	{"panic", []string{"collector panicked"}},
+	{"timeout", []string{"timeout", "timed out", "deadline exceeded"}},
+	{"permission", []string{"permission denied", "operation not permitted"}},
+	{"connection", []string{"connection refused", "connection reset", "broken pipe", "no such file or directory", "eof"}},
+	{"unsupported", []string{"is not a valid command"}},
+	{"command", []string{"exit status", "executable file not found"}},
+	{"parse", []string{"parse", "parsing", "malformed", "unexpected", "invalid"}},
+}
+
+// classifyCollectorError returns the class of an error message of a
+// collector, other when it matches none.
+func classifyCollectorError(message string) string {
+	message = strings.ToLower(message)
+	for _, c := range collectorErrorClasses {
+		for _, pattern := range c.patterns {
+			if strings.Contains(message, pattern) {
+				return c.class
+			}
+		}
+	}
+	return "other"
+}
+
+// CollectorStatus is the status of a collector returned by
+// GET /api/v1/collectors. The last error is kept until another error
+// occurs, while the number of errors is the one of the last run. The
+// panics are counted since the exporter started.
+type CollectorStatus struct {
+	Name                string     `json:"name"`
+	Enabled             bool       `json:"enabled"`
+	LastRun             *time.Time `json:"last_run,omitempty"`
+	LastDurationSeconds float64    `json:"last_duration_seconds"`
+	LastRunErrors       int64      `json:"last_run_errors"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorClass      string     `json:"last_error_class,omitempty"`
+	LastErrorTime       *time.Time `json:"last_error_time,omitempty"`
+	Panics              uint64     `json:"panics"`
+}
+
+// startCollector records the start of a collector within the current
+// collection, ending the collector running before.
+func (e *Exporter) startCollector(name string) {
+	e.collectorsMu.Lock()
+	defer e.collectorsMu.Unlock()
+	e.stopCollectorLocked()
+	if e.collectorRuns == nil {
+		e.collectorRuns = make(map[string]*collectorRun)
+	}
+	run, exists := e.collectorRuns[name]
+	if !exists {
+		run = &collectorRun{}
+		e.collectorRuns[name] = run
+	}
+	if !run.start.Equal(e.lastCollection) {
+		run.start = e.lastCollection
+		run.duration = 0
+		run.errors = 0
+	}
+	e.runningCollector = name
+	e.runningSince = time.Now()
+}
+
+// stopCollector records the end of the running collector.
+func (e *Exporter) stopCollector() {
+	e.collectorsMu.Lock()
+	defer e.collectorsMu.Unlock()
+	e.stopCollectorLocked()
+}
+
+func (e *Exporter) stopCollectorLocked() {
+	if e.runningCollector == "" {
+		return
+	}
+	e.collectorRuns[e.runningCollector].duration += time.Since(e.runningSince)
+	e.runningCollector = ""
+}
+
+// countCollectorError counts an error of the running collector.
+func (e *Exporter) countCollectorError() {
+	e.collectorsMu.Lock()
+	defer e.collectorsMu.Unlock()
+	if e.runningCollector == "" {
+		return
+	}
+	e.collectorRuns[e.runningCollector].errors++
+}
+
+// recordCollectorError counts a failure of the running collector and
+// records it as its last error, exported by
+// ovs_exporter_collector_last_error and returned by /api/v1/collectors.
+func (e *Exporter) recordCollectorError(msg string, err error) {
+	e.collectorsMu.Lock()
+	if e.runningCollector != "" {
+		run := e.collectorRuns[e.runningCollector]
+		run.lastError = msg + ": " + err.Error()
+		run.errorClass = classifyCollectorError(run.lastError)
+		run.lastErrorTime = time.Now()
+	}
+	e.collectorsMu.Unlock()
+	e.IncrementErrorCounter()
+}
+
+// collectCollectorErrorMetrics sends the time of the last error of the
+// collectors, labeled with the class of the error, so that dashboards show
+// what is failing without access to the logs. The caller must hold the
+// exporter mutex.
+func (e *Exporter) collectCollectorErrorMetrics(ch chan<- prometheus.Metric) {
+	e.collectorsMu.Lock()
+	defer e.collectorsMu.Unlock()
+	for name, run := range e.collectorRuns {
+		if run.lastError == "" {
+			continue
+		}
+		ch <- e.newConstMetric(
+			collectorLastError,
+			prometheus.GaugeValue,
+			float64(run.lastErrorTime.UnixNano())/1e9,
+			e.Client.System.ID,
+			name,
+			run.errorClass,
+		)
+	}
+}
+
+// CollectorStatuses returns the status of all collectors, including the
+// disabled ones.
+func (e *Exporter) CollectorStatuses() []CollectorStatus {
+	e.collectorsMu.Lock()
+	defer e.collectorsMu.Unlock()
+	var statuses []CollectorStatus
+	for _, c := range collectorRegistry {
+		status := CollectorStatus{Name: c.name, Enabled: c.isEnabled(e)}
+		name := c.name
+		if c.reportedBy != "" {
+			name = c.reportedBy
+		}
+		if run, exists := e.collectorRuns[name]; exists && status.Enabled {
+			start := run.start
+			status.LastRun = &start
+			status.LastDurationSeconds = run.duration.Seconds()
+			status.LastRunErrors = run.errors
+			status.Panics = run.panics
+			if run.lastError != "" {
+				errorTime := run.lastErrorTime
+				status.LastError = run.lastError
+				status.LastErrorClass = run.errorClass
+				status.LastErrorTime = &errorTime
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// CollectorsHandler returns the handler of the GET /api/v1/collectors
+// endpoint, which lists the collectors with their status, e.g. for fleet
+// tooling verifying the configuration of the exporters.
+func (e *Exporter) CollectorsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(e.CollectorStatuses()); err != nil {
+			level.Error(e.logger).Log(
+				"msg", "failed to encode collector statuses",
+				"error", err.Error(),
+			)
+		}
+	})
+}
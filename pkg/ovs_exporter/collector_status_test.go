@@ -0,0 +1,182 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollectorStatuses(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.lastCollection = time.Now()
+
+	e.startCollector("coverage")
+	e.recordCollectorError("AppListCommands() failed", errors.New("connection refused"))
+	e.startCollector("memory")
+	e.startCollector("coverage")
+	e.stopCollector()
+	e.recordCollectorError("unrelated failure", errors.New("outside of the collectors"))
+
+	statuses := make(map[string]CollectorStatus)
+	for _, status := range e.CollectorStatuses() {
+		statuses[status.Name] = status
+	}
+	coverage := statuses["coverage"]
+	if !coverage.Enabled || coverage.LastRun == nil || coverage.LastRunErrors != 1 {
+		t.Errorf("Unexpected coverage status %+v", coverage)
+	}
+	if coverage.LastError != "AppListCommands() failed: connection refused" || coverage.LastErrorClass != "connection" {
+		t.Errorf("Unexpected last error %q of class %q", coverage.LastError, coverage.LastErrorClass)
+	}
+	if memory := statuses["memory"]; memory.LastRun == nil || memory.LastRunErrors != 0 || memory.LastError != "" {
+		t.Errorf("Unexpected memory status %+v", memory)
+	}
+	if rate := statuses["coverage_rate"]; rate.Enabled || rate.LastRun != nil {
+		t.Errorf("Expected the coverage rates to be disabled, got %+v", rate)
+	}
+	if pmd := statuses["pmd"]; !pmd.Enabled || pmd.LastRun != nil {
+		t.Errorf("Expected the PMD collector not to have run, got %+v", pmd)
+	}
+
+	// A new collection resets the errors, but keeps the last error.
+	e.lastCollection = e.lastCollection.Add(time.Second)
+	e.startCollector("coverage")
+	e.stopCollector()
+	for _, status := range e.CollectorStatuses() {
+		if status.Name == "coverage" && (status.LastRunErrors != 0 || status.LastError == "") {
+			t.Errorf("Unexpected coverage status after a new collection %+v", status)
+		}
+	}
+}
+
+func TestCollectCollectorErrorMetrics(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.lastCollection = time.Now()
+
+	e.startCollector("coverage")
+	e.recordCollectorError("GetAppCoverageMetrics() failed", errors.New("signal: killed, timeout after 2s"))
+	e.startCollector("memory")
+	e.stopCollector()
+
+	ch := make(chan prometheus.Metric, len(e.collectorRuns))
+	e.collectCollectorErrorMetrics(ch)
+	close(ch)
+	var metrics []*dto.Metric
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		metrics = append(metrics, pb)
+	}
+	if len(metrics) != 1 {
+		t.Fatalf("Expected the last error of a single collector, got %d", len(metrics))
+	}
+	labels := make(map[string]string)
+	for _, label := range metrics[0].GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	if labels["collector"] != "coverage" || labels["error_class"] != "timeout" {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+	if value := metrics[0].GetGauge().GetValue(); value < float64(e.lastCollection.Unix()) {
+		t.Errorf("Expected the time of the error, got %v", value)
+	}
+}
+
+func TestGatherMetricsRecordsSystemError(t *testing.T) {
+	// The failure of the system collector, logged as a warning, sets up to
+	// 0 and must be visible as the last error of the collector.
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.GatherMetrics()
+	for _, status := range e.CollectorStatuses() {
+		if status.Name == "system" && (status.LastRunErrors == 0 || status.LastError == "") {
+			t.Errorf("Expected the failure of the system collector to be recorded, got %+v", status)
+		}
+	}
+}
+
+func TestCollectorRegistry(t *testing.T) {
+	// The collectors recorded by GatherMetrics must be the ones listed by
+	// check-config and /api/v1/collectors.
+	e := &Exporter{
+		Client:        ovsdb.NewOvsClient(),
+		logger:        log.NewNopLogger(),
+		ovnNorthbound: &ovnDatabase{name: "OVN_Northbound"},
+	}
+	registered := make(map[string]bool)
+	for _, c := range collectorRegistry {
+		if registered[c.name] {
+			t.Errorf("Collector %s registered twice", c.name)
+		}
+		registered[c.name] = true
+	}
+	e.GatherMetrics()
+	for name := range e.collectorRuns {
+		if !registered[name] {
+			t.Errorf("Collector %s run by GatherMetrics is not registered", name)
+		}
+	}
+	if _, exists := e.collectorRuns["ovn_northbound"]; !exists {
+		t.Error("Expected the OVN northbound database collector to run")
+	}
+}
+
+func TestClassifyCollectorError(t *testing.T) {
+	for message, expected := range map[string]string{
+		"collector panicked: runtime error: index out of range [1] with length 0": "panic",
+		"GetPmdThreads() failed: context deadline exceeded":                       "timeout",
+		"failed to execute vlog/list for ovsdb-server: exit status 2":             "command",
+		"\"dpif-netdev/pmd-perf-show\" is not a valid command, exit status 2":     "unsupported",
+		"dial unix /var/run/openvswitch/db.sock: connect: permission denied":      "permission",
+		"parsing 'other_config' failed: unexpected type":                          "parse",
+		"no rows found in the Open_vSwitch table":                                 "other",
+	} {
+		if class := classifyCollectorError(message); class != expected {
+			t.Errorf("Expected class %q of %q, got %q", expected, message, class)
+		}
+	}
+}
+
+func TestCollectorsHandler(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	handler := e.CollectorsHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/collectors", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d for POST, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/collectors", nil))
+	var statuses []CollectorStatus
+	if err := json.NewDecoder(rec.Body).Decode(&statuses); err != nil {
+		t.Fatalf("Failed decoding the response: %v", err)
+	}
+	if len(statuses) != len(collectorRegistry) {
+		t.Errorf("Expected %d collectors, got %d", len(collectorRegistry), len(statuses))
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewCollector(t *testing.T) {
+	e := NewCollector()
+	if e.timeout != 2 || e.logger == nil || e.sysfsPath != "/sys" || e.pollInterval != 0 {
+		t.Errorf("Unexpected defaults: timeout %d, sysfs %q, poll interval %d", e.timeout, e.sysfsPath, e.pollInterval)
+	}
+
+	e = NewCollector(
+		WithOptions(Options{OvnControllerEnabled: true}),
+		WithLogger(log.NewNopLogger()),
+		WithTimeout(5),
+		WithPollInterval(30),
+		WithRunDir("/tmp/sandbox"),
+		WithDatabaseSocket("unix:/tmp/db.sock"),
+	)
+	if !e.ovnControllerEnabled || e.timeout != 5 || e.pollInterval != 30 {
+		t.Errorf("Unexpected options: ovn-controller %v, timeout %d, poll interval %d", e.ovnControllerEnabled, e.timeout, e.pollInterval)
+	}
+	if e.Client.Service.Vswitchd.File.Pid.Path != "/tmp/sandbox/ovs-vswitchd.pid" {
+		t.Errorf("Expected the run directory to be used, got %q", e.Client.Service.Vswitchd.File.Pid.Path)
+	}
+	if e.Client.Database.Vswitch.Socket.Remote != "unix:/tmp/db.sock" {
+		t.Errorf("Expected the database socket to be overridden, got %q", e.Client.Database.Vswitch.Socket.Remote)
+	}
+}
+
+func TestNewCollectorRegistry(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	NewCollector(WithRegistry(registry))
+	NewCollector(WithRegistry(registry))
+	if n, err := testutil.GatherAndCount(registry, "ovs_exporter_build_info"); err != nil || n != 1 {
+		t.Errorf("Expected the build information to be registered once, got %d (error: %v)", n, err)
+	}
+
+	NewCollector()
+	if n, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "ovs_exporter_build_info"); err != nil || n != 0 {
+		t.Errorf("Expected nothing to be registered with the default registry, got %d (error: %v)", n, err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pidfileState is the content and modification time of the pidfile of a
+// daemon.
+type pidfileState struct {
+	pid     int
+	modTime time.Time
+}
+
+// readPidfile returns the state of a pidfile. It only reads the pidfile,
+// hence works when the /proc of the daemons is not available.
+func readPidfile(path string) (pidfileState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return pidfileState{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pidfileState{}, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return pidfileState{}, fmt.Errorf("malformed pidfile %s: %s", path, err)
+	}
+	return pidfileState{pid: pid, modTime: info.ModTime()}, nil
+}
+
+// componentRestartTracker counts the restarts of the daemons from the
+// changes of their pidfiles between polls. A daemon restarted when its
+// pidfile holds another pid, or was rewritten, e.g. by a daemon restarted
+// with the same pid in a container.
+type componentRestartTracker struct {
+	states   map[string]pidfileState
+	restarts map[string]uint64
+}
+
+// observe records the state of the pidfile of a component and returns
+// whether the component restarted since the previous observation. The
+// first observation is not a restart.
+func (t *componentRestartTracker) observe(component string, state pidfileState) bool {
+	if t.states == nil {
+		t.states = make(map[string]pidfileState)
+		t.restarts = make(map[string]uint64)
+	}
+	previous, exists := t.states[component]
+	t.states[component] = state
+	if !exists {
+		t.restarts[component] = 0
+		return false
+	}
+	if state.pid == previous.pid && state.modTime.Equal(previous.modTime) {
+		return false
+	}
+	t.restarts[component]++
+	return true
+}
+
+// collectComponentRestartMetrics watches the pidfiles of ovsdb-server and
+// ovs-vswitchd, and exports the number of restarts of the daemons since
+// the exporter started.
+func (e *Exporter) collectComponentRestartMetrics() {
+	pidfiles := map[string]string{
+		"ovsdb-server": e.Client.Database.Vswitch.File.Pid.Path,
+		"ovs-vswitchd": e.Client.Service.Vswitchd.File.Pid.Path,
+	}
+	for component, path := range pidfiles {
+		state, err := readPidfile(path)
+		if err != nil {
+			level.Debug(e.logger).Log(
+				"msg", "failed to read pidfile",
+				"component", component,
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+		} else if e.componentRestarts.observe(component, state) {
+			level.Info(e.logger).Log(
+				"msg", "detected restart of component",
+				"component", component,
+				"system_id", e.Client.System.ID,
+				"pid", state.pid,
+			)
+		}
+		restarts, exists := e.componentRestarts.restarts[component]
+		if !exists {
+			continue
+		}
+		e.emit(e.newConstMetric(
+			componentRestarts,
+			prometheus.CounterValue,
+			float64(restarts),
+			e.Client.System.ID,
+			component,
+		))
+	}
+}
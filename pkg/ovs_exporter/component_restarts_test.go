@@ -0,0 +1,73 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadPidfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ovs-vswitchd.pid")
+	if err := os.WriteFile(path, []byte("4242\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	state, err := readPidfile(path)
+	if err != nil {
+		t.Fatalf("readPidfile() returned error: %v", err)
+	}
+	if state.pid != 4242 || state.modTime.IsZero() {
+		t.Errorf("Unexpected pidfile state %+v", state)
+	}
+
+	if err := os.WriteFile(path, []byte("ovs-vswitchd\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readPidfile(path); err == nil {
+		t.Error("Expected an error for a malformed pidfile")
+	}
+	if _, err := readPidfile(filepath.Join(t.TempDir(), "missing.pid")); err == nil {
+		t.Error("Expected an error for a missing pidfile")
+	}
+}
+
+func TestComponentRestartTracker(t *testing.T) {
+	var tracker componentRestartTracker
+	start := time.Unix(1760000000, 0)
+
+	if tracker.observe("ovs-vswitchd", pidfileState{pid: 100, modTime: start}) {
+		t.Error("Expected the first observation not to be a restart")
+	}
+	if tracker.observe("ovs-vswitchd", pidfileState{pid: 100, modTime: start}) {
+		t.Error("Expected an unchanged pidfile not to be a restart")
+	}
+	if !tracker.observe("ovs-vswitchd", pidfileState{pid: 200, modTime: start.Add(time.Minute)}) {
+		t.Error("Expected a new pid to be a restart")
+	}
+	// A daemon restarted in a container may get the same pid.
+	if !tracker.observe("ovs-vswitchd", pidfileState{pid: 200, modTime: start.Add(2 * time.Minute)}) {
+		t.Error("Expected a rewritten pidfile to be a restart")
+	}
+	tracker.observe("ovsdb-server", pidfileState{pid: 50, modTime: start})
+
+	if restarts := tracker.restarts["ovs-vswitchd"]; restarts != 2 {
+		t.Errorf("Expected 2 restarts of ovs-vswitchd, got %d", restarts)
+	}
+	if restarts, exists := tracker.restarts["ovsdb-server"]; !exists || restarts != 0 {
+		t.Errorf("Expected 0 restarts of ovsdb-server, got %d", restarts)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	openFlowVersion     = 0x04 // OpenFlow 1.3
+	openFlowHello       = 0
+	openFlowEchoRequest = 2
+	openFlowEchoReply   = 3
+	openFlowHeaderLen   = 8
+	openFlowDefaultPort = "6653"
+)
+
+// BridgeController holds an OpenFlow controller configured for a bridge.
+type BridgeController struct {
+	Bridge string
+	Target string
+}
+
+// GetBridgeControllers returns the controllers of all bridges.
+func (e *Exporter) GetBridgeControllers() ([]BridgeController, error) {
+	query := "SELECT _uuid, target FROM Controller"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	targets := make(map[string]string)
+	for _, row := range result.Rows {
+		uuid, dt, err := row.GetColumnValue("_uuid", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		if target, dt, err := row.GetColumnValue("target", result.Columns); err == nil && dt == "string" {
+			targets[uuid.(string)] = target.(string)
+		}
+	}
+
+	query = "SELECT name, controller FROM Bridge"
+	result, err = e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	var controllers []BridgeController
+	for _, row := range result.Rows {
+		name, dt, err := row.GetColumnValue("name", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		r, dt, err := row.GetColumnValue("controller", result.Columns)
+		if err != nil {
+			continue
+		}
+		// A set with a single uuid is returned as a string.
+		var uuids []string
+		switch dt {
+		case "string":
+			uuids = []string{r.(string)}
+		case "[]string":
+			uuids = r.([]string)
+		}
+		for _, uuid := range uuids {
+			if target, exists := targets[uuid]; exists {
+				controllers = append(controllers, BridgeController{Bridge: name.(string), Target: target})
+			}
+		}
+	}
+	sort.Slice(controllers, func(i, j int) bool {
+		if controllers[i].Bridge != controllers[j].Bridge {
+			return controllers[i].Bridge < controllers[j].Bridge
+		}
+		return controllers[i].Target < controllers[j].Target
+	})
+	return controllers, nil
+}
+
+// controllerAddress converts an active controller target, e.g.
+// tcp:192.0.2.1:6653 or unix:/var/run/controller.sock, to a network and
+// address. Passive and ssl targets are not supported, because measuring
+// them requires accepting connections or client certificates.
+func controllerAddress(target string) (string, string, error) {
+	parts := strings.SplitN(target, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("malformed controller target %q", target)
+	}
+	switch parts[0] {
+	case "tcp":
+		address := parts[1]
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(strings.Trim(address, "[]"), openFlowDefaultPort)
+		}
+		return "tcp", address, nil
+	case "unix":
+		return "unix", parts[1], nil
+	default:
+		return "", "", fmt.Errorf("unsupported controller target type %q", parts[0])
+	}
+}
+
+// writeOpenFlowMessage sends an OpenFlow message without a body.
+func writeOpenFlowMessage(w io.Writer, msgType uint8, xid uint32) error {
+	msg := make([]byte, openFlowHeaderLen)
+	msg[0] = openFlowVersion
+	msg[1] = msgType
+	binary.BigEndian.PutUint16(msg[2:4], openFlowHeaderLen)
+	binary.BigEndian.PutUint32(msg[4:8], xid)
+	_, err := w.Write(msg)
+	return err
+}
+
+// readOpenFlowMessage reads an OpenFlow message and returns its type and
+// transaction id, discarding its body.
+func readOpenFlowMessage(r io.Reader) (uint8, uint32, error) {
+	header := make([]byte, openFlowHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, 0, err
+	}
+	length := binary.BigEndian.Uint16(header[2:4])
+	if length < openFlowHeaderLen {
+		return 0, 0, fmt.Errorf("malformed OpenFlow message length %d", length)
+	}
+	if _, err := io.CopyN(io.Discard, r, int64(length-openFlowHeaderLen)); err != nil {
+		return 0, 0, err
+	}
+	return header[1], binary.BigEndian.Uint32(header[4:8]), nil
+}
+
+// ProbeController connects to an OpenFlow controller and returns the
+// round-trip time of an echo request.
+func ProbeController(target string, timeout time.Duration) (time.Duration, error) {
+	network, address, err := controllerAddress(target)
+	if err != nil {
+		return 0, err
+	}
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	if err := writeOpenFlowMessage(conn, openFlowHello, 1); err != nil {
+		return 0, err
+	}
+	const echoXid = 2
+	start := time.Now()
+	if err := writeOpenFlowMessage(conn, openFlowEchoRequest, echoXid); err != nil {
+		return 0, err
+	}
+	for {
+		msgType, xid, err := readOpenFlowMessage(conn)
+		if err != nil {
+			return 0, err
+		}
+		// Other messages, e.g. the hello or a features request of the
+		// controller, are ignored.
+		if msgType == openFlowEchoReply && xid == echoXid {
+			return time.Since(start), nil
+		}
+	}
+}
+
+// collectControllerRttMetrics exports the OpenFlow echo round-trip time
+// of the controllers of all bridges.
+func (e *Exporter) collectControllerRttMetrics() {
+	e.IncrementRequestCounter()
+	controllers, err := e.GetBridgeControllers()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetBridgeControllers() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetBridgeControllers() failed", err)
+		return
+	}
+	for _, c := range controllers {
+		e.IncrementRequestCounter()
+		rtt, err := ProbeController(c.Target, time.Duration(e.timeout)*time.Second)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "ProbeController() failed",
+				"system_id", e.Client.System.ID,
+				"bridge", c.Bridge,
+				"target", c.Target,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("ProbeController() failed", err)
+			continue
+		}
+		e.emit(e.newConstMetric(
+			bridgeControllerRtt,
+			prometheus.GaugeValue,
+			rtt.Seconds(),
+			e.Client.System.ID,
+			c.Bridge,
+			c.Target,
+		))
+	}
+}
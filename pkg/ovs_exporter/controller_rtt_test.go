@@ -0,0 +1,78 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestControllerAddress(t *testing.T) {
+	tests := []struct {
+		target  string
+		network string
+		address string
+		ok      bool
+	}{
+		{"tcp:192.0.2.1:6633", "tcp", "192.0.2.1:6633", true},
+		{"tcp:192.0.2.1", "tcp", "192.0.2.1:6653", true},
+		{"tcp:[2001:db8::1]", "tcp", "[2001:db8::1]:6653", true},
+		{"unix:/var/run/ctl.sock", "unix", "/var/run/ctl.sock", true},
+		{"ssl:192.0.2.1:6653", "", "", false},
+		{"ptcp:6653", "", "", false},
+		{"tcp:", "", "", false},
+	}
+	for _, tt := range tests {
+		network, address, err := controllerAddress(tt.target)
+		if (err == nil) != tt.ok || network != tt.network || address != tt.address {
+			t.Errorf("controllerAddress(%q) = %q, %q, %v", tt.target, network, address, err)
+		}
+	}
+}
+
+func TestProbeController(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() returned error: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		writeOpenFlowMessage(conn, openFlowHello, 10)
+		for {
+			msgType, xid, err := readOpenFlowMessage(conn)
+			if err != nil {
+				return
+			}
+			if msgType == openFlowEchoRequest {
+				writeOpenFlowMessage(conn, openFlowEchoReply, xid)
+			}
+		}
+	}()
+
+	rtt, err := ProbeController("tcp:"+ln.Addr().String(), 2*time.Second)
+	if err != nil {
+		t.Fatalf("ProbeController() returned error: %v", err)
+	}
+	if rtt <= 0 || rtt > 2*time.Second {
+		t.Errorf("Unexpected round-trip time %v", rtt)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// counterResetPolls is the number of consecutive polls a counter must be
+// lower than its last accepted value before the decrease is accepted as a
+// reset, e.g. of the statistics of a reconfigured DPDK port.
+const counterResetPolls = 3
+
+// counterSample is the last accepted value of a counter and the number of
+// consecutive polls it was observed lower than that.
+type counterSample struct {
+	value     float64
+	decreases int
+}
+
+// counterSanityChecker detects counters decreasing between polls although
+// the daemon reporting them kept running, e.g. the garbage statistics
+// reported for interfaces after a hot-unplug. Such samples are replaced by
+// the last accepted value, so that rate() does not see a bogus reset.
+type counterSanityChecker struct {
+	pid     int
+	samples pollKeys[*counterSample]
+}
+
+func newCounterSanityChecker() *counterSanityChecker {
+	return &counterSanityChecker{}
+}
+
+// begin starts a poll of the counters reported by the daemon with the pid.
+// The counters are reset legitimately when the daemon restarted, so the
+// previous values are forgotten.
+func (c *counterSanityChecker) begin(pid int) {
+	if pid != c.pid {
+		c.samples.reset()
+		c.pid = pid
+	}
+	c.samples.begin()
+}
+
+// check returns the value to export for the counter identified by key and
+// whether the observed value is suspect.
+func (c *counterSanityChecker) check(key string, value float64) (float64, bool) {
+	sample, exists := c.samples.get(key)
+	if !exists {
+		c.samples.set(key, &counterSample{value: value})
+		return value, false
+	}
+	if value >= sample.value {
+		sample.value = value
+		sample.decreases = 0
+		return value, false
+	}
+	sample.decreases++
+	if sample.decreases >= counterResetPolls {
+		sample.value = value
+		sample.decreases = 0
+		return value, false
+	}
+	return sample.value, true
+}
+
+// end forgets the counters not checked during the poll, e.g. of removed
+// interfaces.
+func (c *counterSanityChecker) end() {
+	c.samples.end()
+}
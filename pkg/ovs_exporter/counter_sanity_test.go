@@ -0,0 +1,64 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestCounterSanityChecker(t *testing.T) {
+	c := newCounterSanityChecker()
+	steps := []struct {
+		pid     int
+		value   float64
+		want    float64
+		suspect bool
+	}{
+		{100, 10, 10, false},
+		{100, 20, 20, false},
+		// Garbage after a hot-unplug is replaced by the last value.
+		{100, 3, 20, true},
+		{100, 25, 25, false},
+		// A counter staying lower is eventually accepted as a reset.
+		{100, 5, 25, true},
+		{100, 6, 25, true},
+		{100, 7, 7, false},
+		// A restart of the daemon resets the counters.
+		{200, 1, 1, false},
+	}
+	for i, step := range steps {
+		c.begin(step.pid)
+		value, suspect := c.check("uuid/rx_packets", step.value)
+		c.end()
+		if value != step.want || suspect != step.suspect {
+			t.Errorf("Step %d: expected (%v, %v), got (%v, %v)", i, step.want, step.suspect, value, suspect)
+		}
+	}
+}
+
+func TestCounterSanityCheckerForgetsRemovedCounters(t *testing.T) {
+	c := newCounterSanityChecker()
+	c.begin(100)
+	c.check("a/rx_packets", 10)
+	c.check("b/rx_packets", 10)
+	c.end()
+
+	c.begin(100)
+	c.check("a/rx_packets", 11)
+	c.end()
+	if _, exists := c.samples.values["b/rx_packets"]; exists {
+		t.Errorf("Expected the counter of the removed interface to be forgotten")
+	}
+}
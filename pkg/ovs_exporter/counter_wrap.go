@@ -0,0 +1,114 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// counter32Range is the range of the 32-bit counters.
+const counter32Range = 1 << 32
+
+// ParseCounterWrapKeys parses a comma-separated list of shell patterns of
+// the Interface:statistics keys reported by some drivers as 32-bit
+// counters, e.g. "rx_crc_err,rx_q*_errors".
+func ParseCounterWrapKeys(s string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(s, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("malformed interface statistics key pattern %q: %s", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// wrapSample is the last raw value of a 32-bit counter, the offset added
+// to its raw values and the number of times it wrapped.
+type wrapSample struct {
+	raw    float64
+	offset float64
+	wraps  float64
+}
+
+// counterWrapTracker extends the 32-bit counters of the interfaces to 64
+// bits. A counter lower than on the previous poll wrapped when the implied
+// increase, from the previous value to 2^32 and from 0 to the new value, is
+// less than half the range; otherwise it was reset, e.g. when the port was
+// reconfigured.
+type counterWrapTracker struct {
+	patterns []string
+	pid      int
+	samples  pollKeys[*wrapSample]
+}
+
+func newCounterWrapTracker(patterns []string) *counterWrapTracker {
+	return &counterWrapTracker{
+		patterns: patterns,
+	}
+}
+
+// matches returns whether the statistics key is a 32-bit counter.
+func (c *counterWrapTracker) matches(key string) bool {
+	for _, pattern := range c.patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// begin starts a poll of the counters reported by the daemon with the pid.
+// The counters are reset when the daemon restarted, so the offsets are
+// forgotten.
+func (c *counterWrapTracker) begin(pid int) {
+	if pid != c.pid {
+		c.samples.reset()
+		c.pid = pid
+	}
+	c.samples.begin()
+}
+
+// unwrap returns the 64-bit value of the counter identified by id and the
+// number of times it wrapped.
+func (c *counterWrapTracker) unwrap(id string, raw float64) (float64, float64) {
+	sample, exists := c.samples.get(id)
+	if !exists {
+		c.samples.set(id, &wrapSample{raw: raw})
+		return raw, 0
+	}
+	if raw < sample.raw {
+		if sample.raw < counter32Range && counter32Range-sample.raw+raw < counter32Range/2 {
+			sample.offset += counter32Range
+			sample.wraps++
+		} else {
+			sample.offset = 0
+		}
+	}
+	sample.raw = raw
+	return raw + sample.offset, sample.wraps
+}
+
+// end forgets the counters not seen during the poll, e.g. of removed
+// interfaces.
+func (c *counterWrapTracker) end() {
+	c.samples.end()
+}
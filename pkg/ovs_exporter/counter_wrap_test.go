@@ -0,0 +1,64 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCounterWrapKeys(t *testing.T) {
+	patterns, err := ParseCounterWrapKeys(" rx_crc_err, rx_q*_errors ,")
+	if err != nil {
+		t.Fatalf("ParseCounterWrapKeys() returned error: %v", err)
+	}
+	if expected := []string{"rx_crc_err", "rx_q*_errors"}; !reflect.DeepEqual(patterns, expected) {
+		t.Errorf("Expected %v, got %v", expected, patterns)
+	}
+	if _, err := ParseCounterWrapKeys("rx_[q"); err == nil {
+		t.Error("Expected an error for a malformed pattern")
+	}
+}
+
+func TestCounterWrapTracker(t *testing.T) {
+	c := newCounterWrapTracker([]string{"rx_q*_errors"})
+	if !c.matches("rx_q3_errors") || c.matches("rx_errors") {
+		t.Errorf("Unexpected matches of %v", c.patterns)
+	}
+
+	for i, step := range []struct {
+		raw, value, wraps float64
+	}{
+		{4294967000, 4294967000, 0},
+		// Wrapped past 2^32.
+		{200, 4294967496, 1},
+		{1000, 4294968296, 1},
+		// Reset: the implied increase exceeds half the range.
+		{10, 10, 1},
+	} {
+		c.begin(100)
+		value, wraps := c.unwrap("uuid/rx_q0_errors", step.raw)
+		c.end()
+		if value != step.value || wraps != step.wraps {
+			t.Errorf("Step %d: expected %v with %v wraps, got %v with %v wraps", i, step.value, step.wraps, value, wraps)
+		}
+	}
+
+	// The offsets are forgotten when the daemon restarted.
+	c.begin(101)
+	if value, _ := c.unwrap("uuid/rx_q0_errors", 5); value != 5 {
+		t.Errorf("Expected 5 after a restart, got %v", value)
+	}
+}
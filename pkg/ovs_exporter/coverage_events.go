@@ -0,0 +1,122 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// coverageEvent is the dedicated metric of a coverage event. The metrics
+// are labeled with the system id, the component and the label value,
+// distinguishing the events of the same metric.
+type coverageEvent struct {
+	desc  *prometheus.Desc
+	label string
+}
+
+// coverageEvents maps the coverage events of interest to dedicated
+// metrics, in addition to ovs_coverage_total.
+var coverageEvents = map[string]coverageEvent{
+	// The outcomes of the OVSDB IDL transactions of a daemon, e.g. the
+	// updates of the Interface table by ovs-vswitchd.
+	"txn_success":     {ovsdbTransactions, "success"},
+	"txn_unchanged":   {ovsdbTransactions, "unchanged"},
+	"txn_incomplete":  {ovsdbTransactions, "incomplete"},
+	"txn_aborted":     {ovsdbTransactions, "aborted"},
+	"txn_try_again":   {ovsdbTransactions, "try_again"},
+	"txn_not_locked":  {ovsdbTransactions, "not_locked"},
+	"txn_error":       {ovsdbTransactions, "error"},
+	"txn_uncommitted": {ovsdbTransactions, "uncommitted"},
+	// The OpenFlow messages exchanged with the controllers. OVS has no
+	// dedicated counters of flow-mods and packet-ins; the received messages
+	// are mostly flow-mods, the sent ones mostly packet-ins.
+	"ofproto_recv_openflow": {openflowMessages, "received"},
+	"ofproto_packet_out":    {openflowMessages, "packet_out"},
+	"rconn_sent":            {openflowMessages, "sent"},
+	"rconn_queued":          {openflowMessages, "queued"},
+	"rconn_discarded":       {openflowMessages, "discarded"},
+	"rconn_overflow":        {openflowMessages, "overflow"},
+	"packet_in_overflow":    {openflowMessages, "packet_in_overflow"},
+	// The revalidations of the datapath flows of ovs-vswitchd by reason,
+	// e.g. changes of the flow tables by flow-mods.
+	"rev_flow_table":     {revalidations, "flow_table"},
+	"rev_reconfigure":    {revalidations, "reconfigure"},
+	"rev_port_toggled":   {revalidations, "port_toggled"},
+	"rev_mac_learning":   {revalidations, "mac_learning"},
+	"rev_mcast_snooping": {revalidations, "mcast_snooping"},
+	"rev_bond":           {revalidations, "bond"},
+	"rev_stp":            {revalidations, "stp"},
+	"rev_rstp":           {revalidations, "rstp"},
+	// The operations of ovs-vswitchd on the datapath flows.
+	"dpif_flow_put":          {datapathFlowOperations, "put"},
+	"dpif_flow_del":          {datapathFlowOperations, "del"},
+	"dpif_flow_get":          {datapathFlowOperations, "get"},
+	"dpif_flow_flush":        {datapathFlowOperations, "flush"},
+	"dpif_execute":           {datapathFlowOperations, "execute"},
+	"dpif_execute_with_help": {datapathFlowOperations, "execute_with_help"},
+	// The failed installations of datapath flows. OVS only logs the errors
+	// of the datapath, e.g. ENOSPC; the upcalls whose flow already exists,
+	// i.e. EEXIST, and the installations skipped at the flow limit are
+	// counted.
+	"handler_duplicate_upcall": {datapathFlowOperationFailures, "duplicate_upcall"},
+	"upcall_flow_limit_hit":    {datapathFlowOperationFailures, "flow_limit"},
+	"upcall_ukey_contention":   {datapathFlowOperationFailures, "ukey_contention"},
+	// The packets dropped because they could not be recirculated: by the
+	// userspace datapath beyond the maximum recirculation depth, and by the
+	// translation when the recirculation context of a recirculated packet
+	// is unknown, e.g. after its recirculation ID was freed, or conflicts
+	// with the state of the packet. OVS does not report the usage of its
+	// recirculation ID pool.
+	"datapath_drop_recirc_error":           {recirculationFailures, "depth_exceeded"},
+	"drop_action_no_recirculation_context": {recirculationFailures, "no_context"},
+	"drop_action_recirculation_conflict":   {recirculationFailures, "conflict"},
+	// The ring events of the AF_XDP interfaces of ovs-vswitchd.
+	"afxdp_cq_empty": {afxdpEvents, "cq_empty"},
+	"afxdp_cq_skip":  {afxdpEvents, "cq_skip"},
+	"afxdp_fq_full":  {afxdpEvents, "fq_full"},
+	"afxdp_tx_full":  {afxdpEvents, "tx_full"},
+	// The events of the netlink sockets, e.g. the upcall sockets of the
+	// handler threads of ovs-vswitchd. An overflow means that the kernel
+	// dropped messages because the receive buffer of the socket was full,
+	// e.g. upcalls of the kernel datapath, which are otherwise only counted
+	// as lost by dpif/show.
+	"netlink_overflow":   {netlinkSocketEvents, "overflow"},
+	"netlink_received":   {netlinkSocketEvents, "received"},
+	"netlink_recv_jumbo": {netlinkSocketEvents, "recv_jumbo"},
+	"netlink_sent":       {netlinkSocketEvents, "sent"},
+}
+
+// collectCoverageEvents exports the dedicated metrics of the coverage
+// events of a component.
+func (e *Exporter) collectCoverageEvents(component string, metrics map[string]map[string]float64) {
+	for name, metric := range metrics {
+		event, exists := coverageEvents[name]
+		if !exists {
+			continue
+		}
+		total, exists := metric["total"]
+		if !exists {
+			continue
+		}
+		e.emit(e.newConstMetric(
+			event.desc,
+			prometheus.CounterValue,
+			total,
+			e.Client.System.ID,
+			component,
+			event.label,
+		))
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollectCoverageEvents(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectCoverageEvents("vswitchd-service", map[string]map[string]float64{
+		"txn_success":   {"5s": 0.2, "1m": 0.1, "1h": 0.1, "total": 42},
+		"txn_aborted":   {"total": 3},
+		"netdev_sent":   {"total": 1000},
+		"txn_try_again": {"5s": 0},
+	})
+
+	statuses := make(map[string]float64)
+	for _, m := range e.metrics {
+		if m.Desc() != ovsdbTransactions {
+			t.Fatalf("Unexpected metric %s", m.Desc())
+		}
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range pb.GetLabel() {
+			if label.GetName() == "status" {
+				statuses[label.GetValue()] = pb.GetCounter().GetValue()
+			}
+		}
+	}
+	if len(statuses) != 2 || statuses["success"] != 42 || statuses["aborted"] != 3 {
+		t.Errorf("Unexpected transactions by status: %v", statuses)
+	}
+}
+
+func TestCollectCoverageEventsOpenflow(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectCoverageEvents("vswitchd-service", map[string]map[string]float64{
+		"ofproto_recv_openflow": {"total": 1200},
+		"rconn_sent":            {"total": 300},
+		"rev_flow_table":        {"total": 17},
+	})
+
+	values := make(map[string]float64)
+	for _, m := range e.metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range pb.GetLabel() {
+			switch label.GetName() {
+			case "type", "reason":
+				values[label.GetValue()] = pb.GetCounter().GetValue()
+			}
+		}
+	}
+	if len(values) != 3 || values["received"] != 1200 || values["sent"] != 300 || values["flow_table"] != 17 {
+		t.Errorf("Unexpected OpenFlow metrics: %v", values)
+	}
+}
+
+func TestCollectCoverageEventsFlowOperations(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectCoverageEvents("vswitchd-service", map[string]map[string]float64{
+		"dpif_flow_put":            {"total": 2400},
+		"handler_duplicate_upcall": {"total": 5},
+		"upcall_flow_limit_hit":    {"total": 2},
+	})
+
+	values := make(map[string]float64)
+	for _, m := range e.metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range pb.GetLabel() {
+			switch label.GetName() {
+			case "operation", "reason":
+				values[label.GetValue()] = pb.GetCounter().GetValue()
+			}
+		}
+	}
+	if len(values) != 3 || values["put"] != 2400 || values["duplicate_upcall"] != 5 || values["flow_limit"] != 2 {
+		t.Errorf("Unexpected flow operation metrics: %v", values)
+	}
+}
+
+func TestCollectCoverageEventsRecirculation(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectCoverageEvents("vswitchd-service", map[string]map[string]float64{
+		"datapath_drop_recirc_error":           {"total": 4},
+		"drop_action_no_recirculation_context": {"total": 9},
+	})
+
+	values := make(map[string]float64)
+	for _, m := range e.metrics {
+		if m.Desc() != recirculationFailures {
+			t.Fatalf("Unexpected metric %s", m.Desc())
+		}
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range pb.GetLabel() {
+			if label.GetName() == "reason" {
+				values[label.GetValue()] = pb.GetCounter().GetValue()
+			}
+		}
+	}
+	if len(values) != 2 || values["depth_exceeded"] != 4 || values["no_context"] != 9 {
+		t.Errorf("Unexpected recirculation metrics: %v", values)
+	}
+}
+
+func TestCollectCoverageEventsNetlink(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectCoverageEvents("vswitchd-service", map[string]map[string]float64{
+		"netlink_overflow": {"5s": 0.4, "total": 12},
+		"netlink_received": {"total": 5600},
+	})
+
+	values := make(map[string]float64)
+	for _, m := range e.metrics {
+		if m.Desc() != netlinkSocketEvents {
+			t.Fatalf("Unexpected metric %s", m.Desc())
+		}
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range pb.GetLabel() {
+			if label.GetName() == "event" {
+				values[label.GetValue()] = pb.GetCounter().GetValue()
+			}
+		}
+	}
+	if len(values) != 2 || values["overflow"] != 12 || values["received"] != 5600 {
+		t.Errorf("Unexpected netlink metrics: %v", values)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// cachedCoverage holds the coverage counters of a component read on the
+// last refresh.
+type cachedCoverage struct {
+	metrics map[string]map[string]float64
+	// polls is the number of polls served since the refresh, including
+	// the refresh.
+	polls int
+}
+
+// getCoverageMetrics returns the coverage counters of a component. With
+// coverageFullPolls above 1, the full set of counters is read with
+// coverage/show only every coverageFullPolls polls and cached in between,
+// reducing the load of ovs-appctl on the daemons. It reports whether the
+// counters were served from the cache.
+func (e *Exporter) getCoverageMetrics(component string) (map[string]map[string]float64, bool, error) {
+	if e.coverageFullPolls <= 1 {
+		metrics, err := e.Client.GetAppCoverageMetrics(component)
+		return metrics, false, err
+	}
+	if c, exists := e.coverageCache[component]; exists && c.polls < e.coverageFullPolls {
+		c.polls++
+		return c.metrics, true, nil
+	}
+	metrics, err := e.Client.GetAppCoverageMetrics(component)
+	if err != nil {
+		return nil, false, err
+	}
+	if e.coverageCache == nil {
+		e.coverageCache = make(map[string]*cachedCoverage)
+	}
+	e.coverageCache[component] = &cachedCoverage{metrics: metrics, polls: 1}
+	return metrics, false, nil
+}
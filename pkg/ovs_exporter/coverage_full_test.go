@@ -0,0 +1,47 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestGetCoverageMetricsCached(t *testing.T) {
+	metrics := map[string]map[string]float64{"txn_success": {"total": 120}}
+	e := &Exporter{
+		Client:            ovsdb.NewOvsClient(),
+		coverageFullPolls: 3,
+		coverageCache: map[string]*cachedCoverage{
+			"ovn-northd": {metrics: metrics, polls: 1},
+		},
+	}
+	for i := 2; i <= 3; i++ {
+		cachedMetrics, cached, err := e.getCoverageMetrics("ovn-northd")
+		if err != nil || !cached || !reflect.DeepEqual(cachedMetrics, metrics) {
+			t.Errorf("Poll %d: expected the cached counters, got %v (cached: %v, error: %v)", i, cachedMetrics, cached, err)
+		}
+	}
+	// The cache expired, the counters are read from the daemon, which is
+	// not supported.
+	if _, cached, err := e.getCoverageMetrics("ovn-northd"); err == nil || cached {
+		t.Errorf("Expected the counters to be refreshed, got cached: %v, error: %v", cached, err)
+	}
+	if c := e.coverageCache["ovn-northd"]; c.polls != 3 {
+		t.Errorf("Expected a failed refresh to keep the cache, got %d polls", c.polls)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "time"
+
+// coverageSample is the total of a coverage counter observed at a poll.
+type coverageSample struct {
+	total float64
+	at    time.Time
+}
+
+// coverageRateTracker derives per-minute rates from the totals of the
+// coverage counters observed at consecutive polls.
+type coverageRateTracker struct {
+	samples map[string]coverageSample
+	rates   map[string]float64
+}
+
+func newCoverageRateTracker() *coverageRateTracker {
+	return &coverageRateTracker{
+		samples: make(map[string]coverageSample),
+		rates:   make(map[string]float64),
+	}
+}
+
+// observe records the total of the counter identified by key and returns
+// its rate per minute since the previous poll. No rate is returned for the
+// first observation and after the counter was reset, e.g. by a restart of
+// the daemon.
+func (t *coverageRateTracker) observe(key string, total float64, now time.Time) (float64, bool) {
+	prev, exists := t.samples[key]
+	t.samples[key] = coverageSample{total: total, at: now}
+	delete(t.rates, key)
+	if !exists || total < prev.total {
+		return 0, false
+	}
+	elapsed := now.Sub(prev.at)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	rate := (total - prev.total) / elapsed.Minutes()
+	t.rates[key] = rate
+	return rate, true
+}
+
+// last returns the rate of the counter identified by key returned by the
+// last observation, if any.
+func (t *coverageRateTracker) last(key string) (float64, bool) {
+	rate, exists := t.rates[key]
+	return rate, exists
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoverageRateTracker(t *testing.T) {
+	tracker := newCoverageRateTracker()
+	start := time.Now()
+
+	if _, ok := tracker.observe("ovs-vswitchd/txn_success", 100, start); ok {
+		t.Error("Expected no rate for the first observation")
+	}
+	rate, ok := tracker.observe("ovs-vswitchd/txn_success", 130, start.Add(30*time.Second))
+	if !ok || rate != 60 {
+		t.Errorf("Expected a rate of 60/min, got %v (ok: %v)", rate, ok)
+	}
+	if rate, ok := tracker.last("ovs-vswitchd/txn_success"); !ok || rate != 60 {
+		t.Errorf("Expected the last rate of 60/min, got %v (ok: %v)", rate, ok)
+	}
+	if _, ok := tracker.observe("ovs-vswitchd/txn_success", 10, start.Add(time.Minute)); ok {
+		t.Error("Expected no rate after a counter reset")
+	}
+	if _, ok := tracker.last("ovs-vswitchd/txn_success"); ok {
+		t.Error("Expected no last rate after a counter reset")
+	}
+	rate, ok = tracker.observe("ovs-vswitchd/txn_success", 10, start.Add(2*time.Minute))
+	if !ok || rate != 0 {
+		t.Errorf("Expected a rate of 0/min, got %v (ok: %v)", rate, ok)
+	}
+}
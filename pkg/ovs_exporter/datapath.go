@@ -0,0 +1,139 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectDatapathMetrics exports the datapaths reported by dpif/show and
+// dpctl/show, their lookups, flows and masks, and the bridges and
+// interfaces attached to them.
+func (e *Exporter) collectDatapathMetrics(component string) {
+	dps, brs, intfs, err := e.Client.GetAppDatapath(component)
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetAppDatapath() failed",
+			"component", component,
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetAppDatapath() failed", err)
+		return
+	}
+	e.pendingSnapshot.Datapaths = dps
+	for _, dp := range dps {
+		dpIntefaceCount := 0
+		for _, br := range brs {
+			if dp.Name != br.DatapathName {
+				continue
+			}
+			brIntefaceCount := 0
+			for _, intf := range intfs {
+				if dp.Name != intf.DatapathName || br.Name != intf.BridgeName {
+					continue
+				}
+				dpIntefaceCount += 1
+				brIntefaceCount += 1
+				e.emit(e.newConstMetric(
+					dpInterface,
+					prometheus.GaugeValue,
+					1,
+					e.Client.System.ID,
+					dp.Name,
+					br.Name,
+					intf.Name,
+					fmt.Sprintf("%0.f", intf.OfPort),
+					fmt.Sprintf("%0.f", intf.Index),
+					intf.Type,
+				))
+				e.emit(e.newConstMetric(
+					dpPort,
+					prometheus.GaugeValue,
+					1,
+					e.Client.System.ID,
+					dp.Name,
+					fmt.Sprintf("%0.f", intf.Index),
+					intf.Name,
+				))
+			}
+			// Calculate the total number of interfaces per datapath
+			e.emit(e.newConstMetric(
+				dpBridgeInterfaceTotal,
+				prometheus.GaugeValue,
+				float64(brIntefaceCount),
+				e.Client.System.ID,
+				dp.Name,
+				br.Name,
+			))
+		}
+		// Add datapath hits and misses
+		e.emit(e.newConstMetric(
+			dpLookupsHit,
+			prometheus.CounterValue,
+			dp.Lookups.Hit,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		e.emit(e.newConstMetric(
+			dpLookupsMissed,
+			prometheus.CounterValue,
+			dp.Lookups.Missed,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		e.emit(e.newConstMetric(
+			dpLookupsLost,
+			prometheus.CounterValue,
+			dp.Lookups.Lost,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		// Add datapath flows
+		e.emit(e.newConstMetric(
+			dpFlowsTotal,
+			prometheus.GaugeValue,
+			dp.Flows,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		// Add datapath masks
+		e.emit(e.newConstMetric(
+			dpMasksHit,
+			prometheus.CounterValue,
+			dp.Masks.Hit,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		e.emit(e.newConstMetric(
+			dpMasksTotal,
+			prometheus.CounterValue,
+			dp.Masks.Total,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		e.emit(e.newConstMetric(
+			dpMasksHitRatio,
+			prometheus.GaugeValue,
+			dp.Masks.HitRatio,
+			e.Client.System.ID,
+			dp.Name,
+		))
+		e.collectDpMaskMetrics(dp.Name, dp.Masks.Total)
+	}
+}
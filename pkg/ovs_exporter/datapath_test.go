@@ -0,0 +1,135 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// dpifShowFixture is the dpif/show output of a userspace datapath. The
+// datapath port numbers differ from the OpenFlow port numbers.
+const dpifShowFixture = `netdev@ovs-netdev: hit:1869 missed:38
+  br-ex:
+    br-ex 65534/5: (tap)
+  br-int:
+    br-int 65534/1: (tap)
+    dpdk0 1/3: (dpdk: configured_rx_queues=2, configured_tx_queues=3, mtu=1500)
+    genev_sys_6081 3/2: (geneve: packet_type=ptap)
+    vhu1a2b3c4d-5e 2/4: (dpdkvhostuserclient: configured_rx_queues=1, mtu=1500)
+`
+
+const dpctlShowFixture = `netdev@ovs-netdev:
+  lookups: hit:1869 missed:38 lost:0
+  flows: 4
+  masks: hit:2233 total:3 hit/pkt:1.15
+  port 0: ovs-netdev (tap)
+  port 1: br-int (tap)
+  port 2: genev_sys_6081 (geneve: packet_type=ptap)
+  port 3: dpdk0 (dpdk: configured_rx_queues=2, configured_tx_queues=3, mtu=1500)
+  port 4: vhu1a2b3c4d-5e (dpdkvhostuserclient: configured_rx_queues=1, mtu=1500)
+  port 5: br-ex (tap)
+`
+
+// serveAppctl serves the replies of an ovs-vswitchd control socket until
+// the listener is closed.
+func serveAppctl(listener net.Listener, replies map[string]string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn) {
+			defer conn.Close()
+			decoder := json.NewDecoder(conn)
+			encoder := json.NewEncoder(conn)
+			for {
+				var req struct {
+					ID     interface{} `json:"id"`
+					Method string      `json:"method"`
+				}
+				if err := decoder.Decode(&req); err != nil {
+					return
+				}
+				encoder.Encode(map[string]interface{}{
+					"id":     req.ID,
+					"result": replies[req.Method],
+					"error":  nil,
+				})
+				if req.Method == "shutdown" {
+					return
+				}
+			}
+		}(conn)
+	}
+}
+
+func TestCollectDatapathMetricsPortMapping(t *testing.T) {
+	dir := t.TempDir()
+	listener, err := net.Listen("unix", filepath.Join(dir, "ovs-vswitchd.4242.ctl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go serveAppctl(listener, map[string]string{
+		"dpif/show":  dpifShowFixture,
+		"dpctl/show": dpctlShowFixture,
+	})
+
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.Client.System.RunDir = dir
+	e.Client.Service.Vswitchd.Process.ID = 4242
+	e.pendingSnapshot = &MetricsSnapshot{}
+	e.collectDatapathMetrics("vswitchd-service")
+	if e.errors != 0 {
+		t.Fatal("collectDatapathMetrics() failed to collect the datapath")
+	}
+
+	ports := make(map[string]string)
+	for _, m := range e.metrics {
+		if m.Desc() != dpPort {
+			continue
+		}
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+		labels := make(map[string]string)
+		for _, label := range pb.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels["datapath"] != "netdev@ovs-netdev" {
+			t.Errorf("Unexpected datapath %q", labels["datapath"])
+		}
+		ports[labels["port_no"]] = labels["name"]
+	}
+	expected := map[string]string{
+		"1": "br-int",
+		"2": "genev_sys_6081",
+		"3": "dpdk0",
+		"4": "vhu1a2b3c4d-5e",
+		"5": "br-ex",
+	}
+	if fmt.Sprint(ports) != fmt.Sprint(expected) {
+		t.Errorf("Expected the datapath ports %v, got %v", expected, ports)
+	}
+}
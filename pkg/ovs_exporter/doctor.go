@@ -0,0 +1,89 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"io"
+)
+
+// doctorHints are the remedies suggested by Doctor for the classes of the
+// errors of the collectors, see collectorErrorClasses.
+var doctorHints = map[string]string{
+	"panic":       "the collector has a bug, report it with the stack trace logged by the exporter",
+	"timeout":     "the daemon is overloaded or unresponsive, consider increasing -ovs.timeout",
+	"permission":  "run the exporter as root or as a member of the openvswitch group, or prefix the commands with -exec.wrappers, e.g. ovs-appctl=sudo -n",
+	"connection":  "verify that the daemon is running and the paths of its socket, pid and log files, e.g. -system.run.dir",
+	"unsupported": "the command is not supported by this version of OVS or OVN, the collector can be ignored",
+	"command":     "install the OVS, or OVN, utilities providing the command in the PATH of the exporter, or run the command by hand with the privileges of the exporter to see its error",
+}
+
+// doctorHint returns the remedy of an error of a collector, if known.
+func doctorHint(message string) string {
+	return doctorHints[classifyCollectorError(message)]
+}
+
+// Doctor connects to OVS, runs each enabled collector once and prints
+// whether it succeeded, with the reason of its failure and a suggested
+// remedy otherwise. It is meant to be run with the privileges of the
+// exporter. It returns an error when OVS is unreachable or at least one
+// collector failed.
+func (e *Exporter) Doctor(w io.Writer) error {
+	report := func(status, name, message string) {
+		fmt.Fprintf(w, "[%s] %s", status, name)
+		if message != "" {
+			fmt.Fprintf(w, ": %s", message)
+		}
+		fmt.Fprintln(w)
+		if status != "FAIL" {
+			return
+		}
+		if hint := doctorHint(message); hint != "" {
+			fmt.Fprintf(w, "       hint: %s\n", hint)
+		}
+	}
+
+	if err := e.Connect(); err != nil {
+		report("FAIL", "connect", err.Error())
+		return fmt.Errorf("failed to connect to %s: %w", e.Client.Database.Vswitch.Socket.Remote, err)
+	}
+	report(" OK ", "connect", e.Client.Database.Vswitch.Socket.Remote)
+
+	e.GatherMetrics()
+
+	failed := 0
+	for _, status := range e.CollectorStatuses() {
+		switch {
+		case !status.Enabled:
+			report("SKIP", status.Name, "disabled")
+		case status.LastRun == nil:
+			report("SKIP", status.Name, "not run")
+		case status.LastRunErrors > 0:
+			failed++
+			message := status.LastError
+			if message == "" {
+				message = fmt.Sprintf("%d error(s)", status.LastRunErrors)
+			}
+			report("FAIL", status.Name, message)
+		default:
+			report(" OK ", status.Name, "")
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d collector(s) failed", failed)
+	}
+	return nil
+}
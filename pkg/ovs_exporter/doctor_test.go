@@ -0,0 +1,55 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+func TestDoctorHint(t *testing.T) {
+	for message, expected := range map[string]string{
+		`GetOvnCtZones() failed: failed to execute dpctl/dump-conntrack: exec: "ovs-appctl": executable file not found in $PATH`: "install the OVS",
+		"GetLogInfo() failed: open /var/log/openvswitch/ovs-vswitchd.log: permission denied":                                     "-exec.wrappers",
+		"dial unix /var/run/openvswitch/db.sock: connect: no such file or directory":                                             "-system.run.dir",
+		`GetAppCoverageMetrics() failed: "coverage/read-counter" is not a valid command`:                                         "not supported",
+		"GetInterfaces() failed: i/o timeout":                                                                                    "-ovs.timeout",
+	} {
+		if hint := doctorHint(message); !strings.Contains(hint, expected) {
+			t.Errorf("Expected a hint containing %q for %q, got %q", expected, message, hint)
+		}
+	}
+	if hint := doctorHint("malformed output"); hint != "" {
+		t.Errorf("Expected no hint, got %q", hint)
+	}
+}
+
+func TestDoctorConnectFailure(t *testing.T) {
+	e := NewExporter(Options{Timeout: 1, Logger: log.NewNopLogger()})
+	e.Client.Database.Vswitch.Socket.Remote = "unix:" + filepath.Join(t.TempDir(), "db.sock")
+	e.Client.Database.Vswitch.File.SystemID.Path = filepath.Join(t.TempDir(), "system-id.conf")
+
+	var out bytes.Buffer
+	if err := e.Doctor(&out); err == nil {
+		t.Fatal("Expected Doctor() to fail without OVS")
+	}
+	if !strings.HasPrefix(out.String(), "[FAIL] connect: ") || !strings.Contains(out.String(), "hint: ") {
+		t.Errorf("Expected the connection failure with a hint, got %q", out.String())
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dpMaskChurnTracker derives the churn of the masks of the datapaths from
+// the number of masks reported on consecutive polls. The churn is the sum
+// of the absolute changes of the number of masks, a lower bound of the
+// masks added and removed, since the masks added and removed between two
+// polls cancel out.
+type dpMaskChurnTracker struct {
+	masks map[string]float64
+	churn map[string]float64
+}
+
+// observe records the number of masks of a datapath and returns its churn
+// since start. The masks of the first poll are not counted.
+func (t *dpMaskChurnTracker) observe(datapath string, masks float64) float64 {
+	if t.masks == nil {
+		t.masks = make(map[string]float64)
+		t.churn = make(map[string]float64)
+	}
+	if previous, exists := t.masks[datapath]; exists {
+		t.churn[datapath] += math.Abs(masks - previous)
+	}
+	t.masks[datapath] = masks
+	return t.churn[datapath]
+}
+
+// collectDpMaskMetrics exports the churn of the masks of a datapath and,
+// when an expected maximum is set, its number of masks relative to it.
+func (e *Exporter) collectDpMaskMetrics(datapath string, masks float64) {
+	e.emit(e.newConstMetric(
+		dpMasksChurn,
+		prometheus.CounterValue,
+		e.dpMaskChurn.observe(datapath, masks),
+		e.Client.System.ID,
+		datapath,
+	))
+	if e.dpMasksExpectedMax > 0 {
+		e.emit(e.newConstMetric(
+			dpMasksUsage,
+			prometheus.GaugeValue,
+			masks/float64(e.dpMasksExpectedMax),
+			e.Client.System.ID,
+			datapath,
+		))
+	}
+}
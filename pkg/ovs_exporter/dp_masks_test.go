@@ -0,0 +1,37 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestDpMaskChurnTracker(t *testing.T) {
+	var tracker dpMaskChurnTracker
+	for i, test := range []struct {
+		datapath string
+		masks    float64
+		churn    float64
+	}{
+		{"system@ovs-system", 20, 0},
+		{"system@ovs-system", 20, 0},
+		{"system@ovs-system", 35, 15},
+		{"netdev@ovs-netdev", 8, 0},
+		{"system@ovs-system", 30, 20},
+		{"netdev@ovs-netdev", 10, 2},
+	} {
+		if churn := tracker.observe(test.datapath, test.masks); churn != test.churn {
+			t.Errorf("Poll %d: expected a churn of %v for %s, got %v", i, test.churn, test.datapath, churn)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dpdkLinkStates are the link states of the transitions counted for DPDK
+// physical ports.
+var dpdkLinkStates = []string{"up", "down"}
+
+// dpdkLinkTransitions holds the last link state of a DPDK physical port
+// and the number of transitions to each state since the exporter started.
+type dpdkLinkTransitions struct {
+	state       string
+	transitions map[string]uint64
+}
+
+// dpdkLinkTracker counts the link transitions of the DPDK physical ports
+// by comparing their link_state between polls. Unlike the kernel, the DPDK
+// drivers do not always update link_resets on a flap, and a flap shorter
+// than the poll interval remains unnoticed.
+type dpdkLinkTracker struct {
+	links pollKeys[*dpdkLinkTransitions]
+}
+
+// begin starts a poll.
+func (t *dpdkLinkTracker) begin() {
+	t.links.begin()
+}
+
+// observe records the link state of the port and returns its transitions.
+// The state of the first poll of a port is not counted as a transition,
+// and a poll with an unknown state keeps the last known state.
+func (t *dpdkLinkTracker) observe(uuid, state string) map[string]uint64 {
+	link, exists := t.links.get(uuid)
+	if !exists {
+		link = &dpdkLinkTransitions{state: state, transitions: make(map[string]uint64)}
+		t.links.set(uuid, link)
+	}
+	if state != "up" && state != "down" {
+		state = link.state
+	}
+	if state != link.state && link.state != "" {
+		link.transitions[state]++
+	}
+	link.state = state
+	return link.transitions
+}
+
+// end forgets the ports not observed during the poll.
+func (t *dpdkLinkTracker) end() {
+	t.links.end()
+}
+
+// collectDpdkLinkMetrics exports the link transitions of a DPDK physical
+// port since the exporter started.
+func (e *Exporter) collectDpdkLinkMetrics(uuid, name, state string) {
+	transitions := e.dpdkLink.observe(uuid, state)
+	for _, state := range dpdkLinkStates {
+		e.emit(e.newConstMetric(
+			interfaceDpdkLinkTransitions,
+			prometheus.CounterValue,
+			float64(transitions[state]),
+			e.Client.System.ID,
+			uuid,
+			name,
+			state,
+		))
+	}
+}
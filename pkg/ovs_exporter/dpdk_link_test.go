@@ -0,0 +1,49 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDpdkLinkTracker(t *testing.T) {
+	tracker := dpdkLinkTracker{}
+	for i, test := range []struct {
+		state    string
+		expected map[string]uint64
+	}{
+		{"up", map[string]uint64{}},
+		{"up", map[string]uint64{}},
+		{"down", map[string]uint64{"down": 1}},
+		{"", map[string]uint64{"down": 1}},
+		{"up", map[string]uint64{"up": 1, "down": 1}},
+		{"down", map[string]uint64{"up": 1, "down": 2}},
+	} {
+		tracker.begin()
+		if transitions := tracker.observe("u1", test.state); !reflect.DeepEqual(transitions, test.expected) {
+			t.Errorf("Poll %d: expected %v, got %v", i, test.expected, transitions)
+		}
+		tracker.end()
+	}
+
+	// A port not observed during a poll starts over.
+	tracker.begin()
+	tracker.end()
+	tracker.begin()
+	if transitions := tracker.observe("u1", "down"); len(transitions) != 0 {
+		t.Errorf("Expected no transitions for a new port, got %v", transitions)
+	}
+}
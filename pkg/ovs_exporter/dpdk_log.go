@@ -0,0 +1,168 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dpdkGlobalLogType is the component name of the global DPDK log level.
+const dpdkGlobalLogType = "global"
+
+// dpdkLogLevels maps the DPDK log level names to their numeric values.
+var dpdkLogLevels = map[string]int{
+	"emergency": 1,
+	"alert":     2,
+	"critical":  3,
+	"error":     4,
+	"warning":   5,
+	"notice":    6,
+	"info":      7,
+	"debug":     8,
+}
+
+var (
+	dpdkGlobalLogLevelRegex = regexp.MustCompile(`^\s*global log level is (\w+)`)
+	dpdkLogTypeRegex        = regexp.MustCompile(`^\s*id\s+\d+:\s*(\S+?),\s*level is (\w+)`)
+)
+
+// DesiredDpdkLogLevel holds the expected log level of the DPDK log types
+// matching a shell pattern, e.g. pmd.net.*.
+type DesiredDpdkLogLevel struct {
+	Pattern string
+	Level   string
+}
+
+// ParseDpdkLogLevels parses a comma-separated list of PATTERN=LEVEL pairs,
+// e.g. "global=info,pmd.net.*=notice".
+func ParseDpdkLogLevels(s string) ([]DesiredDpdkLogLevel, error) {
+	var levels []DesiredDpdkLogLevel
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("malformed DPDK log level entry %q, expected PATTERN=LEVEL", entry)
+		}
+		if _, exists := dpdkLogLevels[kv[1]]; !exists {
+			return nil, fmt.Errorf("unsupported DPDK log level %q", kv[1])
+		}
+		if _, err := path.Match(kv[0], ""); err != nil {
+			return nil, fmt.Errorf("malformed DPDK log type pattern %q: %s", kv[0], err)
+		}
+		levels = append(levels, DesiredDpdkLogLevel{Pattern: kv[0], Level: kv[1]})
+	}
+	return levels, nil
+}
+
+// GetDpdkLogLevels returns the log level of each DPDK log type, using
+// ovs-appctl dpdk/log-list. It returns no levels when DPDK is not enabled.
+func (e *Exporter) GetDpdkLogLevels() (map[string]string, error) {
+	output, err := e.vswitchdAppctl("dpdk/log-list").Output()
+	if err != nil {
+		if strings.Contains(err.Error(), "exit status") {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to execute dpdk/log-list: %w", err)
+	}
+	return parseDpdkLogLevels(string(output)), nil
+}
+
+// parseDpdkLogLevels parses the output of dpdk/log-list, e.g.:
+//
+//	global log level is debug
+//	id 0: lib.eal, level is info
+func parseDpdkLogLevels(output string) map[string]string {
+	levels := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if m := dpdkGlobalLogLevelRegex.FindStringSubmatch(line); m != nil {
+			levels[dpdkGlobalLogType] = m[1]
+			continue
+		}
+		if m := dpdkLogTypeRegex.FindStringSubmatch(line); m != nil {
+			levels[m[1]] = m[2]
+		}
+	}
+	return levels
+}
+
+// desiredDpdkLogLevel returns the desired log level of a DPDK log type.
+// The last matching pattern wins.
+func (e *Exporter) desiredDpdkLogLevel(logType string) (string, bool) {
+	desired, found := "", false
+	for _, d := range e.dpdkLogLevels {
+		if matched, _ := path.Match(d.Pattern, logType); matched {
+			desired, found = d.Level, true
+		}
+	}
+	return desired, found
+}
+
+// collectDpdkLogMetrics exports the log level of the DPDK log types and,
+// for the log types with a desired level, whether the levels differ.
+func (e *Exporter) collectDpdkLogMetrics() {
+	e.IncrementRequestCounter()
+	levels, err := e.GetDpdkLogLevels()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetDpdkLogLevels() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetDpdkLogLevels() failed", err)
+		return
+	}
+
+	logTypes := make([]string, 0, len(levels))
+	for logType := range levels {
+		logTypes = append(logTypes, logType)
+	}
+	sort.Strings(logTypes)
+	for _, logType := range logTypes {
+		e.emit(e.newConstMetric(
+			dpdkLogLevel,
+			prometheus.GaugeValue,
+			float64(dpdkLogLevels[levels[logType]]),
+			e.Client.System.ID,
+			logType,
+			levels[logType],
+		))
+		desired, found := e.desiredDpdkLogLevel(logType)
+		if !found {
+			continue
+		}
+		var drift float64
+		if desired != levels[logType] {
+			drift = 1
+		}
+		e.emit(e.newConstMetric(
+			dpdkLogLevelDrift,
+			prometheus.GaugeValue,
+			drift,
+			e.Client.System.ID,
+			logType,
+			desired,
+		))
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseDpdkLogLevels(t *testing.T) {
+	output := `global log level is debug
+id 0: lib.eal, level is info
+id 1: lib.malloc, level is info
+id 33: pmd.net.mlx5, level is notice
+`
+	levels := parseDpdkLogLevels(output)
+	expected := map[string]string{
+		"global":       "debug",
+		"lib.eal":      "info",
+		"lib.malloc":   "info",
+		"pmd.net.mlx5": "notice",
+	}
+	if len(levels) != len(expected) {
+		t.Fatalf("Expected %d log types, got %d: %v", len(expected), len(levels), levels)
+	}
+	for logType, want := range expected {
+		if levels[logType] != want {
+			t.Errorf("%s: expected %s, got %s", logType, want, levels[logType])
+		}
+	}
+}
+
+func TestDesiredDpdkLogLevel(t *testing.T) {
+	desired, err := ParseDpdkLogLevels("global=info, pmd.net.*=notice,pmd.net.mlx5=debug")
+	if err != nil {
+		t.Fatalf("ParseDpdkLogLevels() returned error: %v", err)
+	}
+	e := &Exporter{dpdkLogLevels: desired}
+
+	tests := []struct {
+		logType string
+		level   string
+		found   bool
+	}{
+		{"global", "info", true},
+		{"pmd.net.ixgbe", "notice", true},
+		{"pmd.net.mlx5", "debug", true},
+		{"lib.eal", "", false},
+	}
+	for _, tt := range tests {
+		level, found := e.desiredDpdkLogLevel(tt.logType)
+		if level != tt.level || found != tt.found {
+			t.Errorf("desiredDpdkLogLevel(%q) = %q, %v; expected %q, %v", tt.logType, level, found, tt.level, tt.found)
+		}
+	}
+
+	for _, s := range []string{"global", "global=verbose", "[=info"} {
+		if _, err := ParseDpdkLogLevels(s); err == nil {
+			t.Errorf("ParseDpdkLogLevels(%q) should return error", s)
+		}
+	}
+}
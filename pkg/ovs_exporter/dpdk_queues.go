@@ -0,0 +1,112 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// dpdkDefaultRxQueues is the default number of rx queues requested for
+	// DPDK physical ports with options:n_rxq.
+	dpdkDefaultRxQueues = 1
+	// dpdkDefaultQueueDescriptors is the default size of the rx and tx
+	// queues of DPDK physical ports, set with options:n_rxq_desc and
+	// options:n_txq_desc.
+	dpdkDefaultQueueDescriptors = 2048
+	// dpdkMaxQueueDescriptors is the largest queue size accepted by OVS.
+	dpdkMaxQueueDescriptors = 4096
+)
+
+// dpdkQueueConfig is the queue configuration of a DPDK physical port. The
+// numbers of queues configured are -1 when not reported in the status
+// column, e.g. when the port failed to initialize.
+type dpdkQueueConfig struct {
+	requestedRxQueues float64
+	rxQueues          float64
+	txQueues          float64
+	rxDescriptors     float64
+	txDescriptors     float64
+}
+
+// parseDpdkQueueConfig returns the queue configuration of a DPDK physical
+// port from its options and status columns.
+func parseDpdkQueueConfig(options, status map[string]string) dpdkQueueConfig {
+	config := dpdkQueueConfig{
+		requestedRxQueues: dpdkDefaultRxQueues,
+		rxQueues:          -1,
+		txQueues:          -1,
+		rxDescriptors:     dpdkQueueDescriptors(options["n_rxq_desc"]),
+		txDescriptors:     dpdkQueueDescriptors(options["n_txq_desc"]),
+	}
+	if n, err := strconv.Atoi(options["n_rxq"]); err == nil && n > 0 {
+		config.requestedRxQueues = float64(n)
+	}
+	if n, err := strconv.Atoi(status["n_rxq"]); err == nil {
+		config.rxQueues = float64(n)
+	}
+	if n, err := strconv.Atoi(status["n_txq"]); err == nil {
+		config.txQueues = float64(n)
+	}
+	return config
+}
+
+// dpdkQueueDescriptors returns the size of a queue set in the options of a
+// DPDK port. Like OVS, it falls back to the default for sizes that are not
+// a power of 2 or larger than the maximum.
+func dpdkQueueDescriptors(s string) float64 {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 || n > dpdkMaxQueueDescriptors || n&(n-1) != 0 {
+		return dpdkDefaultQueueDescriptors
+	}
+	return float64(n)
+}
+
+// collectDpdkQueueMetrics exports the queue configuration of a DPDK
+// physical port.
+func (e *Exporter) collectDpdkQueueMetrics(uuid, name string, options, status map[string]string) {
+	config := parseDpdkQueueConfig(options, status)
+	e.emit(e.newConstMetric(
+		interfaceDpdkRequestedRxQueues,
+		prometheus.GaugeValue,
+		config.requestedRxQueues,
+		e.Client.System.ID, uuid, name,
+	))
+	for _, q := range []struct {
+		direction   string
+		queues      float64
+		descriptors float64
+	}{
+		{"rx", config.rxQueues, config.rxDescriptors},
+		{"tx", config.txQueues, config.txDescriptors},
+	} {
+		if q.queues >= 0 {
+			e.emit(e.newConstMetric(
+				interfaceDpdkQueues,
+				prometheus.GaugeValue,
+				q.queues,
+				e.Client.System.ID, uuid, name, q.direction,
+			))
+		}
+		e.emit(e.newConstMetric(
+			interfaceDpdkQueueDescriptors,
+			prometheus.GaugeValue,
+			q.descriptors,
+			e.Client.System.ID, uuid, name, q.direction,
+		))
+	}
+}
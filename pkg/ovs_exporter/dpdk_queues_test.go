@@ -0,0 +1,62 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseDpdkQueueConfig(t *testing.T) {
+	config := parseDpdkQueueConfig(
+		map[string]string{"dpdk-devargs": "0000:01:00.0", "n_rxq": "4", "n_rxq_desc": "4096", "n_txq_desc": "1000"},
+		map[string]string{"driver_name": "net_i40e", "n_rxq": "4", "n_txq": "5"},
+	)
+	expected := dpdkQueueConfig{
+		requestedRxQueues: 4,
+		rxQueues:          4,
+		txQueues:          5,
+		rxDescriptors:     4096,
+		// Sizes that are not a power of 2 fall back to the default.
+		txDescriptors: dpdkDefaultQueueDescriptors,
+	}
+	if config != expected {
+		t.Errorf("Expected %+v, got %+v", expected, config)
+	}
+
+	// A port that failed to initialize reports no queues.
+	config = parseDpdkQueueConfig(map[string]string{}, map[string]string{"error": "could not attach device"})
+	expected = dpdkQueueConfig{
+		requestedRxQueues: dpdkDefaultRxQueues,
+		rxQueues:          -1,
+		txQueues:          -1,
+		rxDescriptors:     dpdkDefaultQueueDescriptors,
+		txDescriptors:     dpdkDefaultQueueDescriptors,
+	}
+	if config != expected {
+		t.Errorf("Expected %+v, got %+v", expected, config)
+	}
+}
+
+func TestDpdkQueueDescriptors(t *testing.T) {
+	for s, expected := range map[string]float64{
+		"":     dpdkDefaultQueueDescriptors,
+		"512":  512,
+		"8192": dpdkDefaultQueueDescriptors,
+		"0":    dpdkDefaultQueueDescriptors,
+		"abc":  dpdkDefaultQueueDescriptors,
+	} {
+		if n := dpdkQueueDescriptors(s); n != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, s, n)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dpdkRxSteering is the rx steering configuration of a DPDK physical port,
+// set with options:rx-steering, e.g. rss+lacp to steer the LACP packets to
+// a dedicated rx queue with rte_flow rules. The queue is -1 when not
+// reported in the status column.
+type dpdkRxSteering struct {
+	mode   string
+	active bool
+	queue  float64
+}
+
+// parseDpdkRxSteering returns the rx steering configuration of a DPDK
+// physical port from its options and status columns, and false when the
+// port uses the default RSS distribution only. OVS reports rx-steering as
+// unsupported when the NIC rejected the rte_flow rules.
+func parseDpdkRxSteering(options, status map[string]string) (dpdkRxSteering, bool) {
+	mode := options["rx-steering"]
+	if mode == "" || mode == "rss" {
+		return dpdkRxSteering{}, false
+	}
+	steering := dpdkRxSteering{
+		mode:   mode,
+		active: status["rx-steering"] != "unsupported",
+		queue:  -1,
+	}
+	if n, err := strconv.Atoi(status["rx_steering_queue"]); err == nil {
+		steering.queue = float64(n)
+	}
+	return steering, true
+}
+
+// collectDpdkRxSteeringMetrics exports the rx steering configuration of a
+// DPDK physical port.
+func (e *Exporter) collectDpdkRxSteeringMetrics(uuid, name string, options, status map[string]string) {
+	steering, configured := parseDpdkRxSteering(options, status)
+	if !configured {
+		return
+	}
+	var active float64
+	if steering.active {
+		active = 1
+	}
+	e.emit(e.newConstMetric(
+		interfaceDpdkRxSteering,
+		prometheus.GaugeValue,
+		active,
+		e.Client.System.ID, uuid, name, steering.mode,
+	))
+	if steering.active && steering.queue >= 0 {
+		e.emit(e.newConstMetric(
+			interfaceDpdkRxSteeringQueue,
+			prometheus.GaugeValue,
+			steering.queue,
+			e.Client.System.ID, uuid, name,
+		))
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseDpdkRxSteering(t *testing.T) {
+	if _, configured := parseDpdkRxSteering(map[string]string{"dpdk-devargs": "0000:01:00.0"}, map[string]string{}); configured {
+		t.Error("Expected no rx steering without options:rx-steering")
+	}
+	if _, configured := parseDpdkRxSteering(map[string]string{"rx-steering": "rss"}, map[string]string{}); configured {
+		t.Error("Expected no rx steering with the default rss mode")
+	}
+
+	steering, configured := parseDpdkRxSteering(
+		map[string]string{"rx-steering": "rss+lacp", "n_rxq": "4"},
+		map[string]string{"n_rxq": "5", "rx_steering_queue": "4"},
+	)
+	expected := dpdkRxSteering{mode: "rss+lacp", active: true, queue: 4}
+	if !configured || steering != expected {
+		t.Errorf("Expected %+v, got %+v", expected, steering)
+	}
+
+	steering, configured = parseDpdkRxSteering(
+		map[string]string{"rx-steering": "rss+lacp"},
+		map[string]string{"n_rxq": "1", "rx-steering": "unsupported"},
+	)
+	expected = dpdkRxSteering{mode: "rss+lacp", active: false, queue: -1}
+	if !configured || steering != expected {
+		t.Errorf("Expected %+v, got %+v", expected, steering)
+	}
+}
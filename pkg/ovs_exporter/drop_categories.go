@@ -0,0 +1,70 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// dropCategories are the categories of the datapath drop reasons, in the
+// order of the rollup.
+var dropCategories = []string{"tunnel", "action", "meter", "upcall", "other"}
+
+// dropReasonCategories maps the drop reasons of GetDropCounters to their
+// category. Keep it in sync with the drop reasons when adding new ones.
+var dropReasonCategories = map[string]string{
+	"datapath_drop_upcall_error":           "upcall",
+	"datapath_drop_lock_error":             "upcall",
+	"datapath_drop_rx_invalid_packet":      "other",
+	"datapath_drop_meter":                  "meter",
+	"datapath_drop_userspace_action_error": "action",
+	"datapath_drop_tunnel_push_error":      "tunnel",
+	"datapath_drop_tunnel_pop_error":       "tunnel",
+	"datapath_drop_recirc_error":           "action",
+	"datapath_drop_invalid_port":           "other",
+	"datapath_drop_invalid_tnl_port":       "tunnel",
+	"datapath_drop_sample_error":           "action",
+	"datapath_drop_nsh_decap_error":        "action",
+	"drop_action_of_pipeline":              "action",
+	"drop_action_bridge_not_found":         "action",
+	"drop_action_recursion_too_deep":       "action",
+	"drop_action_too_many_resubmit":        "action",
+	"drop_action_stack_too_deep":           "action",
+	"drop_action_no_recirculation":         "action",
+	"drop_action_recirculation_conflict":   "action",
+	"drop_action_too_many_mpls_labels":     "action",
+	"drop_action_invalid_tunnel_metadata":  "tunnel",
+	"drop_action_unsupported_packet_type":  "action",
+	"drop_action_congestion":               "action",
+	"drop_action_forwarding_disabled":      "action",
+}
+
+// dropCategory returns the category of a drop reason, or "other" for the
+// reasons without a category.
+func dropCategory(reason string) string {
+	if category, exists := dropReasonCategories[reason]; exists {
+		return category
+	}
+	return "other"
+}
+
+// summarizeDropCounters sums the drop counters by category. All categories
+// are present, so that a category without drops reads zero.
+func summarizeDropCounters(dropCounters map[string]uint64) map[string]uint64 {
+	totals := make(map[string]uint64, len(dropCategories))
+	for _, category := range dropCategories {
+		totals[category] = 0
+	}
+	for reason, count := range dropCounters {
+		totals[dropCategory(reason)] += count
+	}
+	return totals
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeDropCounters(t *testing.T) {
+	totals := summarizeDropCounters(map[string]uint64{
+		"datapath_drop_tunnel_pop_error":      3,
+		"drop_action_invalid_tunnel_metadata": 2,
+		"drop_action_of_pipeline":             10,
+		"datapath_drop_meter":                 4,
+		"datapath_drop_upcall_error":          1,
+		"datapath_drop_lock_error":            1,
+		"datapath_drop_rx_invalid_packet":     7,
+		"datapath_drop_unknown":               5,
+	})
+	expected := map[string]uint64{
+		"tunnel": 5,
+		"action": 10,
+		"meter":  4,
+		"upcall": 2,
+		"other":  12,
+	}
+	if !reflect.DeepEqual(totals, expected) {
+		t.Errorf("Expected %v, got %v", expected, totals)
+	}
+
+	totals = summarizeDropCounters(nil)
+	if len(totals) != len(dropCategories) {
+		t.Errorf("Expected all %d categories, got %v", len(dropCategories), totals)
+	}
+}
+
+func TestDropReasonCategories(t *testing.T) {
+	categories := make(map[string]bool)
+	for _, category := range dropCategories {
+		categories[category] = true
+	}
+	for reason, category := range dropReasonCategories {
+		if !categories[category] {
+			t.Errorf("Drop reason %s has the unknown category %s", reason, category)
+		}
+	}
+}
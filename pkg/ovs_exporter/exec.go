@@ -0,0 +1,74 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultCommandWrapperClass is the command class whose wrapper applies to
+// all commands without a wrapper of their own.
+const defaultCommandWrapperClass = "*"
+
+// ParseCommandWrappers parses a comma-separated list of CLASS=WRAPPER pairs,
+// where CLASS is the name of an external command, e.g. ovs-appctl, or "*"
+// for all commands, and WRAPPER is the space-separated command prefix, e.g.
+// "sudo -n" or "nsenter -t 1 -n".
+func ParseCommandWrappers(s string) (map[string][]string, error) {
+	wrappers := make(map[string][]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("malformed command wrapper entry %q, expected CLASS=WRAPPER", entry)
+		}
+		wrapper := strings.Fields(kv[1])
+		if len(wrapper) == 0 {
+			return nil, fmt.Errorf("empty command wrapper for %q", kv[0])
+		}
+		wrappers[strings.TrimSpace(kv[0])] = wrapper
+	}
+	return wrappers, nil
+}
+
+// command returns the external command to run, prefixed with the wrapper
+// configured for the command, if any, in the environment of the OVS
+// instance of the exporter.
+func (e *Exporter) command(name string, args ...string) *exec.Cmd {
+	wrapper, exists := e.commandWrappers[name]
+	if !exists {
+		wrapper = e.commandWrappers[defaultCommandWrapperClass]
+	}
+	var cmd *exec.Cmd
+	if len(wrapper) == 0 {
+		cmd = exec.Command(name, args...)
+	} else {
+		argv := make([]string, 0, len(wrapper)+len(args))
+		argv = append(argv, wrapper[1:]...)
+		argv = append(argv, name)
+		argv = append(argv, args...)
+		cmd = exec.Command(wrapper[0], argv...)
+	}
+	if len(e.commandEnv) > 0 {
+		cmd.Env = append(os.Environ(), e.commandEnv...)
+	}
+	return cmd
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommandWrappers(t *testing.T) {
+	wrappers, err := ParseCommandWrappers("ovs-appctl=sudo -n, *=nsenter -t 1 -m")
+	if err != nil {
+		t.Fatalf("ParseCommandWrappers() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(wrappers["ovs-appctl"], []string{"sudo", "-n"}) {
+		t.Errorf("Unexpected ovs-appctl wrapper: %v", wrappers["ovs-appctl"])
+	}
+	if !reflect.DeepEqual(wrappers["*"], []string{"nsenter", "-t", "1", "-m"}) {
+		t.Errorf("Unexpected default wrapper: %v", wrappers["*"])
+	}
+
+	for _, s := range []string{"ovs-appctl", "ovs-appctl=", "=sudo"} {
+		if _, err := ParseCommandWrappers(s); err == nil {
+			t.Errorf("ParseCommandWrappers(%q) should return error", s)
+		}
+	}
+}
+
+func TestExporterCommand(t *testing.T) {
+	e := &Exporter{
+		commandWrappers: map[string][]string{
+			"ovs-appctl": {"sudo", "-n"},
+			"*":          {"doas"},
+		},
+	}
+
+	cmd := e.command("ovs-appctl", "coverage/show")
+	if want := []string{"sudo", "-n", "ovs-appctl", "coverage/show"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("Expected %v, got %v", want, cmd.Args)
+	}
+
+	cmd = e.command("ovs-vsctl", "show")
+	if want := []string{"doas", "ovs-vsctl", "show"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("Expected %v, got %v", want, cmd.Args)
+	}
+
+	e.commandWrappers = nil
+	cmd = e.command("ovs-vsctl", "show")
+	if want := []string{"ovs-vsctl", "show"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("Expected %v, got %v", want, cmd.Args)
+	}
+}
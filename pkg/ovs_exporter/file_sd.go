@@ -0,0 +1,91 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// FileSDGroup is a target group of the file-based service discovery of
+// Prometheus, i.e. an entry of a file_sd_configs file.
+type FileSDGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// FileSDAddress returns the address Prometheus scrapes the exporter at
+// from its listen address, replacing an unspecified host, e.g. the one of
+// :9475, with the hostname.
+func FileSDAddress(listenAddress, hostname string) (string, error) {
+	host, port, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return "", fmt.Errorf("malformed listen address %q: %s", listenAddress, err)
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		if hostname == "" {
+			return "", fmt.Errorf("no hostname to replace the unspecified host of %q", listenAddress)
+		}
+		host = hostname
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// FileSDLabels returns the labels of the target group of the exporter: the
+// system-id and the hostname of the OVS instance. The hostname is the one
+// of the Open_vSwitch table, falling back to the one of the host.
+func (e *Exporter) FileSDLabels() map[string]string {
+	labels := map[string]string{"system_id": e.Client.System.ID}
+	if hostname := e.Hostname(); hostname != "" {
+		labels["hostname"] = hostname
+	}
+	return labels
+}
+
+// WriteFileSD writes the target groups as a file_sd_configs file to path,
+// or to w when path is -. The file is replaced atomically, so that
+// Prometheus, which watches it, never reads a partial file.
+func WriteFileSD(path string, w io.Writer, groups []FileSDGroup) error {
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err := w.Write(data)
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
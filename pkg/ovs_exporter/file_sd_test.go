@@ -0,0 +1,79 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileSDAddress(t *testing.T) {
+	for listenAddress, expected := range map[string]string{
+		":9475":          "compute-1:9475",
+		"0.0.0.0:9475":   "compute-1:9475",
+		"[::]:9475":      "compute-1:9475",
+		"10.0.0.5:9475":  "10.0.0.5:9475",
+		"[fd00::5]:9475": "[fd00::5]:9475",
+	} {
+		address, err := FileSDAddress(listenAddress, "compute-1")
+		if err != nil {
+			t.Fatalf("FileSDAddress(%q) returned error: %v", listenAddress, err)
+		}
+		if address != expected {
+			t.Errorf("Expected %q for %q, got %q", expected, listenAddress, address)
+		}
+	}
+	if _, err := FileSDAddress("9475", "compute-1"); err == nil {
+		t.Error("Expected an error for a listen address without port")
+	}
+}
+
+func TestWriteFileSD(t *testing.T) {
+	groups := []FileSDGroup{{
+		Targets: []string{"compute-1:9475"},
+		Labels:  map[string]string{"system_id": "6f3c3a55", "hostname": "compute-1"},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteFileSD("-", &buf, groups); err != nil {
+		t.Fatalf("WriteFileSD() returned error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "ovs.json")
+	if err := WriteFileSD(path, nil, groups); err != nil {
+		t.Fatalf("WriteFileSD() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Errorf("Expected the file to hold %q, got %q", buf.String(), data)
+	}
+
+	var parsed []FileSDGroup
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Unmarshal() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, groups) {
+		t.Errorf("Expected %+v, got %+v", groups, parsed)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Expected the temporary file to be removed, got %d files", len(entries))
+	}
+}
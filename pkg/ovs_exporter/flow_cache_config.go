@@ -0,0 +1,104 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultEmcInsertInvProb is the EMC insertion inverse probability used by
+// ovs-vswitchd when other_config:emc-insert-inv-prob is not set.
+const defaultEmcInsertInvProb = 100
+
+// FlowCacheConfig holds the userspace datapath flow cache configuration
+// from the other_config column of the Open_vSwitch table.
+type FlowCacheConfig struct {
+	EmcInsertInvProb float64
+	SmcEnabled       bool
+}
+
+// GetFlowCacheConfig returns the flow cache configuration.
+func (e *Exporter) GetFlowCacheConfig() (FlowCacheConfig, error) {
+	query := fmt.Sprintf("SELECT other_config FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return FlowCacheConfig{}, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseFlowCacheConfig(result)
+}
+
+// parseFlowCacheConfig extracts the flow cache configuration from the
+// first row of the Open_vSwitch table, applying the ovs-vswitchd defaults.
+func parseFlowCacheConfig(result ovsdb.Result) (FlowCacheConfig, error) {
+	config := FlowCacheConfig{EmcInsertInvProb: defaultEmcInsertInvProb}
+	if len(result.Rows) == 0 {
+		return config, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	r, dt, err := result.Rows[0].GetColumnValue("other_config", result.Columns)
+	if err != nil {
+		return config, fmt.Errorf("parsing 'other_config' failed: %s", err)
+	}
+	// An empty map is returned as an empty set.
+	otherConfig, ok := r.(map[string]string)
+	if !ok && dt != "[]string" {
+		return config, fmt.Errorf("data type '%s' for 'other_config' column is unexpected in this context", dt)
+	}
+	if value, exists := otherConfig["emc-insert-inv-prob"]; exists {
+		prob, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return config, fmt.Errorf("malformed emc-insert-inv-prob %q", value)
+		}
+		config.EmcInsertInvProb = float64(prob)
+	}
+	config.SmcEnabled = otherConfig["smc-enable"] == "true"
+	return config, nil
+}
+
+// collectFlowCacheConfigMetrics exports the flow cache configuration, so
+// that hit ratio regressions can be correlated with configuration changes.
+func (e *Exporter) collectFlowCacheConfigMetrics() {
+	e.IncrementRequestCounter()
+	config, err := e.GetFlowCacheConfig()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetFlowCacheConfig() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetFlowCacheConfig() failed", err)
+		return
+	}
+	var smcEnabled float64
+	if config.SmcEnabled {
+		smcEnabled = 1
+	}
+	e.emit(e.newConstMetric(
+		flowCacheEmcInsertInvProb,
+		prometheus.GaugeValue,
+		config.EmcInsertInvProb,
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		flowCacheSmcEnabled,
+		prometheus.GaugeValue,
+		smcEnabled,
+		e.Client.System.ID,
+	))
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseFlowCacheConfig(t *testing.T) {
+	columns := map[string]string{"other_config": "map[string]string"}
+
+	result := ovsdb.Result{
+		Columns: columns,
+		Rows: []ovsdb.Row{{
+			"other_config": []interface{}{"map", []interface{}{
+				[]interface{}{"emc-insert-inv-prob", "0"},
+				[]interface{}{"smc-enable", "true"},
+				[]interface{}{"dpdk-init", "true"},
+			}},
+		}},
+	}
+	config, err := parseFlowCacheConfig(result)
+	if err != nil {
+		t.Fatalf("parseFlowCacheConfig() returned error: %v", err)
+	}
+	if config.EmcInsertInvProb != 0 || !config.SmcEnabled {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	result.Rows = []ovsdb.Row{{"other_config": []interface{}{"map", []interface{}{}}}}
+	config, err = parseFlowCacheConfig(result)
+	if err != nil {
+		t.Fatalf("parseFlowCacheConfig() returned error: %v", err)
+	}
+	if config.EmcInsertInvProb != defaultEmcInsertInvProb || config.SmcEnabled {
+		t.Errorf("Expected defaults, got %+v", config)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	flowOffloadedRegex = regexp.MustCompile(`(?:^|[\s,])offloaded:(\w+)`)
+	flowDpLayerRegex   = regexp.MustCompile(`(?:^|[\s,])dp:(\w+)`)
+	flowPacketsRegex   = regexp.MustCompile(`(?:^|[\s,])packets:(\d+)`)
+	flowBytesRegex     = regexp.MustCompile(`(?:^|[\s,])bytes:(\d+)`)
+)
+
+// FlowOffloadStats holds the number of flows of a datapath handled by a
+// datapath layer, e.g. ovs for the software path or tc for the flows
+// offloaded to TC, and the packets and bytes they matched.
+type FlowOffloadStats struct {
+	// DpLayer is the layer handling the flows: ovs, tc or dpdk.
+	DpLayer string
+	// Offloaded is whether the flows are offloaded: yes, partial or no.
+	Offloaded string
+	Flows     float64
+	Packets   float64
+	Bytes     float64
+}
+
+// GetFlowOffloadStats returns the flows of a datapath by offload state,
+// using ovs-appctl dpctl/dump-flows -m, which annotates the offloaded flows.
+func (e *Exporter) GetFlowOffloadStats(datapath string) ([]FlowOffloadStats, error) {
+	output, err := e.vswitchdAppctl("dpctl/dump-flows", "-m", datapath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dpctl/dump-flows -m %s: %w", datapath, err)
+	}
+	return parseFlowOffloadStats(string(output)), nil
+}
+
+// parseFlowOffloadStats parses the output of dpctl/dump-flows -m, e.g.:
+//
+//	ufid:1a2b..., recirc_id(0),in_port(2),eth_type(0x0800), packets:10, bytes:980, used:0.5s, offloaded:yes, dp:tc, actions:3
+//
+// The flows without annotations are handled by the software path.
+func parseFlowOffloadStats(output string) []FlowOffloadStats {
+	stats := make(map[[2]string]*FlowOffloadStats)
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, "packets:") {
+			continue
+		}
+		key := [2]string{"ovs", "no"}
+		if m := flowDpLayerRegex.FindStringSubmatch(line); m != nil {
+			key[0] = m[1]
+		}
+		if m := flowOffloadedRegex.FindStringSubmatch(line); m != nil {
+			key[1] = m[1]
+		}
+		s, exists := stats[key]
+		if !exists {
+			s = &FlowOffloadStats{DpLayer: key[0], Offloaded: key[1]}
+			stats[key] = s
+		}
+		s.Flows++
+		if m := flowPacketsRegex.FindStringSubmatch(line); m != nil {
+			packets, _ := strconv.ParseFloat(m[1], 64)
+			s.Packets += packets
+		}
+		if m := flowBytesRegex.FindStringSubmatch(line); m != nil {
+			bytes, _ := strconv.ParseFloat(m[1], 64)
+			s.Bytes += bytes
+		}
+	}
+	result := make([]FlowOffloadStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DpLayer != result[j].DpLayer {
+			return result[i].DpLayer < result[j].DpLayer
+		}
+		return result[i].Offloaded < result[j].Offloaded
+	})
+	return result
+}
+
+// collectFlowOffloadMetrics exports the number of datapath flows, and the
+// packets and bytes they matched, by offload state, so that flows falling
+// back to the software path after an offload regression are measurable.
+// It dumps all datapath flows, hence it is disabled by default.
+func (e *Exporter) collectFlowOffloadMetrics() {
+	e.IncrementRequestCounter()
+	datapaths, err := e.GetDatapathNames()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetDatapathNames() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetDatapathNames() failed", err)
+		return
+	}
+	for _, datapath := range datapaths {
+		e.IncrementRequestCounter()
+		stats, err := e.GetFlowOffloadStats(datapath)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetFlowOffloadStats() failed",
+				"system_id", e.Client.System.ID,
+				"datapath", datapath,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetFlowOffloadStats() failed", err)
+			continue
+		}
+		name := datapath
+		if i := strings.Index(datapath, "@"); i >= 0 {
+			name = datapath[i+1:]
+		}
+		for _, s := range stats {
+			for _, m := range []struct {
+				desc  *prometheus.Desc
+				value float64
+			}{
+				{dpOffloadFlows, s.Flows},
+				{dpOffloadFlowPackets, s.Packets},
+				{dpOffloadFlowBytes, s.Bytes},
+			} {
+				e.emit(e.newConstMetric(
+					m.desc,
+					prometheus.GaugeValue,
+					m.value,
+					e.Client.System.ID,
+					name,
+					s.DpLayer,
+					s.Offloaded,
+				))
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFlowOffloadStats(t *testing.T) {
+	output := `ufid:1a2b3c4d-0000-0000-0000-000000000001, recirc_id(0),dp_hash(0/0),in_port(2),eth_type(0x0800),ipv4(frag=no), packets:10, bytes:980, used:0.5s, offloaded:yes, dp:tc, actions:3
+ufid:1a2b3c4d-0000-0000-0000-000000000002, recirc_id(0),in_port(3),eth_type(0x0800),ipv4(frag=no), packets:5, bytes:420, used:1.2s, offloaded:yes, dp:tc, actions:2
+ufid:1a2b3c4d-0000-0000-0000-000000000003, recirc_id(0),in_port(2),eth_type(0x86dd),ipv6(frag=no),udp(dst=4789), packets:7, bytes:910, used:never, dp:ovs, actions:userspace(pid=3)
+ufid:1a2b3c4d-0000-0000-0000-000000000004, recirc_id(0),in_port(4),eth_type(0x0806), packets:1, bytes:60, used:3.0s, actions:drop
+`
+	expected := []FlowOffloadStats{
+		{DpLayer: "ovs", Offloaded: "no", Flows: 2, Packets: 8, Bytes: 970},
+		{DpLayer: "tc", Offloaded: "yes", Flows: 2, Packets: 15, Bytes: 1400},
+	}
+	if stats := parseFlowOffloadStats(output); !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+	if stats := parseFlowOffloadStats(""); len(stats) != 0 {
+		t.Errorf("Expected no flows, got %+v", stats)
+	}
+}
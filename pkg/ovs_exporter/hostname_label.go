@@ -0,0 +1,54 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Hostname returns the hostname of the OVS instance, i.e. the one of the
+// Open_vSwitch table, falling back to the one of the host.
+func (e *Exporter) Hostname() string {
+	if e.Client.System.Hostname != "" {
+		return e.Client.System.Hostname
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// newInfoMetric returns the ovs_info metric. When the hostname label is
+// added to all series by the caller, it is left out.
+func (e *Exporter) newInfoMetric() prometheus.Metric {
+	if e.hostnameLabel {
+		return e.newConstMetric(
+			infoWithoutHostname,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID, e.Client.System.RunDir,
+			e.Client.System.Type, e.Client.System.Version,
+			e.Client.Database.Vswitch.Version, e.Client.Database.Vswitch.Schema.Version,
+		)
+	}
+	return e.newConstMetric(
+		info,
+		prometheus.GaugeValue,
+		1,
+		e.Client.System.ID, e.Client.System.RunDir, e.Client.System.Hostname,
+		e.Client.System.Type, e.Client.System.Version,
+		e.Client.Database.Vswitch.Version, e.Client.Database.Vswitch.Schema.Version,
+	)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestHostnameLabel(t *testing.T) {
+	e := NewExporter(Options{Logger: log.NewNopLogger()})
+	e.Client.System.Hostname = "compute-1"
+	if hostname := e.Hostname(); hostname != "compute-1" {
+		t.Errorf("Expected hostname compute-1, got %q", hostname)
+	}
+
+	labels := prometheus.Labels{"hostname": e.Hostname()}
+	if err := prometheus.WrapRegistererWith(labels, prometheus.NewRegistry()).Register(e); err == nil {
+		t.Errorf("Expected the hostname label of ovs_info to conflict")
+	}
+
+	e = NewExporter(Options{Logger: log.NewNopLogger(), HostnameLabel: true})
+	if err := prometheus.WrapRegistererWith(labels, prometheus.NewRegistry()).Register(e); err != nil {
+		t.Fatalf("Register() returned error: %v", err)
+	}
+	pb := &dto.Metric{}
+	if err := e.newInfoMetric().Write(pb); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	for _, label := range pb.GetLabel() {
+		if label.GetName() == "hostname" {
+			t.Errorf("Expected no hostname label in ovs_info, got %q", label.GetValue())
+		}
+	}
+}
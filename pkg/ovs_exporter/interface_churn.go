@@ -0,0 +1,63 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// interfaceChurnTracker counts the interfaces added and removed between
+// polls by comparing the UUIDs of the rows of the Interface table.
+type interfaceChurnTracker struct {
+	uuids   map[string]bool
+	added   uint64
+	removed uint64
+}
+
+// observe records the UUIDs of the interfaces of a poll. The interfaces of
+// the first poll are not counted as added.
+func (t *interfaceChurnTracker) observe(uuids []string) {
+	current := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		current[uuid] = true
+		if t.uuids != nil && !t.uuids[uuid] {
+			t.added++
+		}
+	}
+	for uuid := range t.uuids {
+		if !current[uuid] {
+			t.removed++
+		}
+	}
+	t.uuids = current
+}
+
+// collectInterfaceChurnMetrics records the interfaces of the poll and
+// exports the number of interfaces added and removed since start.
+func (e *Exporter) collectInterfaceChurnMetrics(uuids []string) {
+	e.interfaceChurn.observe(uuids)
+	e.emit(e.newConstMetric(
+		interfacesAdded,
+		prometheus.CounterValue,
+		float64(e.interfaceChurn.added),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		interfacesRemoved,
+		prometheus.CounterValue,
+		float64(e.interfaceChurn.removed),
+		e.Client.System.ID,
+	))
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestInterfaceChurnTracker(t *testing.T) {
+	var tracker interfaceChurnTracker
+	tracker.observe([]string{"a", "b"})
+	if tracker.added != 0 || tracker.removed != 0 {
+		t.Fatalf("Expected the first poll not to be counted, got %d added and %d removed", tracker.added, tracker.removed)
+	}
+	tracker.observe([]string{"b", "c", "d"})
+	tracker.observe([]string{"d"})
+	if tracker.added != 2 {
+		t.Errorf("Expected 2 added interfaces, got %d", tracker.added)
+	}
+	if tracker.removed != 3 {
+		t.Errorf("Expected 3 removed interfaces, got %d", tracker.removed)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// interfaceChecks are the inconsistent states of the interfaces, usually
+// caused by misconfiguration:
+//   - admin_up_link_down: enabled, but without link, e.g. an unplugged
+//     cable or a vhost-user interface without guest.
+//   - admin_down_link_up: disabled, while the link is up.
+//   - ofport_error_link_up: not attached to OpenFlow, i.e. ofport -1 after
+//     a failure to add the interface, while the link is up.
+//   - patch_mtu_mismatch: a patch port whose MTU differs from its peer.
+var interfaceChecks = []string{
+	"admin_up_link_down",
+	"admin_down_link_up",
+	"ofport_error_link_up",
+	"patch_mtu_mismatch",
+}
+
+// checkInterface returns whether the interface is in the inconsistent
+// state of a check, and whether the check applies to it. The peers of
+// patch ports are looked up by name.
+func checkInterface(check string, intf *ovsdb.OvsInterface, byName map[string]*ovsdb.OvsInterface) (bool, bool) {
+	switch check {
+	case "admin_up_link_down":
+		return intf.AdminState == "up" && intf.LinkState == "down", true
+	case "admin_down_link_up":
+		return intf.AdminState == "down" && intf.LinkState == "up", true
+	case "ofport_error_link_up":
+		return intf.OfPort == -1 && intf.LinkState == "up", true
+	case "patch_mtu_mismatch":
+		if intf.Type != "patch" {
+			return false, false
+		}
+		peer, exists := byName[intf.Options["peer"]]
+		if !exists || intf.Mtu == 0 || peer.Mtu == 0 {
+			return false, true
+		}
+		return intf.Mtu != peer.Mtu, true
+	}
+	return false, false
+}
+
+// interfaceConsistencyTracker counts the number of consecutive polls in
+// which the interfaces were in an inconsistent state, so that transient
+// states, e.g. while an interface is added, are not reported.
+type interfaceConsistencyTracker struct {
+	polls   int
+	streaks pollKeys[int]
+}
+
+// newInterfaceConsistencyTracker returns a tracker reporting the states
+// lasting for the number of polls, at least 1.
+func newInterfaceConsistencyTracker(polls int) *interfaceConsistencyTracker {
+	if polls < 1 {
+		polls = 1
+	}
+	return &interfaceConsistencyTracker{polls: polls}
+}
+
+// observe records whether the state identified by key is inconsistent and
+// returns whether it was for the number of polls.
+func (t *interfaceConsistencyTracker) observe(key string, inconsistent bool) bool {
+	streak, _ := t.streaks.get(key)
+	if inconsistent {
+		streak++
+	} else {
+		streak = 0
+	}
+	t.streaks.set(key, streak)
+	return streak >= t.polls
+}
+
+// begin starts a poll.
+func (t *interfaceConsistencyTracker) begin() {
+	t.streaks.begin()
+}
+
+// end forgets the states not observed during the poll.
+func (t *interfaceConsistencyTracker) end() {
+	t.streaks.end()
+}
+
+// collectInterfaceConsistencyMetrics exports whether the interfaces are in
+// the inconsistent states of the checks.
+func (e *Exporter) collectInterfaceConsistencyMetrics(intfs []*ovsdb.OvsInterface) {
+	byName := make(map[string]*ovsdb.OvsInterface, len(intfs))
+	for _, intf := range intfs {
+		byName[intf.Name] = intf
+	}
+	e.interfaceConsistency.begin()
+	for _, intf := range intfs {
+		for _, check := range interfaceChecks {
+			inconsistent, applies := checkInterface(check, intf, byName)
+			if !applies {
+				continue
+			}
+			var value float64
+			if e.interfaceConsistency.observe(intf.UUID+"/"+check, inconsistent) {
+				value = 1
+			}
+			e.emit(e.newConstMetric(
+				interfaceInconsistent,
+				prometheus.GaugeValue,
+				value,
+				e.Client.System.ID,
+				intf.UUID,
+				intf.Name,
+				check,
+			))
+		}
+	}
+	e.interfaceConsistency.end()
+}
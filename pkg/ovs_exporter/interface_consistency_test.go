@@ -0,0 +1,80 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollectInterfaceConsistencyMetrics(t *testing.T) {
+	intfs := []*ovsdb.OvsInterface{
+		{UUID: "u1", Name: "eth0", AdminState: "up", LinkState: "down", OfPort: 1},
+		{UUID: "u2", Name: "eth1", AdminState: "down", LinkState: "up", OfPort: 2},
+		{UUID: "u3", Name: "tap0", AdminState: "up", LinkState: "up", OfPort: -1},
+		{UUID: "u4", Name: "patch-br-ex", Type: "patch", AdminState: "up", LinkState: "up", OfPort: 3, Mtu: 1500, Options: map[string]string{"peer": "patch-br-int"}},
+		{UUID: "u5", Name: "patch-br-int", Type: "patch", AdminState: "up", LinkState: "up", OfPort: 4, Mtu: 9000, Options: map[string]string{"peer": "patch-br-ex"}},
+	}
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.interfaceConsistency = newInterfaceConsistencyTracker(2)
+
+	e.collectInterfaceConsistencyMetrics(intfs)
+	if got := inconsistentInterfaces(t, e); len(got) != 0 {
+		t.Errorf("Expected no inconsistency before 2 polls, got %v", got)
+	}
+
+	e.metrics = nil
+	intfs[0].LinkState = "up"
+	e.collectInterfaceConsistencyMetrics(intfs)
+	expected := map[string]bool{
+		"eth1/admin_down_link_up":         true,
+		"tap0/ofport_error_link_up":       true,
+		"patch-br-ex/patch_mtu_mismatch":  true,
+		"patch-br-int/patch_mtu_mismatch": true,
+	}
+	got := inconsistentInterfaces(t, e)
+	if len(got) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	for key := range expected {
+		if !got[key] {
+			t.Errorf("Expected %s to be inconsistent, got %v", key, got)
+		}
+	}
+}
+
+// inconsistentInterfaces returns the NAME/CHECK pairs reported as
+// inconsistent.
+func inconsistentInterfaces(t *testing.T, e *Exporter) map[string]bool {
+	got := make(map[string]bool)
+	for _, m := range e.metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		if pb.GetGauge().GetValue() != 1 {
+			continue
+		}
+		labels := make(map[string]string)
+		for _, label := range pb.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		got[labels["name"]+"/"+labels["check"]] = true
+	}
+	return got
+}
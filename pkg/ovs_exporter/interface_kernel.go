@@ -0,0 +1,163 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// KernelInterfaceState holds the link state of a network device as
+// reported by the kernel.
+type KernelInterfaceState struct {
+	CarrierChanges float64
+	OperState      string
+	// Speed is the link speed in bits per second, or -1 when unknown.
+	Speed float64
+}
+
+// SystemInterface is an OVS interface backed by a kernel network device.
+type SystemInterface struct {
+	UUID string
+	Name string
+}
+
+// GetSystemInterfaces returns the interfaces of the system type, i.e.
+// backed by kernel network devices.
+func (e *Exporter) GetSystemInterfaces() ([]SystemInterface, error) {
+	query := "SELECT _uuid, name, type FROM Interface"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	var intfs []SystemInterface
+	for _, row := range result.Rows {
+		uuid, dt, err := row.GetColumnValue("_uuid", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		name, dt, err := row.GetColumnValue("name", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		if intfType, dt, err := row.GetColumnValue("type", result.Columns); err == nil && dt == "string" {
+			if t := intfType.(string); t != "" && t != "system" {
+				continue
+			}
+		}
+		intfs = append(intfs, SystemInterface{UUID: uuid.(string), Name: name.(string)})
+	}
+	return intfs, nil
+}
+
+// readSysfsValue returns the trimmed content of a sysfs attribute.
+func readSysfsValue(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// GetKernelInterfaceState reads the link state of a network device from
+// the sysfs mounted at sysfsPath, i.e. the data the kernel reports over
+// netlink.
+func GetKernelInterfaceState(sysfsPath, name string) (KernelInterfaceState, error) {
+	state := KernelInterfaceState{Speed: -1}
+	dir := filepath.Join(sysfsPath, "class", "net", name)
+
+	value, err := readSysfsValue(filepath.Join(dir, "carrier_changes"))
+	if err != nil {
+		return state, err
+	}
+	if state.CarrierChanges, err = strconv.ParseFloat(value, 64); err != nil {
+		return state, fmt.Errorf("malformed carrier_changes of %s: %s", name, value)
+	}
+
+	if state.OperState, err = readSysfsValue(filepath.Join(dir, "operstate")); err != nil {
+		return state, err
+	}
+
+	// Reading the speed fails for devices without a carrier.
+	if value, err := readSysfsValue(filepath.Join(dir, "speed")); err == nil {
+		if speed, err := strconv.ParseFloat(value, 64); err == nil && speed >= 0 {
+			state.Speed = speed * 1e6
+		}
+	}
+	return state, nil
+}
+
+// collectKernelInterfaceMetrics exports the kernel view of the link state
+// of system interfaces as a cross-check of the data in the Interface
+// table.
+func (e *Exporter) collectKernelInterfaceMetrics() {
+	e.IncrementRequestCounter()
+	intfs, err := e.GetSystemInterfaces()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetSystemInterfaces() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetSystemInterfaces() failed", err)
+		return
+	}
+	for _, intf := range intfs {
+		state, err := GetKernelInterfaceState(e.sysfsPath, intf.Name)
+		if err != nil {
+			// Devices may live in another network namespace.
+			level.Debug(e.logger).Log(
+				"msg", "GetKernelInterfaceState() failed",
+				"system_id", e.Client.System.ID,
+				"name", intf.Name,
+				"error", err.Error(),
+			)
+			continue
+		}
+		e.emit(e.newConstMetric(
+			interfaceKernelCarrierChanges,
+			prometheus.CounterValue,
+			state.CarrierChanges,
+			e.Client.System.ID,
+			intf.UUID,
+			intf.Name,
+		))
+		e.emit(e.newConstMetric(
+			interfaceKernelOperState,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID,
+			intf.UUID,
+			intf.Name,
+			state.OperState,
+		))
+		if state.Speed >= 0 {
+			e.emit(e.newConstMetric(
+				interfaceKernelLinkSpeed,
+				prometheus.GaugeValue,
+				state.Speed,
+				e.Client.System.ID,
+				intf.UUID,
+				intf.Name,
+			))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetKernelInterfaceState(t *testing.T) {
+	sysfs := t.TempDir()
+	writeAttr := func(dev, attr, value string) {
+		dir := filepath.Join(sysfs, "class", "net", dev)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, attr), []byte(value+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAttr("eth1", "carrier_changes", "7")
+	writeAttr("eth1", "operstate", "up")
+	writeAttr("eth1", "speed", "25000")
+	writeAttr("eth2", "carrier_changes", "2")
+	writeAttr("eth2", "operstate", "down")
+	writeAttr("eth2", "speed", "-1")
+
+	state, err := GetKernelInterfaceState(sysfs, "eth1")
+	if err != nil {
+		t.Fatalf("GetKernelInterfaceState() returned error: %v", err)
+	}
+	if state.CarrierChanges != 7 || state.OperState != "up" || state.Speed != 25e9 {
+		t.Errorf("Unexpected state of eth1: %+v", state)
+	}
+
+	state, err = GetKernelInterfaceState(sysfs, "eth2")
+	if err != nil {
+		t.Fatalf("GetKernelInterfaceState() returned error: %v", err)
+	}
+	if state.OperState != "down" || state.Speed != -1 {
+		t.Errorf("Unexpected state of eth2: %+v", state)
+	}
+
+	if _, err := GetKernelInterfaceState(sysfs, "missing"); err == nil {
+		t.Error("Expected error for a missing device")
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/greenpau/ovsdb"
+)
+
+// interfaceKey is the name and UUID of an interface, the only columns read
+// for all the interfaces when their number is limited.
+type interfaceKey struct {
+	Name string
+	UUID string
+}
+
+// limitInterfaces returns the first interfaces by name, then UUID, so
+// that the same interfaces are exported on every poll, and the number of
+// interfaces left out. A limit of 0 disables the limit.
+func limitInterfaces(keys []interfaceKey, limit int) ([]interfaceKey, int) {
+	if limit <= 0 || len(keys) <= limit {
+		return keys, 0
+	}
+	sorted := append([]interfaceKey(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].UUID < sorted[j].UUID
+	})
+	return sorted[:limit], len(sorted) - limit
+}
+
+// GetInterfaces returns the interfaces exported on this poll, the UUIDs
+// of all the interfaces of the Interface table and the number of
+// interfaces left out by the limit.
+func (e *Exporter) GetInterfaces() ([]*ovsdb.OvsInterface, []string, int, error) {
+	if e.maxInterfaces <= 0 {
+		intfs, err := e.Client.GetDbInterfaces()
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		uuids := make([]string, 0, len(intfs))
+		for _, intf := range intfs {
+			uuids = append(uuids, intf.UUID)
+		}
+		return intfs, uuids, 0, nil
+	}
+	return readLimitedInterfaces(func(query string) (ovsdb.Result, error) {
+		return e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	}, e.maxInterfaces)
+}
+
+// readLimitedInterfaces reads the names and UUIDs of all the interfaces,
+// then the rows of the interfaces kept by the limit one at a time, so that
+// the rows of the interfaces left out are never read.
+func readLimitedInterfaces(transact func(string) (ovsdb.Result, error), limit int) ([]*ovsdb.OvsInterface, []string, int, error) {
+	query := "SELECT _uuid, name FROM Interface"
+	result, err := transact(query)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	if len(result.Rows) == 0 {
+		return nil, nil, 0, fmt.Errorf("the '%s' query did not return any rows", query)
+	}
+	keys := make([]interfaceKey, 0, len(result.Rows))
+	uuids := make([]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		uuid, dt, err := row.GetColumnValue("_uuid", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		key := interfaceKey{UUID: uuid.(string)}
+		if name, dt, err := row.GetColumnValue("name", result.Columns); err == nil && dt == "string" {
+			key.Name = name.(string)
+		}
+		keys = append(keys, key)
+		uuids = append(uuids, key.UUID)
+	}
+
+	kept, truncated := limitInterfaces(keys, limit)
+	intfs := make([]*ovsdb.OvsInterface, 0, len(kept))
+	for _, key := range kept {
+		// The name column is unique, and the UUID is checked in case the
+		// interface was replaced between the queries.
+		query := fmt.Sprintf("SELECT * FROM Interface WHERE name==%q", key.Name)
+		result, err := transact(query)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("the '%s' query failed: %s", query, err)
+		}
+		for _, row := range result.Rows {
+			if intf := interfaceFromRow(row, result.Columns); intf != nil && intf.UUID == key.UUID {
+				intfs = append(intfs, intf)
+			}
+		}
+	}
+	return intfs, uuids, truncated, nil
+}
+
+// interfaceFromRow returns the interface of a row of the Interface table,
+// with the columns ovsdb.OvsClient.GetDbInterfaces reads, or nil when the
+// row has no UUID. Columns missing from the row are skipped, because
+// Row.GetColumnValue panics on them.
+func interfaceFromRow(row ovsdb.Row, columns map[string]string) *ovsdb.OvsInterface {
+	if row["_uuid"] == nil {
+		return nil
+	}
+	r, dt, err := row.GetColumnValue("_uuid", columns)
+	if err != nil || dt != "string" {
+		return nil
+	}
+	intf := &ovsdb.OvsInterface{
+		UUID:        r.(string),
+		ExternalIDs: make(map[string]string),
+		Statistics:  make(map[string]int),
+		Status:      make(map[string]string),
+		Options:     make(map[string]string),
+	}
+
+	texts := map[string]*string{
+		"name":        &intf.Name,
+		"mac_in_use":  &intf.MacInUse,
+		"link_state":  &intf.LinkState,
+		"admin_state": &intf.AdminState,
+		"type":        &intf.Type,
+		"duplex":      &intf.Duplex,
+	}
+	for column, field := range texts {
+		if row[column] == nil {
+			continue
+		}
+		if r, dt, err := row.GetColumnValue(column, columns); err == nil && dt == "string" {
+			*field = r.(string)
+		}
+	}
+
+	integers := map[string]*float64{
+		"ofport":                 &intf.OfPort,
+		"ifindex":                &intf.IfIndex,
+		"mtu":                    &intf.Mtu,
+		"link_speed":             &intf.LinkSpeed,
+		"ingress_policing_burst": &intf.IngressPolicingBurst,
+		"ingress_policing_rate":  &intf.IngressPolicingRate,
+	}
+	for column, field := range integers {
+		if row[column] == nil {
+			continue
+		}
+		if r, dt, err := row.GetColumnValue(column, columns); err == nil && dt == "integer" {
+			*field = float64(r.(int64))
+		}
+	}
+
+	maps := map[string]*map[string]string{
+		"external_ids": &intf.ExternalIDs,
+		"status":       &intf.Status,
+		"options":      &intf.Options,
+	}
+	for column, field := range maps {
+		if row[column] == nil {
+			continue
+		}
+		if r, dt, err := row.GetColumnValue(column, columns); err == nil && dt == "map[string]string" {
+			*field = r.(map[string]string)
+		}
+	}
+
+	if row["statistics"] != nil {
+		if r, dt, err := row.GetColumnValue("statistics", columns); err == nil && dt == "map[string]integer" {
+			intf.Statistics = r.(map[string]int)
+		}
+	}
+	return intf
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestLimitInterfaces(t *testing.T) {
+	intfs := []interfaceKey{
+		{UUID: "c", Name: "tap1"},
+		{UUID: "b", Name: "br-int"},
+		{UUID: "a", Name: "tap1"},
+		{UUID: "d", Name: "tap0"},
+	}
+
+	limited, truncated := limitInterfaces(intfs, 3)
+	if truncated != 1 {
+		t.Errorf("Expected 1 truncated interface, got %d", truncated)
+	}
+	var uuids []string
+	for _, intf := range limited {
+		uuids = append(uuids, intf.UUID)
+	}
+	if len(uuids) != 3 || uuids[0] != "b" || uuids[1] != "d" || uuids[2] != "a" {
+		t.Errorf("Unexpected interfaces %v", uuids)
+	}
+	if intfs[0].UUID != "c" {
+		t.Error("Expected the interfaces of the table not to be reordered")
+	}
+
+	if limited, truncated := limitInterfaces(intfs, 0); len(limited) != 4 || truncated != 0 {
+		t.Errorf("Expected no limit, got %d interfaces and %d truncated", len(limited), truncated)
+	}
+}
+
+func TestReadLimitedInterfaces(t *testing.T) {
+	columns := map[string]string{"statistics": "map[string]integer"}
+	uuid := func(id string) interface{} { return []interface{}{"uuid", id} }
+	var queries []string
+	transact := func(query string) (ovsdb.Result, error) {
+		queries = append(queries, query)
+		switch query {
+		case "SELECT _uuid, name FROM Interface":
+			return ovsdb.Result{Columns: columns, Rows: []ovsdb.Row{
+				{"_uuid": uuid("c"), "name": "tap1"},
+				{"_uuid": uuid("b"), "name": "br-int"},
+				{"_uuid": uuid("d"), "name": "tap0"},
+			}}, nil
+		case `SELECT * FROM Interface WHERE name=="br-int"`:
+			return ovsdb.Result{Columns: columns, Rows: []ovsdb.Row{{
+				"_uuid":      uuid("b"),
+				"name":       "br-int",
+				"type":       "internal",
+				"ofport":     float64(65534),
+				"statistics": []interface{}{"map", []interface{}{[]interface{}{"rx_packets", float64(7)}}},
+			}}}, nil
+		case `SELECT * FROM Interface WHERE name=="tap0"`:
+			// The interface was replaced after the names were read.
+			return ovsdb.Result{Columns: columns, Rows: []ovsdb.Row{{"_uuid": uuid("e"), "name": "tap0"}}}, nil
+		}
+		return ovsdb.Result{}, fmt.Errorf("unexpected query")
+	}
+
+	intfs, uuids, truncated, err := readLimitedInterfaces(transact, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if truncated != 1 || len(uuids) != 3 {
+		t.Errorf("Expected 3 UUIDs and 1 truncated interface, got %v and %d", uuids, truncated)
+	}
+	if len(queries) != 3 {
+		t.Errorf("Expected the row of the truncated interface not to be read, got queries %q", queries)
+	}
+	if len(intfs) != 1 {
+		t.Fatalf("Expected 1 interface, got %d", len(intfs))
+	}
+	if intf := intfs[0]; intf.Name != "br-int" || intf.Type != "internal" || intf.OfPort != 65534 || intf.Statistics["rx_packets"] != 7 {
+		t.Errorf("Unexpected interface %+v", intf)
+	}
+
+	if _, _, _, err := readLimitedInterfaces(func(string) (ovsdb.Result, error) {
+		return ovsdb.Result{}, fmt.Errorf("connection refused")
+	}, 2); err == nil {
+		t.Error("Expected the error of the query")
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// interfaceStat is the metric of a key of the Interface:statistics column.
+type interfaceStat struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+// interfaceStats maps the keys of the Interface:statistics column to their
+// metrics. New counters only need a desc and an entry here.
+var interfaceStats = map[string]interfaceStat{
+	"rx_crc_err":           {interfaceStatRxCrcError, prometheus.CounterValue},
+	"rx_dropped":           {interfaceStatRxDropped, prometheus.CounterValue},
+	"rx_frame_err":         {interfaceStatRxFrameError, prometheus.CounterValue},
+	"rx_over_err":          {interfaceStatRxOverrunError, prometheus.CounterValue},
+	"rx_errors":            {interfaceStatRxErrorsTotal, prometheus.CounterValue},
+	"rx_packets":           {interfaceStatRxPackets, prometheus.CounterValue},
+	"rx_bytes":             {interfaceStatRxBytes, prometheus.CounterValue},
+	"tx_packets":           {interfaceStatTxPackets, prometheus.CounterValue},
+	"tx_bytes":             {interfaceStatTxBytes, prometheus.CounterValue},
+	"tx_dropped":           {interfaceStatTxDropped, prometheus.CounterValue},
+	"tx_errors":            {interfaceStatTxErrorsTotal, prometheus.CounterValue},
+	"collisions":           {interfaceStatCollisions, prometheus.CounterValue},
+	"rx_missed_errors":     {interfaceStatRxMissedErrors, prometheus.CounterValue},
+	"rx_multicast_packets": {interfaceStateMulticastPackets, prometheus.CounterValue},
+}
+
+// afxdpStats maps the statistics of the AF_XDP sockets, reported by OVS per
+// queue as xsk_queue_N_STAT custom statistics of afxdp interfaces, to their
+// metrics.
+var afxdpStats = map[string]*prometheus.Desc{
+	"rx_dropped":               interfaceAfxdpRxDropped,
+	"rx_invalid_descs":         interfaceAfxdpRxInvalidDescs,
+	"tx_invalid_descs":         interfaceAfxdpTxInvalidDescs,
+	"rx_ring_full":             interfaceAfxdpRxRingFull,
+	"rx_fill_ring_empty_descs": interfaceAfxdpRxFillRingEmptyDescs,
+	"tx_ring_empty_descs":      interfaceAfxdpTxRingEmptyDescs,
+}
+
+// afxdpStatKeyRe matches the keys of the statistics of the AF_XDP sockets.
+var afxdpStatKeyRe = regexp.MustCompile(`^xsk_queue_([0-9]+)_([a-z_]+)$`)
+
+// parseAfxdpStatKey returns the metric and the queue of a statistics key of
+// an AF_XDP socket.
+func parseAfxdpStatKey(key string) (interfaceStat, string, bool) {
+	m := afxdpStatKeyRe.FindStringSubmatch(key)
+	if m == nil {
+		return interfaceStat{}, "", false
+	}
+	desc, exists := afxdpStats[m[2]]
+	if !exists {
+		return interfaceStat{}, "", false
+	}
+	return interfaceStat{desc, prometheus.CounterValue}, m[1], true
+}
+
+// interfaceStatKeyRe matches the statistics keys usable in metric names.
+var interfaceStatKeyRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ParseInterfaceStats parses a comma-separated list of KEY[=TYPE] pairs of
+// additional Interface:statistics keys, e.g. vendor-specific counters of
+// DPDK drivers like "rx_q0_good_packets,ovs_tx_qos_drops=counter". TYPE
+// is either counter, the default, or gauge. The keys are exported as
+// ovs_interface_stat_KEY, with a _total suffix for counters.
+func ParseInterfaceStats(s string) (map[string]prometheus.ValueType, error) {
+	stats := make(map[string]prometheus.ValueType)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		key := kv[0]
+		if !interfaceStatKeyRe.MatchString(key) {
+			return nil, fmt.Errorf("malformed interface statistics key %q", key)
+		}
+		if _, exists := interfaceStats[key]; exists {
+			return nil, fmt.Errorf("interface statistics key %q is already exported", key)
+		}
+		valueType := prometheus.CounterValue
+		if len(kv) == 2 {
+			switch kv[1] {
+			case "counter":
+			case "gauge":
+				valueType = prometheus.GaugeValue
+			default:
+				return nil, fmt.Errorf("unsupported type %q of interface statistics key %q, expected counter or gauge", kv[1], key)
+			}
+		}
+		stats[key] = valueType
+	}
+	return stats, nil
+}
+
+// newInterfaceStats returns the registry of the known statistics keys
+// extended with the additional keys.
+func newInterfaceStats(extra map[string]prometheus.ValueType) map[string]interfaceStat {
+	stats := make(map[string]interfaceStat, len(interfaceStats)+len(extra))
+	for key, stat := range interfaceStats {
+		stats[key] = stat
+	}
+	for key, valueType := range extra {
+		name := "interface_stat_" + key
+		if valueType == prometheus.CounterValue && !strings.HasSuffix(name, "_total") {
+			name += "_total"
+		}
+		stats[key] = interfaceStat{
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "", name),
+				fmt.Sprintf("The value of the %s key of the statistics of OVS interface.", key),
+				[]string{"system_id", "uuid", "name"}, nil,
+			),
+			valueType: valueType,
+		}
+	}
+	return stats
+}
+
+// describeInterfaceStats sends the descs of the additional statistics
+// keys. The descs of the known keys are sent by Describe.
+func (e *Exporter) describeInterfaceStats(ch chan<- *prometheus.Desc) {
+	for key, stat := range e.interfaceStats {
+		if _, exists := interfaceStats[key]; !exists {
+			ch <- stat.desc
+		}
+	}
+}
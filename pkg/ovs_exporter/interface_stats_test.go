@@ -0,0 +1,81 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestParseInterfaceStats(t *testing.T) {
+	stats, err := ParseInterfaceStats(" rx_q0_good_packets, ovs_tx_qos_drops=counter,rx_q0_errors=gauge,")
+	if err != nil {
+		t.Fatalf("ParseInterfaceStats() returned error: %v", err)
+	}
+	expected := map[string]prometheus.ValueType{
+		"rx_q0_good_packets": prometheus.CounterValue,
+		"ovs_tx_qos_drops":   prometheus.CounterValue,
+		"rx_q0_errors":       prometheus.GaugeValue,
+	}
+	if len(stats) != len(expected) {
+		t.Fatalf("Expected %d keys, got %v", len(expected), stats)
+	}
+	for key, valueType := range expected {
+		if stats[key] != valueType {
+			t.Errorf("Expected %s to be of type %v, got %v", key, valueType, stats[key])
+		}
+	}
+
+	for _, s := range []string{"rx-q0", "rx_bytes", "rx_q0_errors=histogram"} {
+		if _, err := ParseInterfaceStats(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestNewInterfaceStats(t *testing.T) {
+	stats := newInterfaceStats(map[string]prometheus.ValueType{
+		"rx_q0_good_packets": prometheus.CounterValue,
+		"rx_q0_errors":       prometheus.GaugeValue,
+	})
+	if len(stats) != len(interfaceStats)+2 {
+		t.Fatalf("Expected the known keys and 2 additional keys, got %d keys", len(stats))
+	}
+	if stats["rx_bytes"].desc != interfaceStatRxBytes {
+		t.Errorf("Expected rx_bytes to be exported as %s, got %s", interfaceStatRxBytes, stats["rx_bytes"].desc)
+	}
+	for key, name := range map[string]string{
+		"rx_q0_good_packets": `"ovs_interface_stat_rx_q0_good_packets_total"`,
+		"rx_q0_errors":       `"ovs_interface_stat_rx_q0_errors"`,
+	} {
+		if !strings.Contains(stats[key].desc.String(), name) {
+			t.Errorf("Expected %s to be exported as %s, got %s", key, name, stats[key].desc)
+		}
+	}
+}
+
+func TestParseAfxdpStatKey(t *testing.T) {
+	stat, queue, ok := parseAfxdpStatKey("xsk_queue_3_rx_ring_full")
+	if !ok || stat.desc != interfaceAfxdpRxRingFull || stat.valueType != prometheus.CounterValue || queue != "3" {
+		t.Errorf("Unexpected result for xsk_queue_3_rx_ring_full: %v, %q, %v", stat, queue, ok)
+	}
+	for _, key := range []string{"rx_dropped", "xsk_queue_0_unknown", "xsk_queue_x_rx_dropped"} {
+		if _, _, ok := parseAfxdpStatKey(key); ok {
+			t.Errorf("Expected %q not to be an AF_XDP statistics key", key)
+		}
+	}
+}
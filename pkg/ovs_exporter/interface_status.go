@@ -0,0 +1,75 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var linkSpeedRegex = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([KMGT]?)(?:bps|b/s|bit/s)?\s*$`)
+
+// parseLinkSpeed converts a link speed reported in the status column of
+// an interface, e.g. "10Gbps" or "2.5 Gbps" for DPDK ports, to bits per
+// second.
+func parseLinkSpeed(s string) (float64, bool) {
+	m := linkSpeedRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	switch m[2] {
+	case "K":
+		value *= 1e3
+	case "M":
+		value *= 1e6
+	case "G":
+		value *= 1e9
+	case "T":
+		value *= 1e12
+	}
+	return value, true
+}
+
+// interfaceLinkSpeedValue returns the link speed of an interface in bits
+// per second. Interfaces that do not populate the link_speed column, e.g.
+// DPDK ports, are looked up in the status column.
+func interfaceLinkSpeedValue(linkSpeed float64, status map[string]string) float64 {
+	if linkSpeed > 0 {
+		return linkSpeed
+	}
+	if speed, ok := parseLinkSpeed(status["link_speed"]); ok {
+		return speed
+	}
+	return linkSpeed
+}
+
+// interfaceDuplexValue returns the duplex of an interface, falling back to
+// the status column when the duplex column is empty.
+func interfaceDuplexValue(duplex string, status map[string]string) string {
+	if duplex != "" {
+		return duplex
+	}
+	for _, key := range []string{"duplex", "link_duplex"} {
+		if value, exists := status[key]; exists {
+			return strings.ToLower(value)
+		}
+	}
+	return duplex
+}
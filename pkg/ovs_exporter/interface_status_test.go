@@ -0,0 +1,53 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseLinkSpeed(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		ok       bool
+	}{
+		{"10Gbps", 10e9, true},
+		{"2.5 Gbps", 2.5e9, true},
+		{"100Mbps", 100e6, true},
+		{"1000", 1000, true},
+		{"other", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLinkSpeed(tt.input)
+		if ok != tt.ok || got != tt.expected {
+			t.Errorf("parseLinkSpeed(%q) = %v, %v; expected %v, %v", tt.input, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestInterfaceLinkSpeedValue(t *testing.T) {
+	if got := interfaceLinkSpeedValue(1e9, map[string]string{"link_speed": "10Gbps"}); got != 1e9 {
+		t.Errorf("Expected link_speed column to take precedence, got %v", got)
+	}
+	if got := interfaceLinkSpeedValue(0, map[string]string{"link_speed": "25Gbps"}); got != 25e9 {
+		t.Errorf("Expected fallback to status link_speed, got %v", got)
+	}
+	if got := interfaceLinkSpeedValue(0, map[string]string{"driver_name": "net_vhost"}); got != 0 {
+		t.Errorf("Expected 0 without link_speed status, got %v", got)
+	}
+	if got := interfaceDuplexValue("", map[string]string{"duplex": "Full"}); got != "full" {
+		t.Errorf("Expected fallback to status duplex, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// internalZeroStatsTracker skips the statistics of the internal interfaces,
+// e.g. the local ports of the bridges, as long as they are all zero. The
+// statistics of an interface are exported on the first poll it is seen, so
+// that its series exist, and again as soon as one of them is not zero.
+type internalZeroStatsTracker struct {
+	exported pollKeys[bool]
+}
+
+func newInternalZeroStatsTracker() *internalZeroStatsTracker {
+	return &internalZeroStatsTracker{}
+}
+
+// begin starts a poll of the interfaces.
+func (t *internalZeroStatsTracker) begin() {
+	t.exported.begin()
+}
+
+// skip returns whether the statistics of the interface are skipped on this
+// poll.
+func (t *internalZeroStatsTracker) skip(uuid, intfType string, statistics map[string]int) bool {
+	if intfType != "internal" {
+		return false
+	}
+	if _, exported := t.exported.get(uuid); !exported {
+		t.exported.set(uuid, true)
+		return false
+	}
+	for _, value := range statistics {
+		if value != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// end forgets the interfaces not seen during the poll, e.g. removed ones.
+func (t *internalZeroStatsTracker) end() {
+	t.exported.end()
+}
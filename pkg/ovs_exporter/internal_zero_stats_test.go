@@ -0,0 +1,57 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestInternalZeroStatsTracker(t *testing.T) {
+	tracker := newInternalZeroStatsTracker()
+	zero := map[string]int{"rx_packets": 0, "tx_packets": 0}
+	busy := map[string]int{"rx_packets": 5, "tx_packets": 0}
+
+	tracker.begin()
+	if tracker.skip("br-int", "internal", zero) {
+		t.Error("Expected the statistics to be exported on the first poll")
+	}
+	if tracker.skip("tap0", "", zero) {
+		t.Error("Expected the statistics of a system interface to be exported")
+	}
+	tracker.end()
+
+	tracker.begin()
+	if !tracker.skip("br-int", "internal", zero) {
+		t.Error("Expected the all-zero statistics to be skipped after the first poll")
+	}
+	if tracker.skip("tap0", "", zero) {
+		t.Error("Expected the statistics of a system interface to be exported")
+	}
+	tracker.end()
+
+	tracker.begin()
+	if tracker.skip("br-int", "internal", busy) {
+		t.Error("Expected non-zero statistics to be exported")
+	}
+	tracker.end()
+
+	tracker.begin()
+	tracker.end()
+	tracker.begin()
+	if tracker.skip("br-int", "internal", zero) {
+		t.Error("Expected the statistics of a re-added interface to be exported on its first poll")
+	}
+	tracker.end()
+}
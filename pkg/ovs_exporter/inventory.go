@@ -0,0 +1,153 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// inventoryTables are the tables of the Open_vSwitch database whose rows
+// are counted when its schema is unknown. They exist in all the schema
+// versions the exporter was tested with.
+var inventoryTables = []string{
+	"Open_vSwitch",
+	"Bridge",
+	"Port",
+	"Interface",
+	"QoS",
+	"Queue",
+	"Mirror",
+	"Flow_Table",
+	"Controller",
+	"Manager",
+	"NetFlow",
+	"sFlow",
+	"IPFIX",
+	"Flow_Sample_Collector_Set",
+	"SSL",
+	"AutoAttach",
+}
+
+// GetTableRows returns the number of rows in each table of the
+// Open_vSwitch database, or in the inventory tables when the schema is
+// unknown.
+func (e *Exporter) GetTableRows() (map[string]int, error) {
+	return e.tableRows(e.countTableRows)
+}
+
+// countTableRows returns the number of rows in a table of the
+// Open_vSwitch database.
+func (e *Exporter) countTableRows(table string) (int, error) {
+	query := fmt.Sprintf("SELECT _uuid FROM %s", table)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return 0, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return len(result.Rows), nil
+}
+
+// tableRows counts the rows of the tables of the schema with count. When
+// the schema is unknown, the inventory tables that cannot be counted are
+// skipped, because they may be missing from the schema of the database.
+func (e *Exporter) tableRows(count func(string) (int, error)) (map[string]int, error) {
+	tables := e.schemaTables
+	schemaKnown := len(tables) > 0
+	if !schemaKnown {
+		tables = inventoryTables
+	}
+	rows := make(map[string]int, len(tables))
+	var lastErr error
+	for _, table := range tables {
+		n, err := count(table)
+		if err != nil {
+			if schemaKnown {
+				return nil, err
+			}
+			level.Debug(e.logger).Log(
+				"msg", "skipping inventory table",
+				"table", table,
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+			lastErr = err
+			continue
+		}
+		rows[table] = n
+	}
+	if len(rows) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return rows, nil
+}
+
+// collectInventoryMetrics exports the number of rows in each table, and of
+// bridges, ports and interfaces. Unlike the per-interface series, the
+// counts make a mass deletion of ports or a wiped database easy to alert
+// on, and so are rows leaked by a management plane, e.g. QoS or Queue rows
+// left behind by deleted ports.
+func (e *Exporter) collectInventoryMetrics() {
+	e.IncrementRequestCounter()
+	rows, err := e.GetTableRows()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetTableRows() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetTableRows() failed", err)
+		return
+	}
+	e.collectTableRowMetrics(rows)
+}
+
+// collectTableRowMetrics exports the number of rows of the tables.
+func (e *Exporter) collectTableRowMetrics(rows map[string]int) {
+	tables := make([]string, 0, len(rows))
+	for table := range rows {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		e.emit(e.newConstMetric(
+			ovsdbTableRows,
+			prometheus.GaugeValue,
+			float64(rows[table]),
+			e.Client.System.ID,
+			table,
+		))
+	}
+	e.emit(e.newConstMetric(
+		inventoryBridges,
+		prometheus.GaugeValue,
+		float64(rows["Bridge"]),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		inventoryPorts,
+		prometheus.GaugeValue,
+		float64(rows["Port"]),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		inventoryInterfaces,
+		prometheus.GaugeValue,
+		float64(rows["Interface"]),
+		e.Client.System.ID,
+	))
+}
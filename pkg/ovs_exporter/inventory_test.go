@@ -0,0 +1,114 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// inventoryValues returns the values of the inventory metrics by
+// descriptor and, for ovs_ovsdb_table_rows, by table.
+func inventoryValues(t *testing.T, metrics []prometheus.Metric) (map[*prometheus.Desc]float64, map[string]float64) {
+	values := make(map[*prometheus.Desc]float64)
+	tables := make(map[string]float64)
+	for _, m := range metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+		if m.Desc() == ovsdbTableRows {
+			for _, label := range pb.GetLabel() {
+				if label.GetName() == "table" {
+					tables[label.GetValue()] = pb.GetGauge().GetValue()
+				}
+			}
+			continue
+		}
+		values[m.Desc()] = pb.GetGauge().GetValue()
+	}
+	return values, tables
+}
+
+func TestCollectTableRowMetrics(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectTableRowMetrics(map[string]int{"Bridge": 2, "Port": 12, "Interface": 10, "QoS": 40})
+
+	values, tables := inventoryValues(t, e.metrics)
+	if values[inventoryBridges] != 2 || values[inventoryPorts] != 12 || values[inventoryInterfaces] != 10 {
+		t.Errorf("Unexpected inventory counts %v", values)
+	}
+	if len(tables) != 4 || tables["QoS"] != 40 {
+		t.Errorf("Unexpected table rows %v", tables)
+	}
+
+	// A wiped database reports zero instead of dropping the series.
+	e.metrics = nil
+	e.collectTableRowMetrics(map[string]int{})
+	values, _ = inventoryValues(t, e.metrics)
+	for _, desc := range []*prometheus.Desc{inventoryBridges, inventoryPorts, inventoryInterfaces} {
+		if v, exists := values[desc]; !exists || v != 0 {
+			t.Errorf("Expected %s to be 0, got %v (exists=%v)", desc, v, exists)
+		}
+	}
+}
+
+func TestTableRows(t *testing.T) {
+	counts := map[string]int{"Open_vSwitch": 1, "Bridge": 2, "Port": 12, "Interface": 10}
+	count := func(table string) (int, error) {
+		n, exists := counts[table]
+		if !exists {
+			return 0, fmt.Errorf("unknown table %s", table)
+		}
+		return n, nil
+	}
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+
+	// Unknown schema: the inventory tables missing from the database are
+	// skipped.
+	rows, err := e.tableRows(count)
+	if err != nil {
+		t.Fatalf("tableRows() returned error: %v", err)
+	}
+	if len(rows) != len(counts) || rows["Port"] != 12 {
+		t.Errorf("Expected the rows of the existing tables, got %v", rows)
+	}
+	if _, exists := rows["AutoAttach"]; exists {
+		t.Error("Expected a missing inventory table to be skipped")
+	}
+
+	// Known schema: all its tables are counted and a failure is reported.
+	e.schemaTables = []string{"Bridge", "Port"}
+	rows, err = e.tableRows(count)
+	if err != nil || len(rows) != 2 || rows["Bridge"] != 2 {
+		t.Errorf("Expected the rows of the schema tables, got %v, %v", rows, err)
+	}
+	e.schemaTables = []string{"Bridge", "Datapath"}
+	if _, err := e.tableRows(count); err == nil {
+		t.Error("tableRows() should return error when a table of the schema cannot be counted")
+	}
+
+	// Unknown schema and no table counted, e.g. ovsdb-server is down.
+	e.schemaTables = nil
+	down := func(string) (int, error) { return 0, fmt.Errorf("connection refused") }
+	if _, err := e.tableRows(down); err == nil {
+		t.Error("tableRows() should return error when no table can be counted")
+	}
+}
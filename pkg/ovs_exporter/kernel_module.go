@@ -0,0 +1,119 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// kernelReleasePath holds the release of the running kernel.
+const kernelReleasePath = "/proc/sys/kernel/osrelease"
+
+// KernelModule describes the openvswitch kernel module backing the system
+// datapath.
+type KernelModule struct {
+	Loaded bool
+	// Version is the version of an out-of-tree module, built from the OVS
+	// sources. It is empty for the module shipped with the kernel, which
+	// does not report a version.
+	Version    string
+	SrcVersion string
+}
+
+// GetKernelModule reads the state of the openvswitch kernel module from
+// the sysfs mounted at sysfsPath.
+func GetKernelModule(sysfsPath string) (KernelModule, error) {
+	var module KernelModule
+	dir := filepath.Join(sysfsPath, "module", "openvswitch")
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return module, nil
+		}
+		return module, err
+	}
+	module.Loaded = true
+	module.Version, _ = readSysfsValue(filepath.Join(dir, "version"))
+	module.SrcVersion, _ = readSysfsValue(filepath.Join(dir, "srcversion"))
+	return module, nil
+}
+
+// majorMinorVersionRe matches the major and minor numbers of a version.
+var majorMinorVersionRe = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)`)
+
+// kernelVersionMismatch returns whether an out-of-tree kernel module was
+// built from a different OVS release than the userspace, comparing the
+// major and minor numbers. The modules shipped with the kernel negotiate
+// the features they support with any userspace and never mismatch.
+func kernelVersionMismatch(moduleVersion, userspaceVersion string) bool {
+	if moduleVersion == "" {
+		return false
+	}
+	m := majorMinorVersionRe.FindStringSubmatch(moduleVersion)
+	u := majorMinorVersionRe.FindStringSubmatch(userspaceVersion)
+	if m == nil || u == nil {
+		return false
+	}
+	return m[1] != u[1] || m[2] != u[2]
+}
+
+// collectKernelModuleMetrics exports the version of the openvswitch kernel
+// module and whether it was built from a different OVS release than the
+// userspace, which causes subtle datapath feature mismatches.
+func (e *Exporter) collectKernelModuleMetrics() {
+	module, err := GetKernelModule(e.sysfsPath)
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetKernelModule() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetKernelModule() failed", err)
+		return
+	}
+	if !module.Loaded {
+		// The host only uses the userspace datapath.
+		return
+	}
+	source := "in_tree"
+	if module.Version != "" {
+		source = "out_of_tree"
+	}
+	release, _ := readSysfsValue(kernelReleasePath)
+	e.emit(e.newConstMetric(
+		kernelModuleInfo,
+		prometheus.GaugeValue,
+		1,
+		e.Client.System.ID,
+		module.Version,
+		module.SrcVersion,
+		source,
+		release,
+	))
+	var mismatch float64
+	if kernelVersionMismatch(module.Version, e.Client.Database.Vswitch.Version) {
+		mismatch = 1
+	}
+	e.emit(e.newConstMetric(
+		kernelUserspaceVersionMismatch,
+		prometheus.GaugeValue,
+		mismatch,
+		e.Client.System.ID,
+	))
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetKernelModule(t *testing.T) {
+	sysfs := t.TempDir()
+	module, err := GetKernelModule(sysfs)
+	if err != nil || module.Loaded {
+		t.Fatalf("Expected the module not to be loaded, got %+v (error: %v)", module, err)
+	}
+
+	dir := filepath.Join(sysfs, "module", "openvswitch")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "srcversion"), []byte("8D6B3B2F1C5A0E4D7F9A2B1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	module, err = GetKernelModule(sysfs)
+	if err != nil {
+		t.Fatalf("GetKernelModule() returned error: %v", err)
+	}
+	expected := KernelModule{Loaded: true, SrcVersion: "8D6B3B2F1C5A0E4D7F9A2B1"}
+	if module != expected {
+		t.Errorf("Expected %+v, got %+v", expected, module)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "version"), []byte("2.17.9\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if module, _ = GetKernelModule(sysfs); module.Version != "2.17.9" {
+		t.Errorf("Expected version 2.17.9, got %q", module.Version)
+	}
+}
+
+func TestKernelVersionMismatch(t *testing.T) {
+	for _, tc := range []struct {
+		module, userspace string
+		expected          bool
+	}{
+		{"", "3.3.0", false},
+		{"2.17.9", "2.17.2", false},
+		{"2.17.9", "3.3.0", true},
+		{"2.15.1", "2.17.0", true},
+		{"2.17.9", "", false},
+	} {
+		if mismatch := kernelVersionMismatch(tc.module, tc.userspace); mismatch != tc.expected {
+			t.Errorf("Expected %v for module %q and userspace %q, got %v", tc.expected, tc.module, tc.userspace, mismatch)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces the values of key/value pairs with sensitive keys.
+const redactedValue = "REDACTED"
+
+// DefaultRedactKeys matches the keys of key/value pairs with values that
+// are redacted by default, e.g. the pre-shared keys of IPsec tunnels.
+const DefaultRedactKeys = "psk"
+
+// keyValueFamilies are the interface columns exported as key/value pair
+// metrics that support key allowlists.
+var keyValueFamilies = map[string]bool{
+	"status":       true,
+	"options":      true,
+	"external_ids": true,
+}
+
+// ParseKeyAllowlists parses a comma-separated list of FAMILY:KEY pairs,
+// e.g. "options:remote_ip,status:tunnel_egress_iface", where FAMILY is
+// one of status, options or external_ids.
+func ParseKeyAllowlists(s string) (map[string]map[string]bool, error) {
+	allowlists := make(map[string]map[string]bool)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, ":", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("malformed key allowlist entry %q, expected FAMILY:KEY", entry)
+		}
+		if !keyValueFamilies[kv[0]] {
+			return nil, fmt.Errorf("unsupported key allowlist family %q", kv[0])
+		}
+		if _, exists := allowlists[kv[0]]; !exists {
+			allowlists[kv[0]] = make(map[string]bool)
+		}
+		allowlists[kv[0]][kv[1]] = true
+	}
+	return allowlists, nil
+}
+
+// isKeyAllowed returns whether a key of a key/value pair family is
+// exported. All keys are exported for families without an allowlist.
+func (e *Exporter) isKeyAllowed(family, key string) bool {
+	allowlist, exists := e.keyAllowlists[family]
+	if !exists {
+		return true
+	}
+	return allowlist[key]
+}
+
+// ParseRedactKeys compiles the regular expression matching the keys of
+// key/value pairs with sensitive values. The expression must match the
+// whole key. It returns nil for an empty expression.
+func ParseRedactKeys(s string) (*regexp.Regexp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile("^(?:" + s + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("malformed redaction expression %q: %s", s, err)
+	}
+	return re, nil
+}
+
+// keyValue returns the value of a key/value pair to export, i.e. the value
+// or the redacted value for sensitive keys.
+func (e *Exporter) keyValue(key, value string) string {
+	if e.redactKeys != nil && e.redactKeys.MatchString(key) {
+		return redactedValue
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestKeyAllowlists(t *testing.T) {
+	allowlists, err := ParseKeyAllowlists("options:remote_ip, status:tunnel_egress_iface,options:key")
+	if err != nil {
+		t.Fatalf("ParseKeyAllowlists() returned error: %v", err)
+	}
+	e := &Exporter{keyAllowlists: allowlists}
+
+	tests := []struct {
+		family   string
+		key      string
+		expected bool
+	}{
+		{"options", "remote_ip", true},
+		{"options", "key", true},
+		{"options", "csum", false},
+		{"status", "tunnel_egress_iface", true},
+		{"status", "driver_name", false},
+		{"external_ids", "iface-id", true},
+	}
+	for _, tt := range tests {
+		if got := e.isKeyAllowed(tt.family, tt.key); got != tt.expected {
+			t.Errorf("isKeyAllowed(%q, %q) = %v, expected %v", tt.family, tt.key, got, tt.expected)
+		}
+	}
+
+	for _, s := range []string{"options", "options:", "other_config:foo"} {
+		if _, err := ParseKeyAllowlists(s); err == nil {
+			t.Errorf("ParseKeyAllowlists(%q) should return error", s)
+		}
+	}
+}
+
+func TestRedactKeys(t *testing.T) {
+	re, err := ParseRedactKeys("psk|tenant_.*")
+	if err != nil {
+		t.Fatalf("ParseRedactKeys() returned error: %v", err)
+	}
+	e := &Exporter{redactKeys: re}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"psk", redactedValue},
+		{"tenant_id", redactedValue},
+		{"remote_ip", "secret"},
+		{"pskx", "secret"},
+	}
+	for _, tt := range tests {
+		if got := e.keyValue(tt.key, "secret"); got != tt.expected {
+			t.Errorf("keyValue(%q) = %q, expected %q", tt.key, got, tt.expected)
+		}
+	}
+
+	if re, err := ParseRedactKeys(""); err != nil || re != nil {
+		t.Errorf("ParseRedactKeys(\"\") = %v, %v, expected no expression", re, err)
+	}
+	if _, err := ParseRedactKeys("("); err == nil {
+		t.Error("ParseRedactKeys() should return error for a malformed expression")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultLabelValueReplacement replaces the characters of label values
+// that are not exported as is.
+const DefaultLabelValueReplacement = "_"
+
+// isUnsafeLabelRune returns whether the character of a label value is
+// replaced. Control characters, e.g. newlines, and quotes are valid in
+// label values, but break dashboards and hand-written queries.
+func isUnsafeLabelRune(r rune) bool {
+	return r == utf8.RuneError || r == '"' || r == '\\' || unicode.IsControl(r)
+}
+
+// sanitizeLabelValue replaces invalid UTF-8 sequences, control characters
+// and quotes in a label value. Prometheus rejects label values that are
+// not valid UTF-8.
+func sanitizeLabelValue(value, replacement string) string {
+	if utf8.ValidString(value) && strings.IndexFunc(value, isUnsafeLabelRune) < 0 {
+		return value
+	}
+	var b strings.Builder
+	for _, r := range value {
+		if isUnsafeLabelRune(r) {
+			b.WriteString(replacement)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// sanitizeLabelValues returns the sanitized label values. The slice is
+// only copied when a value changes.
+func (e *Exporter) sanitizeLabelValues(labelValues []string) []string {
+	var sanitized []string
+	for i, value := range labelValues {
+		v := sanitizeLabelValue(value, e.labelValueReplacement)
+		if v == value {
+			continue
+		}
+		if sanitized == nil {
+			sanitized = append([]string(nil), labelValues...)
+		}
+		sanitized[i] = v
+	}
+	if sanitized == nil {
+		return labelValues
+	}
+	return sanitized
+}
+
+// newConstMetric is prometheus.MustNewConstMetric with the label values
+// sanitized. All metrics of the exporter are constructed with it.
+func (e *Exporter) newConstMetric(desc *prometheus.Desc, valueType prometheus.ValueType, value float64, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, valueType, value, e.sanitizeLabelValues(labelValues)...)
+}
+
+// newConstHistogram is prometheus.MustNewConstHistogram with the label
+// values sanitized.
+func (e *Exporter) newConstHistogram(desc *prometheus.Desc, count uint64, sum float64, buckets map[float64]uint64, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstHistogram(desc, count, sum, buckets, e.sanitizeLabelValues(labelValues)...)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestSanitizeLabelValue(t *testing.T) {
+	for _, test := range []struct {
+		value       string
+		replacement string
+		expected    string
+	}{
+		{"eth0", "_", "eth0"},
+		{"tenant \"blue\"", "_", "tenant _blue_"},
+		{"line1\nline2\t", "_", "line1_line2_"},
+		{"bad\xffbyte", "_", "bad_byte"},
+		{"bad\xffbyte\n", "", "badbyte"},
+		{"vm-ü", "_", "vm-ü"},
+	} {
+		if sanitized := sanitizeLabelValue(test.value, test.replacement); sanitized != test.expected {
+			t.Errorf("sanitizeLabelValue(%q, %q) = %q, expected %q", test.value, test.replacement, sanitized, test.expected)
+		}
+	}
+}
+
+func TestNewConstMetricSanitizesLabels(t *testing.T) {
+	e := &Exporter{labelValueReplacement: DefaultLabelValueReplacement}
+	labelValues := []string{"id\xff", "ovs-vswitchd", "ev\nent"}
+
+	// An invalid UTF-8 label value makes prometheus.MustNewConstMetric panic.
+	m := e.newConstMetric(covTotal, prometheus.CounterValue, 1, labelValues...)
+	pb := &dto.Metric{}
+	if err := m.Write(pb); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	values := make(map[string]string)
+	for _, label := range pb.GetLabel() {
+		values[label.GetName()] = label.GetValue()
+	}
+	if values["system_id"] != "id_" || values["event"] != "ev_ent" {
+		t.Errorf("Unexpected label values: %v", values)
+	}
+	if labelValues[0] != "id\xff" {
+		t.Error("newConstMetric() modified the label values of the caller")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+// dedupLoggerMaxEntries bounds the number of distinct messages remembered
+// before the expired ones are forgotten.
+const dedupLoggerMaxEntries = 256
+
+// dedupLogger suppresses the warnings and errors repeated within a window,
+// e.g. the failures logged on every poll while OVS is down. The first
+// occurrence of a message after the window is logged with the number of
+// occurrences suppressed in between as repeated. Messages are identical
+// when all their key/value pairs are, so that the errors of different
+// components or causes are logged separately.
+type dedupLogger struct {
+	next       log.Logger
+	window     time.Duration
+	now        func() time.Time
+	suppressed *atomic.Uint64
+
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+}
+
+// dedupEntry is the state of a message.
+type dedupEntry struct {
+	logged   time.Time
+	repeated int
+}
+
+// newDedupLogger returns a logger suppressing the messages repeated within
+// the window and counting them in suppressed.
+func newDedupLogger(next log.Logger, window time.Duration, suppressed *atomic.Uint64) *dedupLogger {
+	return &dedupLogger{
+		next:       next,
+		window:     window,
+		now:        time.Now,
+		suppressed: suppressed,
+		entries:    make(map[string]*dedupEntry),
+	}
+}
+
+// Log implements log.Logger.
+func (l *dedupLogger) Log(keyvals ...interface{}) error {
+	var dedup bool
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == level.Key() {
+			dedup = keyvals[i+1] == level.ErrorValue() || keyvals[i+1] == level.WarnValue()
+			break
+		}
+	}
+	if !dedup {
+		return l.next.Log(keyvals...)
+	}
+
+	key := fmt.Sprint(keyvals...)
+	now := l.now()
+	l.mu.Lock()
+	entry, exists := l.entries[key]
+	if exists && now.Sub(entry.logged) < l.window {
+		entry.repeated++
+		l.mu.Unlock()
+		l.suppressed.Add(1)
+		return nil
+	}
+	if !exists {
+		if len(l.entries) >= dedupLoggerMaxEntries {
+			l.forgetExpired(now)
+		}
+		entry = &dedupEntry{}
+		l.entries[key] = entry
+	}
+	repeated := entry.repeated
+	entry.logged = now
+	entry.repeated = 0
+	l.mu.Unlock()
+
+	if repeated > 0 {
+		keyvals = append(keyvals, "repeated", repeated)
+	}
+	return l.next.Log(keyvals...)
+}
+
+// forgetExpired forgets the messages logged before the window. The caller
+// must hold the mutex.
+func (l *dedupLogger) forgetExpired(now time.Time) {
+	for key, entry := range l.entries {
+		if now.Sub(entry.logged) >= l.window {
+			delete(l.entries, key)
+		}
+	}
+}
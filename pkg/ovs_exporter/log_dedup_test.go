@@ -0,0 +1,60 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"bytes"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+)
+
+func TestDedupLogger(t *testing.T) {
+	var buf bytes.Buffer
+	var suppressed atomic.Uint64
+	l := newDedupLogger(log.NewLogfmtLogger(&buf), time.Minute, &suppressed)
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		level.Error(l).Log("msg", "GetBonds() failed", "error", "connection refused")
+		level.Info(l).Log("msg", "poll")
+		now = now.Add(10 * time.Second)
+	}
+	level.Error(l).Log("msg", "GetMeters() failed", "error", "connection refused")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || suppressed.Load() != 2 {
+		t.Fatalf("Expected 5 lines and 2 suppressed messages, got %d and %d:\n%s", len(lines), suppressed.Load(), buf.String())
+	}
+
+	buf.Reset()
+	now = now.Add(time.Minute)
+	level.Error(l).Log("msg", "GetBonds() failed", "error", "connection refused")
+	if !strings.Contains(buf.String(), "repeated=2") {
+		t.Errorf("Expected the number of suppressed messages to be logged, got %q", buf.String())
+	}
+
+	buf.Reset()
+	now = now.Add(time.Minute)
+	level.Error(l).Log("msg", "GetBonds() failed", "error", "connection refused")
+	if buf.String() == "" || strings.Contains(buf.String(), "repeated") {
+		t.Errorf("Expected the message to be logged without repetitions, got %q", buf.String())
+	}
+}
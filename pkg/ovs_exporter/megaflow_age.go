@@ -0,0 +1,158 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// megaflowAgeBuckets are the histogram buckets, in seconds, of the time
+// since datapath flows were last used. Idle flows are evicted after 10
+// seconds by default.
+var megaflowAgeBuckets = []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+var megaflowUsedRegex = regexp.MustCompile(`(?:^|[\s,])used:([0-9.]+)s`)
+
+// MegaflowAges holds the time, in seconds, since each flow of a datapath
+// was last used. Flows that were never used are counted separately.
+type MegaflowAges struct {
+	Datapath string
+	Ages     []float64
+	Unused   int
+}
+
+// GetDatapathNames returns the datapaths, e.g. system@ovs-system, reported
+// by ovs-appctl dpctl/dump-dps.
+func (e *Exporter) GetDatapathNames() ([]string, error) {
+	output, err := e.vswitchdAppctl("dpctl/dump-dps").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dpctl/dump-dps: %w", err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// GetMegaflowAges samples the "used" age of the flows of a datapath using
+// ovs-appctl dpctl/dump-flows.
+func (e *Exporter) GetMegaflowAges(datapath string) (MegaflowAges, error) {
+	output, err := e.vswitchdAppctl("dpctl/dump-flows", datapath).Output()
+	if err != nil {
+		return MegaflowAges{}, fmt.Errorf("failed to execute dpctl/dump-flows %s: %w", datapath, err)
+	}
+	ages := parseMegaflowAges(string(output))
+	ages.Datapath = datapath
+	if i := strings.Index(datapath, "@"); i >= 0 {
+		ages.Datapath = datapath[i+1:]
+	}
+	return ages, nil
+}
+
+// parseMegaflowAges parses the output of dpctl/dump-flows.
+func parseMegaflowAges(output string) MegaflowAges {
+	ages := MegaflowAges{}
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, "used:") {
+			continue
+		}
+		match := megaflowUsedRegex.FindStringSubmatch(line)
+		if match == nil {
+			ages.Unused++
+			continue
+		}
+		age, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			ages.Unused++
+			continue
+		}
+		ages.Ages = append(ages.Ages, age)
+	}
+	return ages
+}
+
+// megaflowAgeHistogram converts flow ages to cumulative bucket counts.
+func megaflowAgeHistogram(ages []float64) (uint64, float64, map[float64]uint64) {
+	var sum float64
+	buckets := make(map[float64]uint64, len(megaflowAgeBuckets))
+	for _, bound := range megaflowAgeBuckets {
+		buckets[bound] = 0
+	}
+	for _, age := range ages {
+		sum += age
+		for _, bound := range megaflowAgeBuckets {
+			if age <= bound {
+				buckets[bound]++
+			}
+		}
+	}
+	return uint64(len(ages)), sum, buckets
+}
+
+// collectMegaflowAgeMetrics exports the distribution of the time since
+// datapath flows were last used. It dumps all datapath flows, hence it is
+// disabled by default.
+func (e *Exporter) collectMegaflowAgeMetrics() {
+	e.IncrementRequestCounter()
+	datapaths, err := e.GetDatapathNames()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetDatapathNames() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetDatapathNames() failed", err)
+		return
+	}
+	for _, datapath := range datapaths {
+		e.IncrementRequestCounter()
+		ages, err := e.GetMegaflowAges(datapath)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetMegaflowAges() failed",
+				"system_id", e.Client.System.ID,
+				"datapath", datapath,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetMegaflowAges() failed", err)
+			continue
+		}
+		count, sum, buckets := megaflowAgeHistogram(ages.Ages)
+		e.emit(e.newConstHistogram(
+			dpFlowAge,
+			count,
+			sum,
+			buckets,
+			e.Client.System.ID,
+			ages.Datapath,
+		))
+		e.emit(e.newConstMetric(
+			dpFlowUnused,
+			prometheus.GaugeValue,
+			float64(ages.Unused),
+			e.Client.System.ID,
+			ages.Datapath,
+		))
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseMegaflowAges(t *testing.T) {
+	output := `recirc_id(0),in_port(2),eth(src=52:54:00:12:34:56,dst=52:54:00:65:43:21),eth_type(0x0800),ipv4(frag=no), packets:12, bytes:1176, used:0.532s, actions:3
+recirc_id(0),in_port(3),eth_type(0x0806), packets:0, bytes:0, used:never, actions:2
+recirc_id(0),in_port(3),eth_type(0x0800),ipv4(frag=no), packets:5, bytes:490, used:7.1s, flags:S, actions:2
+flow-dump from pmd on cpu core: 3
+recirc_id(0),in_port(4),eth_type(0x86dd),ipv6(frag=no), packets:1, bytes:90, used:42.000s, actions:drop
+`
+	ages := parseMegaflowAges(output)
+	if len(ages.Ages) != 3 {
+		t.Fatalf("Expected 3 used flows, got %d", len(ages.Ages))
+	}
+	if ages.Unused != 1 {
+		t.Errorf("Expected 1 unused flow, got %d", ages.Unused)
+	}
+
+	count, sum, buckets := megaflowAgeHistogram(ages.Ages)
+	if count != 3 {
+		t.Errorf("Expected count 3, got %d", count)
+	}
+	if sum != 0.532+7.1+42 {
+		t.Errorf("Unexpected sum %v", sum)
+	}
+	if buckets[1] != 1 || buckets[10] != 2 || buckets[60] != 3 {
+		t.Errorf("Unexpected buckets %v", buckets)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// meterProtocols are the OpenFlow versions supporting meters.
+const meterProtocols = "OpenFlow13,OpenFlow14,OpenFlow15"
+
+// MeterBand holds the configuration and statistics of a meter band.
+type MeterBand struct {
+	Type    string
+	Rate    float64
+	Packets float64
+	Bytes   float64
+}
+
+// Meter holds the configuration and statistics of a meter of a bridge.
+type Meter struct {
+	ID        string
+	Unit      string
+	Flows     float64
+	PacketsIn float64
+	BytesIn   float64
+	Bands     []MeterBand
+}
+
+// GetBridgeNames returns the names of the bridges in the Bridge table.
+func (e *Exporter) GetBridgeNames() ([]string, error) {
+	query := "SELECT name FROM Bridge"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	var names []string
+	for _, row := range result.Rows {
+		if r, dt, err := row.GetColumnValue("name", result.Columns); err == nil && dt == "string" {
+			names = append(names, r.(string))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetMeters returns the meters of a bridge, combining ovs-ofctl
+// dump-meters and meter-stats.
+func (e *Exporter) GetMeters(bridge string) ([]Meter, error) {
+	config, err := e.command("ovs-ofctl", "-O", meterProtocols, "dump-meters", bridge).Output()
+	if err != nil {
+		// Bridges limited to OpenFlow 1.0-1.2 do not support meters.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), "version negotiation failed") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to execute dump-meters for %s: %w", bridge, err)
+	}
+	stats, err := e.command("ovs-ofctl", "-O", meterProtocols, "meter-stats", bridge).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute meter-stats for %s: %w", bridge, err)
+	}
+	return parseMeters(string(config), string(stats)), nil
+}
+
+// meterFields splits a line into key/value pairs separated by "=" or ":".
+func meterFields(line string) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range strings.Fields(line) {
+		// The first band may follow "bands=" on the same line.
+		field = strings.TrimPrefix(field, "bands=")
+		if i := strings.IndexAny(field, "=:"); i > 0 {
+			fields[field[:i]] = field[i+1:]
+		} else {
+			fields[field] = ""
+		}
+	}
+	return fields
+}
+
+// parseMeters parses the output of ovs-ofctl dump-meters, e.g.:
+//
+//	OFPST_METER_CONFIG reply (OF1.3) (xid=0x2):
+//	meter=1 pktps burst stats bands=
+//	type=drop rate=100 burst_size=10
+//
+// and of ovs-ofctl meter-stats, e.g.:
+//
+//	OFPST_METER reply (OF1.3) (xid=0x2):
+//	meter:1 flow_count:2 packet_in_count:150 byte_in_count:9000 duration:10.2s bands:
+//	0: packet_count:50 byte_count:3000
+func parseMeters(config, stats string) []Meter {
+	meters := make(map[string]*Meter)
+	var ids []string
+	getMeter := func(id string) *Meter {
+		if m, exists := meters[id]; exists {
+			return m
+		}
+		meters[id] = &Meter{ID: id}
+		ids = append(ids, id)
+		return meters[id]
+	}
+
+	var current *Meter
+	for _, line := range strings.Split(config, "\n") {
+		fields := meterFields(line)
+		if id, exists := fields["meter"]; exists {
+			current = getMeter(id)
+			for _, unit := range []string{"kbps", "pktps"} {
+				if _, exists := fields[unit]; exists {
+					current.Unit = unit
+				}
+			}
+		}
+		if current == nil {
+			continue
+		}
+		if bandType, exists := fields["type"]; exists {
+			rate, _ := strconv.ParseFloat(fields["rate"], 64)
+			current.Bands = append(current.Bands, MeterBand{Type: bandType, Rate: rate})
+		}
+	}
+
+	current = nil
+	for _, line := range strings.Split(stats, "\n") {
+		fields := meterFields(line)
+		if id, exists := fields["meter"]; exists {
+			current = getMeter(id)
+			current.Flows, _ = strconv.ParseFloat(fields["flow_count"], 64)
+			current.PacketsIn, _ = strconv.ParseFloat(fields["packet_in_count"], 64)
+			current.BytesIn, _ = strconv.ParseFloat(fields["byte_in_count"], 64)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if _, exists := fields["packet_count"]; !exists {
+			continue
+		}
+		band, err := strconv.Atoi(strings.TrimSuffix(strings.Fields(line)[0], ":"))
+		if err != nil || band < 0 {
+			continue
+		}
+		for len(current.Bands) <= band {
+			current.Bands = append(current.Bands, MeterBand{})
+		}
+		current.Bands[band].Packets, _ = strconv.ParseFloat(fields["packet_count"], 64)
+		current.Bands[band].Bytes, _ = strconv.ParseFloat(fields["byte_count"], 64)
+	}
+
+	result := make([]Meter, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *meters[id])
+	}
+	return result
+}
+
+// collectMeterMetrics exports the meter configuration and statistics of
+// all bridges. OVN uses meters to rate limit ACL logging and controller
+// traffic, and the packets dropped by the meter bands are otherwise
+// invisible.
+func (e *Exporter) collectMeterMetrics() {
+	e.IncrementRequestCounter()
+	bridges, err := e.GetBridgeNames()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetBridgeNames() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetBridgeNames() failed", err)
+		return
+	}
+	for _, bridge := range bridges {
+		e.IncrementRequestCounter()
+		meters, err := e.GetMeters(bridge)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetMeters() failed",
+				"system_id", e.Client.System.ID,
+				"bridge", bridge,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetMeters() failed", err)
+			continue
+		}
+		for _, m := range meters {
+			e.emit(e.newConstMetric(
+				meterFlows,
+				prometheus.GaugeValue,
+				m.Flows,
+				e.Client.System.ID,
+				bridge,
+				m.ID,
+			))
+			e.emit(e.newConstMetric(
+				meterPacketsIn,
+				prometheus.CounterValue,
+				m.PacketsIn,
+				e.Client.System.ID,
+				bridge,
+				m.ID,
+			))
+			e.emit(e.newConstMetric(
+				meterBytesIn,
+				prometheus.CounterValue,
+				m.BytesIn,
+				e.Client.System.ID,
+				bridge,
+				m.ID,
+			))
+			for i, band := range m.Bands {
+				e.emit(e.newConstMetric(
+					meterBandRate,
+					prometheus.GaugeValue,
+					band.Rate,
+					e.Client.System.ID,
+					bridge,
+					m.ID,
+					strconv.Itoa(i),
+					band.Type,
+					m.Unit,
+				))
+				e.emit(e.newConstMetric(
+					meterBandPackets,
+					prometheus.CounterValue,
+					band.Packets,
+					e.Client.System.ID,
+					bridge,
+					m.ID,
+					strconv.Itoa(i),
+				))
+				e.emit(e.newConstMetric(
+					meterBandBytes,
+					prometheus.CounterValue,
+					band.Bytes,
+					e.Client.System.ID,
+					bridge,
+					m.ID,
+					strconv.Itoa(i),
+				))
+			}
+		}
+	}
+}
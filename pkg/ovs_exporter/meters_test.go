@@ -0,0 +1,53 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseMeters(t *testing.T) {
+	config := `OFPST_METER_CONFIG reply (OF1.3) (xid=0x2):
+meter=1 pktps burst stats bands=
+type=drop rate=20 burst_size=10
+
+meter=2 kbps bands=type=drop rate=1000
+`
+	stats := `OFPST_METER reply (OF1.3) (xid=0x2):
+meter:1 flow_count:4 packet_in_count:150 byte_in_count:9000 duration:10.200s bands:
+0: packet_count:50 byte_count:3000
+
+meter:2 flow_count:1 packet_in_count:0 byte_in_count:0 duration:3.000s bands:
+0: packet_count:0 byte_count:0
+`
+	meters := parseMeters(config, stats)
+	if len(meters) != 2 {
+		t.Fatalf("Expected 2 meters, got %d: %+v", len(meters), meters)
+	}
+
+	m := meters[0]
+	if m.ID != "1" || m.Unit != "pktps" || m.Flows != 4 || m.PacketsIn != 150 || m.BytesIn != 9000 {
+		t.Errorf("Unexpected meter 1: %+v", m)
+	}
+	if len(m.Bands) != 1 {
+		t.Fatalf("Expected 1 band for meter 1, got %d", len(m.Bands))
+	}
+	if b := m.Bands[0]; b.Type != "drop" || b.Rate != 20 || b.Packets != 50 || b.Bytes != 3000 {
+		t.Errorf("Unexpected band of meter 1: %+v", b)
+	}
+
+	m = meters[1]
+	if m.ID != "2" || m.Unit != "kbps" || len(m.Bands) != 1 || m.Bands[0].Rate != 1000 {
+		t.Errorf("Unexpected meter 2: %+v", m)
+	}
+}
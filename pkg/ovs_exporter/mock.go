@@ -0,0 +1,133 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+// mockMetrics are the metrics served in mock mode, in the text exposition
+// format. They describe a single DPDK host with two PMD threads.
+//
+//go:embed fixtures/mock_metrics.prom
+var mockMetrics []byte
+
+// mockFamily is a metric family of the fixture along with the labels of its
+// descriptor, in the order of the fixture.
+type mockFamily struct {
+	desc   *prometheus.Desc
+	family *dto.MetricFamily
+	labels []string
+}
+
+// MockCollector serves synthetic metrics of a bundled fixture, without any
+// OVS present, e.g. for end-to-end tests of dashboards and alerts. Counters
+// grow with the time since the creation of the collector, so that rate()
+// and increase() return non-zero values, and gauges of timestamps follow
+// the current time.
+type MockCollector struct {
+	families []mockFamily
+	start    time.Time
+	now      func() time.Time
+}
+
+// NewMockCollector returns a collector of the bundled fixture.
+func NewMockCollector() (*MockCollector, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	parsed, err := parser.TextToMetricFamilies(bytes.NewReader(mockMetrics))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing mock metrics: %s", err)
+	}
+	c := &MockCollector{start: time.Now(), now: time.Now}
+	for name, family := range parsed {
+		if len(family.GetMetric()) == 0 {
+			continue
+		}
+		var labels []string
+		for _, pair := range family.GetMetric()[0].GetLabel() {
+			labels = append(labels, pair.GetName())
+		}
+		c.families = append(c.families, mockFamily{
+			desc:   prometheus.NewDesc(name, family.GetHelp(), labels, nil),
+			family: family,
+			labels: labels,
+		})
+	}
+	return c, nil
+}
+
+// Describe implements prometheus.Collector.
+func (c *MockCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, f := range c.families {
+		ch <- f.desc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *MockCollector) Collect(ch chan<- prometheus.Metric) {
+	now := c.now()
+	// Counters grow by their fixture value every hour.
+	growth := 1 + now.Sub(c.start).Hours()
+	for _, f := range c.families {
+		name := f.family.GetName()
+		for _, m := range f.family.GetMetric() {
+			values := make([]string, 0, len(f.labels))
+			for _, pair := range m.GetLabel() {
+				values = append(values, pair.GetValue())
+			}
+			switch f.family.GetType() {
+			case dto.MetricType_COUNTER:
+				ch <- prometheus.MustNewConstMetric(
+					f.desc,
+					prometheus.CounterValue,
+					m.GetCounter().GetValue()*growth,
+					values...,
+				)
+			case dto.MetricType_HISTOGRAM:
+				buckets := make(map[float64]uint64, len(m.GetHistogram().GetBucket()))
+				for _, b := range m.GetHistogram().GetBucket() {
+					buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+				}
+				ch <- prometheus.MustNewConstHistogram(
+					f.desc,
+					m.GetHistogram().GetSampleCount(),
+					m.GetHistogram().GetSampleSum(),
+					buckets,
+					values...,
+				)
+			default:
+				value := m.GetGauge().GetValue()
+				if strings.HasSuffix(name, "_timestamp_seconds") {
+					value = float64(now.Unix())
+				}
+				ch <- prometheus.MustNewConstMetric(
+					f.desc,
+					prometheus.GaugeValue,
+					value,
+					values...,
+				)
+			}
+		}
+	}
+}
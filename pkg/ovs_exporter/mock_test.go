@@ -0,0 +1,83 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMockCollectorMatchesExporter(t *testing.T) {
+	c, err := NewMockCollector()
+	if err != nil {
+		t.Fatalf("NewMockCollector() returned error: %v", err)
+	}
+
+	descs := make(map[string]bool)
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		NewExporter(Options{Timeout: 2, Logger: log.NewNopLogger()}).Describe(ch)
+		close(ch)
+	}()
+	for desc := range ch {
+		descs[desc.String()] = true
+	}
+
+	for _, f := range c.families {
+		if !descs[f.desc.String()] {
+			t.Errorf("The mock metric %s does not match any metric of the exporter", f.desc)
+		}
+	}
+	if len(c.families) != len(descs) {
+		t.Errorf("Expected the %d metrics of the exporter, got %d mock metrics", len(descs), len(c.families))
+	}
+}
+
+func TestMockCollectorCountersGrow(t *testing.T) {
+	c, err := NewMockCollector()
+	if err != nil {
+		t.Fatalf("NewMockCollector() returned error: %v", err)
+	}
+	now := c.start
+	c.now = func() time.Time { return now }
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(c)
+	if n, err := testutil.GatherAndCount(registry); err != nil || n == 0 {
+		t.Fatalf("Expected the mock metrics to be gathered, got %d (error: %v)", n, err)
+	}
+
+	before := testutil.ToFloat64(mockMetric(t, c, "ovs_requests_total"))
+	now = now.Add(30 * time.Minute)
+	after := testutil.ToFloat64(mockMetric(t, c, "ovs_requests_total"))
+	if after != before*1.5 {
+		t.Errorf("Expected the counter to grow from %v to %v, got %v", before, before*1.5, after)
+	}
+}
+
+// mockMetric returns a collector of the family of the mock collector.
+func mockMetric(t *testing.T, c *MockCollector, name string) prometheus.Collector {
+	for _, f := range c.families {
+		if f.family.GetName() == name {
+			return &MockCollector{families: []mockFamily{f}, start: c.start, now: c.now}
+		}
+	}
+	t.Fatalf("The mock metric %s does not exist", name)
+	return nil
+}
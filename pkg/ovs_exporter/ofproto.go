@@ -0,0 +1,123 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Ofproto is an OpenFlow switch instantiated by ovs-vswitchd for a bridge,
+// and the datapath it is backed by.
+type Ofproto struct {
+	Name         string
+	DatapathType string
+	// DatapathID is the OpenFlow datapath ID of the bridge, in hex.
+	DatapathID string
+}
+
+// Datapath returns the name of the datapath of the ofproto, as reported by
+// dpif/show, e.g. system@ovs-system. The bridges of a datapath type share
+// a single datapath.
+func (o Ofproto) Datapath() string {
+	return fmt.Sprintf("%s@ovs-%s", o.DatapathType, o.DatapathType)
+}
+
+// GetOfprotos returns the ofprotos of ovs-vswitchd using ovs-appctl
+// ofproto/list, with the datapath type and ID of their bridge.
+func (e *Exporter) GetOfprotos() ([]Ofproto, error) {
+	target, err := e.appctlTarget("vswitchd-service")
+	if err != nil {
+		return nil, err
+	}
+	output, err := e.command("ovs-appctl", "-t", target, "ofproto/list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute ofproto/list: %w", err)
+	}
+	query := "SELECT name, datapath_type, datapath_id FROM Bridge"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseOfprotos(string(output), result), nil
+}
+
+// parseOfprotos parses the output of ofproto/list, listing an ofproto per
+// line, and looks up the datapath of their bridge in the Bridge table. An
+// empty datapath type is the default, system.
+func parseOfprotos(output string, result ovsdb.Result) []Ofproto {
+	bridges := make(map[string]Ofproto)
+	for _, row := range result.Rows {
+		name, dt, err := row.GetColumnValue("name", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		bridge := Ofproto{Name: name.(string), DatapathType: "system"}
+		if value, dt, err := row.GetColumnValue("datapath_type", result.Columns); err == nil && dt == "string" && value.(string) != "" {
+			bridge.DatapathType = value.(string)
+		}
+		// An unset datapath ID is returned as an empty set.
+		if value, dt, err := row.GetColumnValue("datapath_id", result.Columns); err == nil && dt == "string" {
+			bridge.DatapathID = value.(string)
+		}
+		bridges[bridge.Name] = bridge
+	}
+	var ofprotos []Ofproto
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		if name == "" {
+			continue
+		}
+		ofproto, exists := bridges[name]
+		if !exists {
+			ofproto = Ofproto{Name: name, DatapathType: "system"}
+		}
+		ofprotos = append(ofprotos, ofproto)
+	}
+	return ofprotos
+}
+
+// collectOfprotoMetrics exports the datapath of each ofproto, so that the
+// metrics of the bridges, e.g. of ovs-ofctl, can be joined with the ones of
+// the datapaths, e.g. of dpctl, on the datapath label.
+func (e *Exporter) collectOfprotoMetrics() {
+	e.IncrementRequestCounter()
+	ofprotos, err := e.GetOfprotos()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOfprotos() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOfprotos() failed", err)
+		return
+	}
+	for _, ofproto := range ofprotos {
+		e.emit(e.newConstMetric(
+			ofprotoInfo,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID,
+			ofproto.Name,
+			ofproto.Datapath(),
+			ofproto.DatapathType,
+			ofproto.DatapathID,
+		))
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseOfprotos(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"name": "string", "datapath_type": "string", "datapath_id": "string"},
+		Rows: []ovsdb.Row{
+			{
+				"name":          "br-int",
+				"datapath_type": "",
+				"datapath_id":   "00002a4f8c3b1e46",
+			},
+			{
+				"name":          "br-phy",
+				"datapath_type": "netdev",
+				"datapath_id":   "0000b8cef6a1c2d4",
+			},
+			{
+				"name":          "br-down",
+				"datapath_type": "netdev",
+				"datapath_id":   []interface{}{"set", []interface{}{}},
+			},
+		},
+	}
+	output := "br-int\nbr-phy\nbr-new\n"
+
+	expected := []Ofproto{
+		{Name: "br-int", DatapathType: "system", DatapathID: "00002a4f8c3b1e46"},
+		{Name: "br-phy", DatapathType: "netdev", DatapathID: "0000b8cef6a1c2d4"},
+		{Name: "br-new", DatapathType: "system"},
+	}
+	ofprotos := parseOfprotos(output, result)
+	if !reflect.DeepEqual(ofprotos, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, ofprotos)
+	}
+	if datapath := ofprotos[1].Datapath(); datapath != "netdev@ovs-netdev" {
+		t.Errorf("Expected datapath netdev@ovs-netdev, got %q", datapath)
+	}
+}
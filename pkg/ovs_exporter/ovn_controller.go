@@ -0,0 +1,224 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ovnControllerEngineRunTypes are the run counters of the nodes of the
+// incremental processing engine of ovn-controller: full recomputes,
+// incremental computes and runs cancelled, e.g. because the OVS database
+// was not writable.
+var ovnControllerEngineRunTypes = map[string]bool{
+	"recompute": true,
+	"compute":   true,
+	"cancel":    true,
+}
+
+// OvnControllerNbCfg holds the sequence number of the northbound
+// configuration for which ovn-controller installed the OpenFlow flows, from
+// the external_ids of the Open_vSwitch table. The timestamp, in
+// milliseconds, is only set by OVN releases providing it.
+type OvnControllerNbCfg struct {
+	NbCfg     int64
+	Timestamp int64
+}
+
+// ovnRecomputeTracker records when the exporter observed the last
+// recompute of the incremental processing engine of ovn-controller. The
+// exact time of a recompute is unknown, hence it is the time of the poll in
+// which the recompute counters changed. The recomputes before the first
+// poll, e.g. at the start of ovn-controller, are not observed.
+type ovnRecomputeTracker struct {
+	polled     bool
+	recomputes float64
+	last       time.Time
+}
+
+// observe records the number of recomputes of a poll and returns the time
+// the last recompute was observed, if any.
+func (t *ovnRecomputeTracker) observe(recomputes float64, now time.Time) (time.Time, bool) {
+	if t.polled && recomputes != t.recomputes {
+		t.last = now
+	}
+	t.polled = true
+	t.recomputes = recomputes
+	return t.last, !t.last.IsZero()
+}
+
+// GetOvnControllerEngineStats returns the run counters of the nodes of the
+// incremental processing engine of ovn-controller.
+func (e *Exporter) GetOvnControllerEngineStats() (map[string]map[string]float64, error) {
+	output, err := e.command("ovn-appctl", "-t", "ovn-controller", "inc-engine/show-stats").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute inc-engine/show-stats: %w", err)
+	}
+	return parseOvnControllerEngineStats(string(output)), nil
+}
+
+// parseOvnControllerEngineStats parses the output of inc-engine/show-stats,
+// e.g.:
+//
+//	Node: lflow_output
+//	- recompute:            3
+//	- compute:             12
+//	- cancel:               0
+func parseOvnControllerEngineStats(output string) map[string]map[string]float64 {
+	stats := make(map[string]map[string]float64)
+	var node string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if name, found := strings.CutPrefix(line, "Node:"); found {
+			node = strings.TrimSpace(name)
+			continue
+		}
+		if node == "" {
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "- "), ":")
+		key = strings.TrimSpace(key)
+		if !found || !ovnControllerEngineRunTypes[key] {
+			continue
+		}
+		count, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		if stats[node] == nil {
+			stats[node] = make(map[string]float64)
+		}
+		stats[node][key] = count
+	}
+	return stats
+}
+
+// GetOvnControllerNbCfg returns the northbound configuration installed by
+// ovn-controller.
+func (e *Exporter) GetOvnControllerNbCfg() (OvnControllerNbCfg, error) {
+	query := fmt.Sprintf("SELECT external_ids FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return OvnControllerNbCfg{}, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseOvnControllerNbCfg(result)
+}
+
+// parseOvnControllerNbCfg extracts the ovn-nb-cfg and ovn-nb-cfg-ts keys
+// from the external_ids of the first row of the Open_vSwitch table.
+func parseOvnControllerNbCfg(result ovsdb.Result) (OvnControllerNbCfg, error) {
+	cfg := OvnControllerNbCfg{}
+	if len(result.Rows) == 0 {
+		return cfg, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	r, dt, err := result.Rows[0].GetColumnValue("external_ids", result.Columns)
+	if err != nil {
+		return cfg, fmt.Errorf("parsing 'external_ids' failed: %s", err)
+	}
+	// An empty map is returned as an empty set.
+	externalIDs, ok := r.(map[string]string)
+	if !ok && dt != "[]string" {
+		return cfg, fmt.Errorf("data type '%s' for 'external_ids' column is unexpected in this context", dt)
+	}
+	value, exists := externalIDs["ovn-nb-cfg"]
+	if !exists {
+		return cfg, fmt.Errorf("ovn-nb-cfg not found in external_ids, is ovn-controller running?")
+	}
+	if cfg.NbCfg, err = strconv.ParseInt(value, 10, 64); err != nil {
+		return cfg, fmt.Errorf("malformed ovn-nb-cfg %q", value)
+	}
+	if value, exists := externalIDs["ovn-nb-cfg-ts"]; exists {
+		if cfg.Timestamp, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return cfg, fmt.Errorf("malformed ovn-nb-cfg-ts %q", value)
+		}
+	}
+	return cfg, nil
+}
+
+// collectOvnControllerMetrics exports the run counters of the incremental
+// processing engine of ovn-controller, the state of its connection to the
+// southbound database and the northbound configuration it installed, to
+// catch chassis where the OpenFlow programming lags behind the changes of
+// the southbound database.
+func (e *Exporter) collectOvnControllerMetrics() {
+	e.IncrementRequestCounter()
+	stats, err := e.GetOvnControllerEngineStats()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnControllerEngineStats() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnControllerEngineStats() failed", err)
+	} else {
+		var recomputes float64
+		for node, runs := range stats {
+			for runType, count := range runs {
+				e.emit(e.newConstMetric(
+					ovnControllerEngineRuns,
+					prometheus.CounterValue,
+					count,
+					e.Client.System.ID,
+					node,
+					runType,
+				))
+			}
+			recomputes += runs["recompute"]
+		}
+		if last, observed := e.ovnRecompute.observe(recomputes, time.Now()); observed {
+			e.emit(e.newConstMetric(
+				ovnControllerLastRecompute,
+				prometheus.GaugeValue,
+				float64(last.Unix()),
+				e.Client.System.ID,
+			))
+		}
+	}
+
+	e.collectOvnControllerSbMetrics()
+
+	e.IncrementRequestCounter()
+	cfg, err := e.GetOvnControllerNbCfg()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnControllerNbCfg() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnControllerNbCfg() failed", err)
+		return
+	}
+	e.emit(e.newConstMetric(
+		ovnControllerNbCfg,
+		prometheus.GaugeValue,
+		float64(cfg.NbCfg),
+		e.Client.System.ID,
+	))
+	if cfg.Timestamp > 0 {
+		e.emit(e.newConstMetric(
+			ovnControllerNbCfgTimestamp,
+			prometheus.GaugeValue,
+			float64(cfg.Timestamp)/1000,
+			e.Client.System.ID,
+		))
+	}
+}
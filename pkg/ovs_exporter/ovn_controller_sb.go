@@ -0,0 +1,238 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ovnDefaultProbeInterval is the inactivity probe interval, in
+// milliseconds, of the connection of ovn-controller to the southbound
+// database when ovn-remote-probe-interval is not set.
+const ovnDefaultProbeInterval = 5000
+
+// reconnectLogRe matches the messages of the reconnect module about the
+// state of a connection, e.g.:
+//
+//	2025-06-02T10:15:00.123Z|00042|reconnect|ERR|ssl:10.0.0.1:6642: no response to inactivity probe after 5 seconds, disconnecting
+var reconnectLogRe = regexp.MustCompile(`\|reconnect\|[A-Z]+\|(\S+): (.*)$`)
+
+// reconnectEvents maps the prefixes of the messages of the reconnect module
+// to the event label of ovn_controller_reconnect_events_total.
+var reconnectEvents = []struct {
+	prefix string
+	event  string
+}{
+	{"connected", "connected"},
+	{"connection dropped", "dropped"},
+	{"connection closed by peer", "dropped"},
+	{"no response to inactivity probe", "probe_timeout"},
+	{"connection attempt timed out", "failed"},
+	{"connection attempt failed", "failed"},
+}
+
+// reconnectEvent is an event of a connection of ovn-controller.
+type reconnectEvent struct {
+	remote string
+	event  string
+}
+
+// parseReconnectEvent returns the event of a log line of the reconnect
+// module.
+func parseReconnectEvent(line string) (reconnectEvent, bool) {
+	m := reconnectLogRe.FindStringSubmatch(line)
+	if m == nil {
+		return reconnectEvent{}, false
+	}
+	for _, e := range reconnectEvents {
+		if strings.HasPrefix(m[2], e.prefix) {
+			return reconnectEvent{m[1], e.event}, true
+		}
+	}
+	return reconnectEvent{}, false
+}
+
+// reconnectLogTracker counts the connection events logged by ovn-controller
+// since the exporter started, reading the lines appended to the log file
+// between polls.
+type reconnectLogTracker struct {
+	started bool
+	offset  int64
+	counts  map[reconnectEvent]float64
+}
+
+// scan counts the events of the lines appended to the log file since the
+// previous call. The first call only records the end of the file. A file
+// smaller than the offset was rotated and is read from the start.
+func (t *reconnectLogTracker) scan(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if t.counts == nil {
+		t.counts = make(map[reconnectEvent]float64)
+	}
+	if !t.started {
+		t.started = true
+		t.offset = fi.Size()
+		return nil
+	}
+	if fi.Size() < t.offset {
+		t.offset = 0
+	}
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			// An incomplete line is read again on the next call.
+			break
+		}
+		t.offset += int64(len(line))
+		if event, ok := parseReconnectEvent(strings.TrimRight(line, "\n")); ok {
+			t.counts[event]++
+		}
+	}
+	return nil
+}
+
+// GetOvnControllerSbConnected returns whether ovn-controller is connected
+// to the southbound database.
+func (e *Exporter) GetOvnControllerSbConnected() (bool, error) {
+	output, err := e.command("ovn-appctl", "-t", "ovn-controller", "connection-status").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to execute connection-status: %w", err)
+	}
+	return strings.TrimSpace(string(output)) == "connected", nil
+}
+
+// GetOvnRemoteProbeInterval returns the inactivity probe interval of the
+// connection of ovn-controller to the southbound database, in
+// milliseconds.
+func (e *Exporter) GetOvnRemoteProbeInterval() (int64, error) {
+	query := fmt.Sprintf("SELECT external_ids FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return 0, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseOvnRemoteProbeInterval(result)
+}
+
+// parseOvnRemoteProbeInterval extracts the ovn-remote-probe-interval key
+// from the external_ids of the first row of the Open_vSwitch table. 0
+// disables the probes.
+func parseOvnRemoteProbeInterval(result ovsdb.Result) (int64, error) {
+	if len(result.Rows) == 0 {
+		return 0, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	r, _, err := result.Rows[0].GetColumnValue("external_ids", result.Columns)
+	if err != nil {
+		return 0, fmt.Errorf("parsing 'external_ids' failed: %s", err)
+	}
+	// An empty map is returned as an empty set.
+	externalIDs, _ := r.(map[string]string)
+	value, exists := externalIDs["ovn-remote-probe-interval"]
+	if !exists {
+		return ovnDefaultProbeInterval, nil
+	}
+	interval, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("malformed ovn-remote-probe-interval %q", value)
+	}
+	return interval, nil
+}
+
+// collectOvnControllerSbMetrics exports the state of the connection of
+// ovn-controller to the southbound database, its inactivity probe interval
+// and the connection events logged by ovn-controller, so that probe
+// timeout storms at scale can be quantified.
+func (e *Exporter) collectOvnControllerSbMetrics() {
+	e.IncrementRequestCounter()
+	if connected, err := e.GetOvnControllerSbConnected(); err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnControllerSbConnected() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnControllerSbConnected() failed", err)
+	} else {
+		var value float64
+		if connected {
+			value = 1
+		}
+		e.emit(e.newConstMetric(
+			ovnControllerSbConnected,
+			prometheus.GaugeValue,
+			value,
+			e.Client.System.ID,
+		))
+	}
+
+	e.IncrementRequestCounter()
+	if interval, err := e.GetOvnRemoteProbeInterval(); err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnRemoteProbeInterval() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnRemoteProbeInterval() failed", err)
+	} else {
+		e.emit(e.newConstMetric(
+			ovnControllerSbProbeInterval,
+			prometheus.GaugeValue,
+			float64(interval)/1000,
+			e.Client.System.ID,
+		))
+	}
+
+	path := e.Client.Service.OvnController.File.Log.Path
+	if err := e.ovnReconnects.scan(path); err != nil {
+		// ovn-controller may log to the journal only.
+		level.Debug(e.logger).Log(
+			"msg", "reading the log file of ovn-controller failed",
+			"system_id", e.Client.System.ID,
+			"file", path,
+			"error", err.Error(),
+		)
+		return
+	}
+	for event, count := range e.ovnReconnects.counts {
+		e.emit(e.newConstMetric(
+			ovnControllerReconnectEvents,
+			prometheus.CounterValue,
+			count,
+			e.Client.System.ID,
+			event.remote,
+			event.event,
+		))
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseReconnectEvent(t *testing.T) {
+	for line, expected := range map[string]reconnectEvent{
+		"2025-06-02T10:15:00.123Z|00042|reconnect|ERR|ssl:10.0.0.1:6642: no response to inactivity probe after 5 seconds, disconnecting": {"ssl:10.0.0.1:6642", "probe_timeout"},
+		"2025-06-02T10:15:01.123Z|00043|reconnect|INFO|ssl:10.0.0.1:6642: connected":                                                     {"ssl:10.0.0.1:6642", "connected"},
+		"2025-06-02T10:15:02.123Z|00044|reconnect|WARN|unix:/var/run/openvswitch/br-int.mgmt: connection dropped (Broken pipe)":          {"unix:/var/run/openvswitch/br-int.mgmt", "dropped"},
+	} {
+		if event, ok := parseReconnectEvent(line); !ok || event != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, line, event)
+		}
+	}
+	if _, ok := parseReconnectEvent("2025-06-02T10:15:00.123Z|00045|reconnect|INFO|ssl:10.0.0.1:6642: connecting..."); ok {
+		t.Error("Expected no event for a connection attempt")
+	}
+}
+
+func TestReconnectLogTracker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ovn-controller.log")
+	write := func(s string, flag int) {
+		f, err := os.OpenFile(path, flag|os.O_WRONLY|os.O_CREATE, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	probe := "2025-06-02T10:15:00.123Z|00042|reconnect|ERR|ssl:10.0.0.1:6642: no response to inactivity probe after 5 seconds, disconnecting\n"
+	key := reconnectEvent{"ssl:10.0.0.1:6642", "probe_timeout"}
+
+	// The events logged before the exporter started are not counted.
+	write(probe, os.O_TRUNC)
+	var tracker reconnectLogTracker
+	if err := tracker.scan(path); err != nil || tracker.counts[key] != 0 {
+		t.Fatalf("Expected no events on the first scan, got %v (error: %v)", tracker.counts, err)
+	}
+
+	// An incomplete line is counted once complete.
+	write(probe+probe[:20], os.O_APPEND)
+	if err := tracker.scan(path); err != nil || tracker.counts[key] != 1 {
+		t.Fatalf("Expected 1 event, got %v (error: %v)", tracker.counts, err)
+	}
+	write(probe[20:], os.O_APPEND)
+	if err := tracker.scan(path); err != nil || tracker.counts[key] != 2 {
+		t.Fatalf("Expected 2 events, got %v (error: %v)", tracker.counts, err)
+	}
+
+	// A rotated file is read from the start.
+	write(probe, os.O_TRUNC)
+	if err := tracker.scan(path); err != nil || tracker.counts[key] != 3 {
+		t.Fatalf("Expected 3 events after the rotation, got %v (error: %v)", tracker.counts, err)
+	}
+}
+
+func TestParseOvnRemoteProbeInterval(t *testing.T) {
+	for _, tc := range []struct {
+		externalIDs interface{}
+		expected    int64
+	}{
+		{[]interface{}{"map", []interface{}{[]interface{}{"ovn-remote-probe-interval", "30000"}}}, 30000},
+		{[]interface{}{"map", []interface{}{[]interface{}{"system-id", "chassis-1"}}}, ovnDefaultProbeInterval},
+		{[]interface{}{"map", []interface{}{}}, ovnDefaultProbeInterval},
+	} {
+		result := ovsdb.Result{
+			Columns: map[string]string{"external_ids": "map[string]string"},
+			Rows:    []ovsdb.Row{{"external_ids": tc.externalIDs}},
+		}
+		interval, err := parseOvnRemoteProbeInterval(result)
+		if err != nil || interval != tc.expected {
+			t.Errorf("Expected %d, got %d (error: %v)", tc.expected, interval, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseOvnControllerEngineStats(t *testing.T) {
+	output := `Node: SB_chassis
+- recompute:            1
+- compute:              0
+- cancel:               0
+Node: lflow_output
+- recompute:            3
+- compute:             12
+- cancel:               1
+- compute time:        40
+`
+	expected := map[string]map[string]float64{
+		"SB_chassis":   {"recompute": 1, "compute": 0, "cancel": 0},
+		"lflow_output": {"recompute": 3, "compute": 12, "cancel": 1},
+	}
+	if stats := parseOvnControllerEngineStats(output); !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Expected %v, got %v", expected, stats)
+	}
+}
+
+func TestParseOvnControllerNbCfg(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"external_ids": "map[string]string"},
+		Rows: []ovsdb.Row{
+			{"external_ids": []interface{}{"map", []interface{}{
+				[]interface{}{"ovn-nb-cfg", "42"},
+				[]interface{}{"ovn-nb-cfg-ts", "1700000000123"},
+				[]interface{}{"system-id", "chassis-1"},
+			}}},
+		},
+	}
+	cfg, err := parseOvnControllerNbCfg(result)
+	if err != nil {
+		t.Fatalf("parseOvnControllerNbCfg() returned error: %v", err)
+	}
+	if cfg.NbCfg != 42 || cfg.Timestamp != 1700000000123 {
+		t.Errorf("Unexpected configuration %+v", cfg)
+	}
+
+	result.Rows[0]["external_ids"] = []interface{}{"map", []interface{}{}}
+	if _, err := parseOvnControllerNbCfg(result); err == nil {
+		t.Error("Expected an error without ovn-nb-cfg")
+	}
+}
+
+func TestOvnRecomputeTracker(t *testing.T) {
+	var tracker ovnRecomputeTracker
+	start := time.Unix(1760000000, 0)
+	steps := []struct {
+		recomputes float64
+		expected   time.Time
+		observed   bool
+	}{
+		// The recomputes before the first poll are not observed.
+		{4, time.Time{}, false},
+		{4, time.Time{}, false},
+		{5, start.Add(2 * time.Minute), true},
+		{5, start.Add(2 * time.Minute), true},
+		// A restart of ovn-controller resets the counters.
+		{1, start.Add(4 * time.Minute), true},
+	}
+	for i, step := range steps {
+		last, observed := tracker.observe(step.recomputes, start.Add(time.Duration(i)*time.Minute))
+		if !last.Equal(step.expected) || observed != step.observed {
+			t.Errorf("Step %d: expected (%v, %v), got (%v, %v)", i, step.expected, step.observed, last, observed)
+		}
+	}
+}
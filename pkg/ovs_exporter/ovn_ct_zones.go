@@ -0,0 +1,198 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ovnCtZonePrefix is the prefix of the external_ids keys of the integration
+// bridge holding the conntrack zones ovn-controller assigned to the logical
+// entities of the chassis.
+const ovnCtZonePrefix = "ct-zone-"
+
+// OvnCtZone is a conntrack zone assigned by ovn-controller to a logical
+// port, or to the DNAT or SNAT of a logical router, whose entity is then
+// the UUID of the router datapath.
+type OvnCtZone struct {
+	Zone   int
+	Entity string
+	Type   string
+}
+
+// GetOvnCtZones returns the conntrack zones assigned by ovn-controller,
+// recorded as external_ids:ct-zone-NAME of the integration bridge.
+func (e *Exporter) GetOvnCtZones() ([]OvnCtZone, error) {
+	query := "SELECT name, external_ids FROM Bridge"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseOvnCtZones(result), nil
+}
+
+// parseOvnCtZones extracts the conntrack zones from the external_ids of the
+// bridges.
+func parseOvnCtZones(result ovsdb.Result) []OvnCtZone {
+	var zones []OvnCtZone
+	for _, row := range result.Rows {
+		r, _, err := row.GetColumnValue("external_ids", result.Columns)
+		if err != nil {
+			continue
+		}
+		// An empty map is returned as an empty set.
+		externalIDs, ok := r.(map[string]string)
+		if !ok {
+			continue
+		}
+		for key, value := range externalIDs {
+			if !strings.HasPrefix(key, ovnCtZonePrefix) {
+				continue
+			}
+			zone, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			zones = append(zones, newOvnCtZone(zone, strings.TrimPrefix(key, ovnCtZonePrefix)))
+		}
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Zone < zones[j].Zone
+	})
+	return zones
+}
+
+// newOvnCtZone returns the zone of a logical entity named as in the
+// external_ids key: the name of a logical port, or the UUID of a router
+// datapath suffixed with _dnat or _snat.
+func newOvnCtZone(zone int, name string) OvnCtZone {
+	for _, nat := range []string{"dnat", "snat"} {
+		if strings.HasSuffix(name, "_"+nat) {
+			return OvnCtZone{Zone: zone, Entity: strings.TrimSuffix(name, "_"+nat), Type: nat}
+		}
+	}
+	return OvnCtZone{Zone: zone, Entity: name, Type: "port"}
+}
+
+var conntrackZoneRegex = regexp.MustCompile(`(?:^|,)zone=([0-9]+)(?:,|$)`)
+
+// GetConntrackZoneConnections returns the number of connections of each
+// conntrack zone of a datapath using ovs-appctl dpctl/dump-conntrack.
+func (e *Exporter) GetConntrackZoneConnections(datapath string) (map[int]int, error) {
+	output, err := e.vswitchdAppctl("dpctl/dump-conntrack", datapath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dpctl/dump-conntrack %s: %w", datapath, err)
+	}
+	return parseConntrackZoneConnections(string(output)), nil
+}
+
+// parseConntrackZoneConnections counts the connections of the output of
+// dpctl/dump-conntrack by zone. The zone is omitted for the default zone 0.
+func parseConntrackZoneConnections(output string) map[int]int {
+	connections := make(map[int]int)
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		zone := 0
+		if match := conntrackZoneRegex.FindStringSubmatch(line); match != nil {
+			if n, err := strconv.Atoi(match[1]); err == nil {
+				zone = n
+			}
+		}
+		connections[zone]++
+	}
+	return connections
+}
+
+// collectOvnCtZoneMetrics exports the number of connections of the
+// conntrack zones assigned to the logical entities of the chassis, and of
+// the zones with connections but no entity, e.g. the zones of removed
+// ports. It dumps all conntrack entries, hence it is disabled by default.
+func (e *Exporter) collectOvnCtZoneMetrics() {
+	e.IncrementRequestCounter()
+	zones, err := e.GetOvnCtZones()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnCtZones() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnCtZones() failed", err)
+		return
+	}
+	e.IncrementRequestCounter()
+	datapaths, err := e.GetDatapathNames()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetDatapathNames() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetDatapathNames() failed", err)
+		return
+	}
+	connections := make(map[int]int)
+	for _, datapath := range datapaths {
+		e.IncrementRequestCounter()
+		counts, err := e.GetConntrackZoneConnections(datapath)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetConntrackZoneConnections() failed",
+				"system_id", e.Client.System.ID,
+				"datapath", datapath,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetConntrackZoneConnections() failed", err)
+			return
+		}
+		for zone, n := range counts {
+			connections[zone] += n
+		}
+	}
+	for _, zone := range zones {
+		e.emit(e.newConstMetric(
+			ovnChassisCtZoneConnections,
+			prometheus.GaugeValue,
+			float64(connections[zone.Zone]),
+			e.Client.System.ID, strconv.Itoa(zone.Zone), zone.Entity, zone.Type,
+		))
+		delete(connections, zone.Zone)
+	}
+	// The default zone 0 holds the connections of the host.
+	delete(connections, 0)
+	for zone, n := range connections {
+		e.emit(e.newConstMetric(
+			ovnChassisCtZoneConnections,
+			prometheus.GaugeValue,
+			float64(n),
+			e.Client.System.ID, strconv.Itoa(zone), "", "unassigned",
+		))
+	}
+	e.emit(e.newConstMetric(
+		ovnChassisCtZones,
+		prometheus.GaugeValue,
+		float64(len(zones)),
+		e.Client.System.ID,
+	))
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseOvnCtZones(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"name": "string", "external_ids": "map[string]string"},
+		Rows: []ovsdb.Row{
+			{"name": "br-int", "external_ids": []interface{}{"map", []interface{}{
+				[]interface{}{"ct-zone-ns1_pod0", "3"},
+				[]interface{}{"ct-zone-5b1f2c3d-7a8e-4f90-a1b2-c3d4e5f60718_dnat", "1"},
+				[]interface{}{"ct-zone-5b1f2c3d-7a8e-4f90-a1b2-c3d4e5f60718_snat", "2"},
+				[]interface{}{"ct-zone-broken", "x"},
+				[]interface{}{"ovn-nb-cfg", "42"},
+			}}},
+			{"name": "br-ex", "external_ids": []interface{}{"set", []interface{}{}}},
+		},
+	}
+
+	expected := []OvnCtZone{
+		{Zone: 1, Entity: "5b1f2c3d-7a8e-4f90-a1b2-c3d4e5f60718", Type: "dnat"},
+		{Zone: 2, Entity: "5b1f2c3d-7a8e-4f90-a1b2-c3d4e5f60718", Type: "snat"},
+		{Zone: 3, Entity: "ns1_pod0", Type: "port"},
+	}
+	if zones := parseOvnCtZones(result); !reflect.DeepEqual(zones, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, zones)
+	}
+}
+
+func TestParseConntrackZoneConnections(t *testing.T) {
+	output := `tcp,orig=(src=10.0.0.5,dst=10.0.0.6,sport=41234,dport=80),reply=(src=10.0.0.6,dst=10.0.0.5,sport=80,dport=41234),zone=3,protoinfo=(state=ESTABLISHED)
+tcp,orig=(src=10.0.0.5,dst=10.0.0.7,sport=41236,dport=443),reply=(src=10.0.0.7,dst=10.0.0.5,sport=443,dport=41236),zone=3,mark=2,protoinfo=(state=TIME_WAIT)
+udp,orig=(src=172.16.0.1,dst=172.16.0.2,sport=53000,dport=53),reply=(src=172.16.0.2,dst=172.16.0.1,sport=53,dport=53000),zone=12
+icmp,orig=(src=192.168.1.1,dst=192.168.1.2,id=7,type=8,code=0),reply=(src=192.168.1.2,dst=192.168.1.1,id=7,type=0,code=0)
+`
+	expected := map[int]int{0: 1, 3: 2, 12: 1}
+	if connections := parseConntrackZoneConnections(output); !reflect.DeepEqual(connections, expected) {
+		t.Errorf("Expected %v, got %v", expected, connections)
+	}
+}
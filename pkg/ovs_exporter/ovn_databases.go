@@ -0,0 +1,292 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// ovnNamespace is the namespace of the metrics about the OVN databases.
+	ovnNamespace = "ovn"
+	// defaultInactivityProbe is the inactivity probe interval in
+	// milliseconds used by ovsdb-server when the inactivity_probe column
+	// of a connection is not set.
+	defaultInactivityProbe = 5000
+)
+
+// ovnDatabase is an OVN database, e.g. OVN_Northbound, connected to on
+// first use. A failed query drops the connection, so that the next poll
+// reconnects.
+type ovnDatabase struct {
+	name   string
+	socket string
+	client *ovsdb.Client
+	schema *ovsdb.Schema
+}
+
+// newOvnDatabase returns the database served at the socket, or nil when
+// the socket is empty.
+func newOvnDatabase(name, socket string) *ovnDatabase {
+	if socket == "" {
+		return nil
+	}
+	return &ovnDatabase{name: name, socket: socket}
+}
+
+// connect connects to the database, unless already connected, and
+// retrieves its schema.
+func (db *ovnDatabase) connect(timeout int) error {
+	if db.client != nil {
+		return nil
+	}
+	remote, err := clientRemote(db.socket)
+	if err != nil {
+		return fmt.Errorf("failed connecting to %s: %s", db.name, err)
+	}
+	client, err := ovsdb.NewClient(remote, timeout)
+	if err != nil {
+		return fmt.Errorf("failed connecting to %s via %s: %s", db.name, db.socket, err)
+	}
+	db.client = &client
+	db.schema = nil
+	if schema, err := client.GetSchema(db.name); err == nil {
+		db.schema = &schema
+	}
+	return nil
+}
+
+// hasColumns returns whether the schema of the database provides the
+// columns of the table.
+func (db *ovnDatabase) hasColumns(table string, columns ...string) bool {
+	if db.schema == nil {
+		return false
+	}
+	t, exists := db.schema.Tables[table]
+	if !exists {
+		return false
+	}
+	for _, column := range columns {
+		if _, exists := t.Columns[column]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+// transact runs the query against the database.
+func (db *ovnDatabase) transact(query string, timeout int) (ovsdb.Result, error) {
+	if err := db.connect(timeout); err != nil {
+		return ovsdb.Result{}, err
+	}
+	result, err := db.client.Transact(db.name, query)
+	if err != nil {
+		db.client.Close()
+		db.client = nil
+		return ovsdb.Result{}, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return result, nil
+}
+
+// OvnGlobal holds the configuration sequence numbers of the NB_Global or
+// SB_Global table. The SB_Global table only has nb_cfg. The timestamps,
+// in milliseconds, are only set by OVN releases providing them.
+type OvnGlobal struct {
+	NbCfg          int64
+	SbCfg          int64
+	HvCfg          int64
+	NbCfgTimestamp int64
+	HvCfgTimestamp int64
+}
+
+// propagationSeconds returns the time it took all chassis to catch up
+// with the last configuration change, if known.
+func (g OvnGlobal) propagationSeconds() (float64, bool) {
+	if g.HvCfg != g.NbCfg || g.NbCfgTimestamp == 0 || g.HvCfgTimestamp < g.NbCfgTimestamp {
+		return 0, false
+	}
+	return float64(g.HvCfgTimestamp-g.NbCfgTimestamp) / 1000, true
+}
+
+// OvnConnection holds a row of the Connection table.
+type OvnConnection struct {
+	Target          string
+	InactivityProbe int64
+}
+
+// GetOvnGlobal returns the sequence numbers of the global table of the
+// database.
+func (e *Exporter) GetOvnGlobal(db *ovnDatabase) (OvnGlobal, error) {
+	if err := db.connect(e.Client.Timeout); err != nil {
+		return OvnGlobal{}, err
+	}
+	table, columns := "SB_Global", []string{"nb_cfg"}
+	if db.name == "OVN_Northbound" {
+		table, columns = "NB_Global", []string{"nb_cfg", "sb_cfg", "hv_cfg"}
+		if db.hasColumns(table, "nb_cfg_timestamp", "hv_cfg_timestamp") {
+			columns = append(columns, "nb_cfg_timestamp", "hv_cfg_timestamp")
+		}
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), table)
+	result, err := db.transact(query, e.Client.Timeout)
+	if err != nil {
+		return OvnGlobal{}, err
+	}
+	return parseOvnGlobal(result, columns)
+}
+
+// parseOvnGlobal extracts the sequence numbers from the first row of the
+// NB_Global or SB_Global table.
+func parseOvnGlobal(result ovsdb.Result, columns []string) (OvnGlobal, error) {
+	global := OvnGlobal{}
+	if len(result.Rows) == 0 {
+		return global, fmt.Errorf("no rows found in the global table")
+	}
+	values := map[string]*int64{
+		"nb_cfg":           &global.NbCfg,
+		"sb_cfg":           &global.SbCfg,
+		"hv_cfg":           &global.HvCfg,
+		"nb_cfg_timestamp": &global.NbCfgTimestamp,
+		"hv_cfg_timestamp": &global.HvCfgTimestamp,
+	}
+	for _, col := range columns {
+		r, dt, err := result.Rows[0].GetColumnValue(col, result.Columns)
+		if err != nil {
+			return global, fmt.Errorf("parsing '%s' failed: %s", col, err)
+		}
+		if dt != "integer" {
+			return global, fmt.Errorf("data type '%s' for '%s' column is unexpected in this context", dt, col)
+		}
+		*values[col] = r.(int64)
+	}
+	return global, nil
+}
+
+// GetOvnConnections returns the rows of the Connection table of the
+// database, i.e. the remotes ovsdb-server listens on or connects to.
+func (e *Exporter) GetOvnConnections(db *ovnDatabase) ([]OvnConnection, error) {
+	result, err := db.transact("SELECT target, inactivity_probe FROM Connection", e.Client.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return parseOvnConnections(result), nil
+}
+
+// parseOvnConnections extracts the target and the inactivity probe of the
+// connections, applying the ovsdb-server default to unset probes.
+func parseOvnConnections(result ovsdb.Result) []OvnConnection {
+	var connections []OvnConnection
+	for _, row := range result.Rows {
+		r, dt, err := row.GetColumnValue("target", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		connection := OvnConnection{Target: r.(string), InactivityProbe: defaultInactivityProbe}
+		// An unset optional integer is returned as an empty set.
+		if r, dt, err := row.GetColumnValue("inactivity_probe", result.Columns); err == nil && dt == "integer" {
+			connection.InactivityProbe = r.(int64)
+		}
+		connections = append(connections, connection)
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		return connections[i].Target < connections[j].Target
+	})
+	return connections
+}
+
+// collectOvnDatabaseMetrics exports the configuration sequence numbers and
+// the connection settings of an OVN database.
+func (e *Exporter) collectOvnDatabaseMetrics(db *ovnDatabase) {
+	e.IncrementRequestCounter()
+	global, err := e.GetOvnGlobal(db)
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnGlobal() failed",
+			"system_id", e.Client.System.ID,
+			"database", db.name,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnGlobal() failed", err)
+		return
+	}
+	if db == e.ovnNorthbound {
+		e.emit(e.newConstMetric(
+			ovnNbGlobalNbCfg,
+			prometheus.GaugeValue,
+			float64(global.NbCfg),
+			e.Client.System.ID,
+		))
+		e.emit(e.newConstMetric(
+			ovnNbGlobalSbCfg,
+			prometheus.GaugeValue,
+			float64(global.SbCfg),
+			e.Client.System.ID,
+		))
+		e.emit(e.newConstMetric(
+			ovnNbGlobalHvCfg,
+			prometheus.GaugeValue,
+			float64(global.HvCfg),
+			e.Client.System.ID,
+		))
+		e.emit(e.newConstMetric(
+			ovnNbCfgLag,
+			prometheus.GaugeValue,
+			float64(global.NbCfg-global.HvCfg),
+			e.Client.System.ID,
+		))
+		if seconds, ok := global.propagationSeconds(); ok {
+			e.emit(e.newConstMetric(
+				ovnNbCfgPropagation,
+				prometheus.GaugeValue,
+				seconds,
+				e.Client.System.ID,
+			))
+		}
+	} else {
+		e.emit(e.newConstMetric(
+			ovnSbGlobalNbCfg,
+			prometheus.GaugeValue,
+			float64(global.NbCfg),
+			e.Client.System.ID,
+		))
+	}
+
+	e.IncrementRequestCounter()
+	connections, err := e.GetOvnConnections(db)
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnConnections() failed",
+			"system_id", e.Client.System.ID,
+			"database", db.name,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnConnections() failed", err)
+		return
+	}
+	for _, connection := range connections {
+		e.emit(e.newConstMetric(
+			ovnDbConnectionInactivityProbe,
+			prometheus.GaugeValue,
+			float64(connection.InactivityProbe)/1000,
+			e.Client.System.ID, db.name, connection.Target,
+		))
+	}
+}
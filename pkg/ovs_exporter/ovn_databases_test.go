@@ -0,0 +1,142 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseOvnGlobal(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"nb_cfg": "integer", "sb_cfg": "integer", "hv_cfg": "integer"},
+		Rows: []ovsdb.Row{
+			{"nb_cfg": float64(12), "sb_cfg": float64(12), "hv_cfg": float64(10)},
+		},
+	}
+
+	global, err := parseOvnGlobal(result, []string{"nb_cfg", "sb_cfg", "hv_cfg"})
+	if err != nil {
+		t.Fatalf("parseOvnGlobal() returned error: %v", err)
+	}
+	if global.NbCfg != 12 || global.SbCfg != 12 || global.HvCfg != 10 {
+		t.Errorf("Expected nb_cfg=12 sb_cfg=12 hv_cfg=10, got %+v", global)
+	}
+
+	if _, err := parseOvnGlobal(ovsdb.Result{}, []string{"nb_cfg"}); err == nil {
+		t.Error("parseOvnGlobal() should return error for empty result")
+	}
+}
+
+func TestParseOvnConnections(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"target": "string", "inactivity_probe": "integer"},
+		Rows: []ovsdb.Row{
+			{"target": "ptcp:6642", "inactivity_probe": []interface{}{"set", []interface{}{}}},
+			{"target": "pssl:6641", "inactivity_probe": float64(60000)},
+		},
+	}
+
+	connections := parseOvnConnections(result)
+	if len(connections) != 2 {
+		t.Fatalf("Expected 2 connections, got %d", len(connections))
+	}
+	if connections[0].Target != "pssl:6641" || connections[0].InactivityProbe != 60000 {
+		t.Errorf("Unexpected connection: %+v", connections[0])
+	}
+	if connections[1].Target != "ptcp:6642" || connections[1].InactivityProbe != defaultInactivityProbe {
+		t.Errorf("Expected the default inactivity probe for an unset column, got %+v", connections[1])
+	}
+}
+
+func TestNewOvnDatabase(t *testing.T) {
+	if db := newOvnDatabase("OVN_Northbound", ""); db != nil {
+		t.Errorf("Expected no database for an empty socket, got %+v", db)
+	}
+	if db := newOvnDatabase("OVN_Northbound", "unix:/var/run/ovn/ovnnb_db.sock"); db == nil || db.name != "OVN_Northbound" {
+		t.Errorf("Unexpected database: %+v", db)
+	}
+}
+
+func TestOvnGlobalPropagationSeconds(t *testing.T) {
+	for _, test := range []struct {
+		global   OvnGlobal
+		expected float64
+		ok       bool
+	}{
+		{OvnGlobal{NbCfg: 5, HvCfg: 5, NbCfgTimestamp: 1000, HvCfgTimestamp: 3500}, 2.5, true},
+		{OvnGlobal{NbCfg: 6, HvCfg: 5, NbCfgTimestamp: 1000, HvCfgTimestamp: 3500}, 0, false},
+		{OvnGlobal{NbCfg: 5, HvCfg: 5}, 0, false},
+	} {
+		seconds, ok := test.global.propagationSeconds()
+		if seconds != test.expected || ok != test.ok {
+			t.Errorf("propagationSeconds() of %+v = %v, %v, expected %v, %v", test.global, seconds, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestOvnDatabaseTransactFailureClosesClient(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "ovnnb_db.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// A server answering get_schema, reporting when the client hangs up.
+	closed := make(chan struct{})
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		decoder := json.NewDecoder(conn)
+		encoder := json.NewEncoder(conn)
+		for {
+			var req struct {
+				ID     interface{} `json:"id"`
+				Method string      `json:"method"`
+			}
+			if err := decoder.Decode(&req); err != nil {
+				close(closed)
+				return
+			}
+			encoder.Encode(map[string]interface{}{
+				"id":     req.ID,
+				"result": map[string]interface{}{"name": "OVN_Northbound", "version": "7.3.0", "tables": map[string]interface{}{}},
+				"error":  nil,
+			})
+		}
+	}()
+
+	db := newOvnDatabase("OVN_Northbound", "unix:"+socket)
+	if _, err := db.transact("not a query", 1); err == nil {
+		t.Fatal("transact() should return error for a malformed query")
+	}
+	if db.client != nil {
+		t.Error("Expected the client to be dropped after a failed query")
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("Expected the connection to be closed after a failed query")
+	}
+}
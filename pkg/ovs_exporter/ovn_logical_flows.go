@@ -0,0 +1,269 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ovnDefaultIntegrationBridge is the integration bridge of ovn-controller
+// when external_ids:ovn-bridge of the Open_vSwitch table is not set.
+const ovnDefaultIntegrationBridge = "br-int"
+
+// OvnLogicalDatapath is a logical switch or router of the southbound
+// Datapath_Binding table. The flows of logical flows applied to a datapath
+// group are accounted to the group, with the group type.
+type OvnLogicalDatapath struct {
+	UUID string
+	Name string
+	Type string
+}
+
+// OvnLogicalFlows maps the OpenFlow cookies of ovn-controller, the first
+// 32 bits of the UUID of a logical flow, to the datapath of the flow.
+type OvnLogicalFlows struct {
+	Datapaths []OvnLogicalDatapath
+	Cookies   map[uint64]string
+}
+
+// GetOvnIntegrationBridge returns the integration bridge of ovn-controller.
+func (e *Exporter) GetOvnIntegrationBridge() (string, error) {
+	query := fmt.Sprintf("SELECT external_ids FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return "", fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseOvnIntegrationBridge(result), nil
+}
+
+// parseOvnIntegrationBridge extracts the ovn-bridge key from the
+// external_ids of the first row of the Open_vSwitch table.
+func parseOvnIntegrationBridge(result ovsdb.Result) string {
+	if len(result.Rows) == 0 {
+		return ovnDefaultIntegrationBridge
+	}
+	r, _, err := result.Rows[0].GetColumnValue("external_ids", result.Columns)
+	if err != nil {
+		return ovnDefaultIntegrationBridge
+	}
+	// An empty map is returned as an empty set.
+	if externalIDs, ok := r.(map[string]string); ok && externalIDs["ovn-bridge"] != "" {
+		return externalIDs["ovn-bridge"]
+	}
+	return ovnDefaultIntegrationBridge
+}
+
+// GetOvnLogicalFlows returns the logical datapaths and the datapath of
+// each logical flow of the southbound database.
+func (e *Exporter) GetOvnLogicalFlows() (OvnLogicalFlows, error) {
+	db := e.ovnSouthbound
+	if err := db.connect(e.Client.Timeout); err != nil {
+		return OvnLogicalFlows{}, err
+	}
+	query := "SELECT _uuid, external_ids FROM Datapath_Binding"
+	datapaths, err := db.transact(query, e.Client.Timeout)
+	if err != nil {
+		return OvnLogicalFlows{}, err
+	}
+	query = "SELECT _uuid, logical_datapath FROM Logical_Flow"
+	if db.hasColumns("Logical_Flow", "logical_dp_group") {
+		query = "SELECT _uuid, logical_datapath, logical_dp_group FROM Logical_Flow"
+	}
+	flows, err := db.transact(query, e.Client.Timeout)
+	if err != nil {
+		return OvnLogicalFlows{}, err
+	}
+	return parseOvnLogicalFlows(datapaths, flows), nil
+}
+
+// parseOvnLogicalFlows extracts the logical datapaths from the rows of the
+// Datapath_Binding table and the cookies of the rows of the Logical_Flow
+// table. The type of a datapath is switch or router, from the
+// logical-switch or logical-router key of its external_ids.
+func parseOvnLogicalFlows(datapaths, flows ovsdb.Result) OvnLogicalFlows {
+	lflows := OvnLogicalFlows{Cookies: make(map[uint64]string)}
+	for _, row := range datapaths.Rows {
+		uuid, dt, err := row.GetColumnValue("_uuid", datapaths.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		datapath := OvnLogicalDatapath{UUID: uuid.(string)}
+		if r, _, err := row.GetColumnValue("external_ids", datapaths.Columns); err == nil {
+			// An empty map is returned as an empty set.
+			if externalIDs, ok := r.(map[string]string); ok {
+				datapath.Name = externalIDs["name"]
+				if _, exists := externalIDs["logical-router"]; exists {
+					datapath.Type = "router"
+				} else if _, exists := externalIDs["logical-switch"]; exists {
+					datapath.Type = "switch"
+				}
+			}
+		}
+		lflows.Datapaths = append(lflows.Datapaths, datapath)
+	}
+	groups := make(map[string]bool)
+	for _, row := range flows.Rows {
+		uuid, dt, err := row.GetColumnValue("_uuid", flows.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		cookie, ok := ovnLogicalFlowCookie(uuid.(string))
+		if !ok {
+			continue
+		}
+		// Optional references are returned as empty sets when not set.
+		if r, dt, err := row.GetColumnValue("logical_datapath", flows.Columns); err == nil && dt == "string" {
+			lflows.Cookies[cookie] = r.(string)
+			continue
+		}
+		if _, exists := flows.Columns["logical_dp_group"]; !exists {
+			continue
+		}
+		if r, dt, err := row.GetColumnValue("logical_dp_group", flows.Columns); err == nil && dt == "string" {
+			lflows.Cookies[cookie] = r.(string)
+			groups[r.(string)] = true
+		}
+	}
+	for group := range groups {
+		lflows.Datapaths = append(lflows.Datapaths, OvnLogicalDatapath{UUID: group, Type: "group"})
+	}
+	sort.Slice(lflows.Datapaths, func(i, j int) bool {
+		return lflows.Datapaths[i].UUID < lflows.Datapaths[j].UUID
+	})
+	return lflows
+}
+
+// ovnLogicalFlowCookie returns the OpenFlow cookie ovn-controller sets on
+// the flows of a logical flow: the first 32 bits of its UUID.
+func ovnLogicalFlowCookie(uuid string) (uint64, bool) {
+	if len(uuid) < 8 {
+		return 0, false
+	}
+	cookie, err := strconv.ParseUint(uuid[:8], 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return cookie, true
+}
+
+var openflowCookieRegex = regexp.MustCompile(`(?:^|\s)cookie=0x([0-9a-f]+)`)
+
+// GetOpenFlowCookies returns the number of OpenFlow flows of a bridge by
+// cookie using ovs-ofctl dump-flows.
+func (e *Exporter) GetOpenFlowCookies(bridge string) (map[uint64]int, error) {
+	output, err := e.command("ovs-ofctl", "dump-flows", bridge).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dump-flows %s: %w", bridge, err)
+	}
+	return parseOpenFlowCookies(string(output)), nil
+}
+
+// parseOpenFlowCookies counts the flows of the output of ovs-ofctl
+// dump-flows by cookie. The cookie is omitted for the flows without one.
+func parseOpenFlowCookies(output string) map[uint64]int {
+	cookies := make(map[uint64]int)
+	for _, line := range strings.Split(output, "\n") {
+		// The header of the reply has no actions.
+		if !strings.Contains(line, "actions=") {
+			continue
+		}
+		cookie := uint64(0)
+		if match := openflowCookieRegex.FindStringSubmatch(line); match != nil {
+			if n, err := strconv.ParseUint(match[1], 16, 64); err == nil {
+				cookie = n
+			}
+		}
+		cookies[cookie]++
+	}
+	return cookies
+}
+
+// collectOvnLogicalFlowMetrics exports the number of OpenFlow flows of the
+// integration bridge installed for each logical datapath, mapping their
+// cookies to the logical flows of the southbound database. Only the
+// datapaths with flows on the chassis are exported. The flows whose cookie
+// matches no logical flow, e.g. the physical flows of port bindings, have
+// the unmapped type. It dumps all OpenFlow flows and logical flows, hence
+// it is disabled by default.
+func (e *Exporter) collectOvnLogicalFlowMetrics() {
+	e.IncrementRequestCounter()
+	bridge, err := e.GetOvnIntegrationBridge()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnIntegrationBridge() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnIntegrationBridge() failed", err)
+		return
+	}
+	e.IncrementRequestCounter()
+	lflows, err := e.GetOvnLogicalFlows()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOvnLogicalFlows() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOvnLogicalFlows() failed", err)
+		return
+	}
+	e.IncrementRequestCounter()
+	cookies, err := e.GetOpenFlowCookies(bridge)
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetOpenFlowCookies() failed",
+			"system_id", e.Client.System.ID,
+			"bridge", bridge,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetOpenFlowCookies() failed", err)
+		return
+	}
+	flows := make(map[string]int)
+	unmapped := 0
+	for cookie, n := range cookies {
+		if datapath, exists := lflows.Cookies[cookie]; exists {
+			flows[datapath] += n
+		} else {
+			unmapped += n
+		}
+	}
+	for _, datapath := range lflows.Datapaths {
+		if flows[datapath.UUID] == 0 {
+			continue
+		}
+		e.emit(e.newConstMetric(
+			ovnChassisLogicalDatapathFlows,
+			prometheus.GaugeValue,
+			float64(flows[datapath.UUID]),
+			e.Client.System.ID, datapath.UUID, datapath.Name, datapath.Type,
+		))
+	}
+	e.emit(e.newConstMetric(
+		ovnChassisLogicalDatapathFlows,
+		prometheus.GaugeValue,
+		float64(unmapped),
+		e.Client.System.ID, "", "", "unmapped",
+	))
+}
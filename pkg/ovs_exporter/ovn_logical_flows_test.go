@@ -0,0 +1,90 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseOvnIntegrationBridge(t *testing.T) {
+	columns := map[string]string{"external_ids": "map[string]string"}
+	for _, test := range []struct {
+		rows     []ovsdb.Row
+		expected string
+	}{
+		{nil, "br-int"},
+		{[]ovsdb.Row{{"external_ids": []interface{}{"set", []interface{}{}}}}, "br-int"},
+		{[]ovsdb.Row{{"external_ids": []interface{}{"map", []interface{}{
+			[]interface{}{"ovn-bridge", "br-ovn"},
+		}}}}, "br-ovn"},
+	} {
+		result := ovsdb.Result{Columns: columns, Rows: test.rows}
+		if bridge := parseOvnIntegrationBridge(result); bridge != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, bridge)
+		}
+	}
+}
+
+func TestParseOvnLogicalFlows(t *testing.T) {
+	datapaths := ovsdb.Result{
+		Columns: map[string]string{"_uuid": "string", "external_ids": "map[string]string"},
+		Rows: []ovsdb.Row{
+			{"_uuid": []interface{}{"uuid", "d1"}, "external_ids": []interface{}{"map", []interface{}{
+				[]interface{}{"logical-switch", "5b1f2c3d-7a8e-4f90-a1b2-c3d4e5f60718"},
+				[]interface{}{"name", "tenant1-net"},
+			}}},
+			{"_uuid": []interface{}{"uuid", "d2"}, "external_ids": []interface{}{"map", []interface{}{
+				[]interface{}{"logical-router", "0c1d2e3f-4a5b-6c7d-8e9f-a0b1c2d3e4f5"},
+				[]interface{}{"name", "tenant1-router"},
+			}}},
+		},
+	}
+	flows := ovsdb.Result{
+		Columns: map[string]string{"_uuid": "string", "logical_datapath": "string", "logical_dp_group": "string"},
+		Rows: []ovsdb.Row{
+			{"_uuid": []interface{}{"uuid", "0000abcd-1111-2222-3333-444455556666"}, "logical_datapath": []interface{}{"uuid", "d1"}, "logical_dp_group": []interface{}{"set", []interface{}{}}},
+			{"_uuid": []interface{}{"uuid", "8f0e4c21-1111-2222-3333-444455556666"}, "logical_datapath": []interface{}{"uuid", "d2"}, "logical_dp_group": []interface{}{"set", []interface{}{}}},
+			{"_uuid": []interface{}{"uuid", "ffffffff-1111-2222-3333-444455556666"}, "logical_datapath": []interface{}{"set", []interface{}{}}, "logical_dp_group": []interface{}{"uuid", "g1"}},
+		},
+	}
+
+	expected := OvnLogicalFlows{
+		Datapaths: []OvnLogicalDatapath{
+			{UUID: "d1", Name: "tenant1-net", Type: "switch"},
+			{UUID: "d2", Name: "tenant1-router", Type: "router"},
+			{UUID: "g1", Type: "group"},
+		},
+		Cookies: map[uint64]string{0xabcd: "d1", 0x8f0e4c21: "d2", 0xffffffff: "g1"},
+	}
+	if lflows := parseOvnLogicalFlows(datapaths, flows); !reflect.DeepEqual(lflows, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, lflows)
+	}
+}
+
+func TestParseOpenFlowCookies(t *testing.T) {
+	output := `OFPST_FLOW reply (OF1.5) (xid=0x2):
+ cookie=0xabcd, duration=120.5s, table=8, n_packets=10, n_bytes=980, priority=50,reg14=0x1,metadata=0x1 actions=next(table=9)
+ cookie=0x8f0e4c21, duration=120.5s, table=10, n_packets=0, n_bytes=0, priority=100,metadata=0x2 actions=drop
+ cookie=0x8f0e4c21, duration=120.5s, table=10, n_packets=0, n_bytes=0, priority=90,metadata=0x2 actions=resubmit(,11)
+ duration=300.1s, table=0, n_packets=0, n_bytes=0, priority=0 actions=drop
+`
+	expected := map[uint64]int{0: 1, 0xabcd: 1, 0x8f0e4c21: 2}
+	if cookies := parseOpenFlowCookies(output); !reflect.DeepEqual(cookies, expected) {
+		t.Errorf("Expected %v, got %v", expected, cookies)
+	}
+}
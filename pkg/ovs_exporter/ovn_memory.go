@@ -0,0 +1,112 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ParseOvnMemoryTargets parses a comma-separated list of NAME=TARGET pairs
+// mapping the names of OVN daemons, e.g. ovnnb_db, ovnsb_db or ovn-northd,
+// to their control sockets, passed to ovn-appctl with -t, e.g.
+// ovnsb_db=/var/run/ovn/ovnsb_db.ctl.
+func ParseOvnMemoryTargets(s string) (map[string]string, error) {
+	targets := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("malformed OVN memory target entry %q, expected NAME=TARGET", entry)
+		}
+		if _, exists := targets[kv[0]]; exists {
+			return nil, fmt.Errorf("duplicate OVN memory target %q", kv[0])
+		}
+		targets[kv[0]] = kv[1]
+	}
+	return targets, nil
+}
+
+// GetOvnMemoryMetrics returns the memory usage counters of an OVN daemon
+// using ovn-appctl memory/show.
+func (e *Exporter) GetOvnMemoryMetrics(target string) (map[string]float64, error) {
+	output, err := e.command("ovn-appctl", "-t", target, "memory/show").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute memory/show for %s: %w", target, err)
+	}
+	return parseMemoryShow(string(output)), nil
+}
+
+// parseMemoryShow parses the FACILITY:VALUE pairs of the output of
+// memory/show, e.g.:
+//
+//	atoms:1262431 cells:1520476 monitors:6 n-weak-refs:0 sessions:12
+//	raft-backlog-kB:0 raft-log:1284 txn-history:87 txn-history-atoms:4261
+func parseMemoryShow(output string) map[string]float64 {
+	metrics := make(map[string]float64)
+	for _, field := range strings.Fields(output) {
+		key, value, found := strings.Cut(field, ":")
+		if !found || key == "" {
+			continue
+		}
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			metrics[key] = v
+		}
+	}
+	return metrics
+}
+
+// collectOvnMemoryMetrics exports the memory usage counters of the OVN
+// daemons, e.g. the cells and monitors of the OVN databases, which grow
+// with the size of the cluster.
+func (e *Exporter) collectOvnMemoryMetrics() {
+	names := make([]string, 0, len(e.ovnMemoryTargets))
+	for name := range e.ovnMemoryTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.IncrementRequestCounter()
+		metrics, err := e.GetOvnMemoryMetrics(e.ovnMemoryTargets[name])
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetOvnMemoryMetrics() failed",
+				"component", name,
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetOvnMemoryMetrics() failed", err)
+			continue
+		}
+		for facility, value := range metrics {
+			e.emit(e.newConstMetric(
+				memUsage,
+				prometheus.GaugeValue,
+				value,
+				e.Client.System.ID,
+				name,
+				facility,
+			))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOvnMemoryTargets(t *testing.T) {
+	targets, err := ParseOvnMemoryTargets("ovnnb_db=/var/run/ovn/ovnnb_db.ctl, ovn-northd=ovn-northd")
+	if err != nil {
+		t.Fatalf("ParseOvnMemoryTargets() returned error: %v", err)
+	}
+	expected := map[string]string{
+		"ovnnb_db":   "/var/run/ovn/ovnnb_db.ctl",
+		"ovn-northd": "ovn-northd",
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Expected %v, got %v", expected, targets)
+	}
+	for _, s := range []string{"ovnnb_db", "=/var/run/ovn/ovnnb_db.ctl", "a=x,a=y"} {
+		if _, err := ParseOvnMemoryTargets(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestParseMemoryShow(t *testing.T) {
+	output := "atoms:1262431 cells:1520476 monitors:6 sessions:12\nraft-backlog-kB:0 txn-history:87 bogus\n"
+	expected := map[string]float64{
+		"atoms":           1262431,
+		"cells":           1520476,
+		"monitors":        6,
+		"sessions":        12,
+		"raft-backlog-kB": 0,
+		"txn-history":     87,
+	}
+	if metrics := parseMemoryShow(output); !reflect.DeepEqual(metrics, expected) {
+		t.Errorf("Expected %v, got %v", expected, metrics)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LogicalPortBinding is an interface requesting a binding to an OVN
+// logical port through external_ids:iface-id. ovn-controller sets
+// external_ids:ovn-installed once it has bound the port and installed
+// its flows.
+type LogicalPortBinding struct {
+	Interface string
+	IfaceID   string
+	Installed bool
+}
+
+// GetLogicalPortBindings returns the interfaces of the chassis bound, or
+// expected to be bound, to OVN logical ports.
+func (e *Exporter) GetLogicalPortBindings() ([]LogicalPortBinding, error) {
+	query := "SELECT name, external_ids FROM Interface"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseLogicalPortBindings(result), nil
+}
+
+// parseLogicalPortBindings extracts the interfaces with an iface-id.
+func parseLogicalPortBindings(result ovsdb.Result) []LogicalPortBinding {
+	var bindings []LogicalPortBinding
+	for _, row := range result.Rows {
+		name, dt, err := row.GetColumnValue("name", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		r, _, err := row.GetColumnValue("external_ids", result.Columns)
+		if err != nil {
+			continue
+		}
+		// An empty map is returned as an empty set.
+		externalIDs, ok := r.(map[string]string)
+		if !ok || externalIDs["iface-id"] == "" {
+			continue
+		}
+		bindings = append(bindings, LogicalPortBinding{
+			Interface: name.(string),
+			IfaceID:   externalIDs["iface-id"],
+			Installed: externalIDs["ovn-installed"] == "true",
+		})
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Interface < bindings[j].Interface
+	})
+	return bindings
+}
+
+// collectLogicalPortBindingMetrics exports the number of logical ports
+// expected on the chassis and the ports ovn-controller has not bound yet.
+func (e *Exporter) collectLogicalPortBindingMetrics() {
+	e.IncrementRequestCounter()
+	bindings, err := e.GetLogicalPortBindings()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetLogicalPortBindings() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetLogicalPortBindings() failed", err)
+		return
+	}
+	unbound := 0
+	for _, binding := range bindings {
+		if binding.Installed {
+			continue
+		}
+		unbound++
+		e.emit(e.newConstMetric(
+			ovnChassisUnboundPort,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID, binding.Interface, binding.IfaceID,
+		))
+	}
+	e.emit(e.newConstMetric(
+		ovnChassisLogicalPorts,
+		prometheus.GaugeValue,
+		float64(len(bindings)),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		ovnChassisUnboundPorts,
+		prometheus.GaugeValue,
+		float64(unbound),
+		e.Client.System.ID,
+	))
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseLogicalPortBindings(t *testing.T) {
+	externalIDs := func(pairs ...string) []interface{} {
+		m := []interface{}{}
+		for i := 0; i < len(pairs); i += 2 {
+			m = append(m, []interface{}{pairs[i], pairs[i+1]})
+		}
+		return []interface{}{"map", m}
+	}
+	result := ovsdb.Result{
+		Columns: map[string]string{"name": "string", "external_ids": "map[string]string"},
+		Rows: []ovsdb.Row{
+			{"name": "veth1", "external_ids": externalIDs("iface-id", "ns1_pod1", "ovn-installed", "true")},
+			{"name": "br-int", "external_ids": []interface{}{"set", []interface{}{}}},
+			{"name": "veth0", "external_ids": externalIDs("iface-id", "ns1_pod0")},
+			{"name": "eth0", "external_ids": externalIDs("attached-mac", "00:00:00:00:00:01")},
+		},
+	}
+
+	expected := []LogicalPortBinding{
+		{Interface: "veth0", IfaceID: "ns1_pod0", Installed: false},
+		{Interface: "veth1", IfaceID: "ns1_pod1", Installed: true},
+	}
+	if bindings := parseLogicalPortBindings(result); !reflect.DeepEqual(bindings, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, bindings)
+	}
+}
@@ -16,8 +16,8 @@ package ovs_exporter
 
 import (
 	"fmt"
-	_ "net/http/pprof"
 	"os"
+	"regexp"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -61,6 +61,18 @@ var (
 			"db_version",
 		}, nil,
 	)
+	infoWithoutHostname = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "info"),
+		"This metric provides basic information about OVN stack. It is always set to 1.",
+		[]string{
+			"system_id",
+			"rundir",
+			"system_type",
+			"system_version",
+			"ovs_version",
+			"db_version",
+		}, nil,
+	)
 	requestErrors = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "failed_requests_total"),
 		"The number of failed requests to OVN stack.",
@@ -76,11 +88,181 @@ var (
 		"The timestamp of the next potential poll of OVN stack.",
 		[]string{"system_id"}, nil,
 	)
+	schemaFeatureInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "schema_feature"),
+		"Whether the database schema provides the tables and columns of a feature (1) or not (0). Collection depending on unavailable features is skipped.",
+		[]string{"system_id", "feature"}, nil,
+	)
+	schemaSupported = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "schema_supported"),
+		"Whether the version of the database schema is one the exporter was tested with (1) or not (0), i.e. whether OVS is too old or too new for the exporter.",
+		[]string{"system_id", "db", "version"}, nil,
+	)
+	collectInFlight = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "collect_in_flight"),
+		"The number of concurrent Collect() calls, i.e. scrapes being served.",
+		[]string{"system_id"}, nil,
+	)
+	lockWaitSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "lock_wait_seconds_total"),
+		"The total time spent waiting on the exporter mutex.",
+		[]string{"system_id"}, nil,
+	)
+	pollCacheHits = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "poll_cache_hits_total"),
+		"The number of scrapes served from cached metrics because the poll interval has not elapsed.",
+		[]string{"system_id"}, nil,
+	)
+	pollCacheMisses = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "poll_cache_misses_total"),
+		"The number of scrapes that triggered a collection from OVS.",
+		[]string{"system_id"}, nil,
+	)
+	dataAgeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "data_age_seconds"),
+		"The time since the start of the last collection from OVS, i.e. the age of the cached metrics.",
+		[]string{"system_id"}, nil,
+	)
+	suspectSamples = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "suspect_samples_total"),
+		"The number of interface statistics counters observed lower than at the previous poll while ovs-vswitchd kept running. The previous value is exported instead, unless the counter stays lower for several polls.",
+		[]string{"system_id"}, nil,
+	)
+	logMessagesSuppressed = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "log_messages_suppressed_total"),
+		"The number of warnings and errors not logged because the same message was logged within the deduplication window.",
+		[]string{"system_id"}, nil,
+	)
+	systemIDChanges = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "system_id_changes_total"),
+		"The number of changes of the system-id of OVS observed since the exporter started, e.g. after the host was re-provisioned. Unless the system_id label is pinned, the series of the host moved to the new system_id label.",
+		[]string{"system_id"}, nil,
+	)
+	collectorPanics = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "collector_panics_total"),
+		"The number of panics of a collector recovered since the exporter started. A panic fails the collector for the poll.",
+		[]string{"system_id", "collector"}, nil,
+	)
+	collectorLastError = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "collector_last_error"),
+		"The time of the last error of a collector, in seconds since the epoch, labeled with the class of the error: panic, timeout, permission, connection, command, parse or other.",
+		[]string{"system_id", "collector", "error_class"}, nil,
+	)
+	parseUnmatchedLines = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace+"_exporter", "", "parse_unmatched_lines_total"),
+		"The number of lines of command output a parser could not interpret. An increase usually means that the output format of OVS changed and metrics are missing.",
+		[]string{"system_id", "parser"}, nil,
+	)
+	ovnNbGlobalNbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "nb_global_nb_cfg"),
+		"The configuration sequence number requested by the northbound clients (NB_Global:nb_cfg).",
+		[]string{"system_id"}, nil,
+	)
+	ovnNbGlobalSbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "nb_global_sb_cfg"),
+		"The configuration sequence number ovn-northd has written to the southbound database (NB_Global:sb_cfg).",
+		[]string{"system_id"}, nil,
+	)
+	ovnNbGlobalHvCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "nb_global_hv_cfg"),
+		"The configuration sequence number all chassis have caught up with (NB_Global:hv_cfg).",
+		[]string{"system_id"}, nil,
+	)
+	ovnNbCfgLag = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "nb_cfg_lag"),
+		"The number of northbound configuration changes not yet applied by all chassis (nb_cfg - hv_cfg).",
+		[]string{"system_id"}, nil,
+	)
+	ovnNbCfgPropagation = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "nb_cfg_propagation_seconds"),
+		"The time it took all chassis to apply the last northbound configuration change (hv_cfg_timestamp - nb_cfg_timestamp).",
+		[]string{"system_id"}, nil,
+	)
+	ovnSbGlobalNbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "sb_global_nb_cfg"),
+		"The northbound configuration sequence number propagated to the southbound database (SB_Global:nb_cfg).",
+		[]string{"system_id"}, nil,
+	)
+	ovnChassisLogicalPorts = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "chassis", "logical_ports"),
+		"The number of interfaces of the chassis requesting a binding to an OVN logical port (external_ids:iface-id).",
+		[]string{"system_id"}, nil,
+	)
+	ovnChassisUnboundPorts = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "chassis", "unbound_ports"),
+		"The number of logical ports expected on the chassis that ovn-controller has not bound yet (no external_ids:ovn-installed).",
+		[]string{"system_id"}, nil,
+	)
+	ovnChassisUnboundPort = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "chassis", "unbound_port"),
+		"A logical port expected on the chassis that ovn-controller has not bound yet. Always set to 1.",
+		[]string{"system_id", "interface", "iface_id"}, nil,
+	)
+	ovnChassisCtZones = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "chassis", "ct_zones"),
+		"The number of conntrack zones assigned by ovn-controller to the logical entities of the chassis (external_ids:ct-zone-NAME of the integration bridge).",
+		[]string{"system_id"}, nil,
+	)
+	ovnChassisCtZoneConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "chassis", "ct_zone_connections"),
+		"The number of conntrack connections of a zone, by the logical entity it is assigned to: a logical port, or the UUID of a logical router datapath with the dnat or snat type. Zones with connections but no entity have the unassigned type.",
+		[]string{"system_id", "zone", "entity", "type"}, nil,
+	)
+	ovnChassisLogicalDatapathFlows = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "chassis", "logical_datapath_flows"),
+		"The number of OpenFlow flows of the integration bridge installed for the logical flows of a logical datapath, mapped by cookie. The type is switch, router, group for datapath groups, or unmapped for the flows of no logical flow.",
+		[]string{"system_id", "datapath", "name", "type"}, nil,
+	)
+	ovnControllerEngineRuns = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "engine_runs_total"),
+		"The number of runs of a node of the incremental processing engine of ovn-controller by type, i.e. recompute, compute or cancel.",
+		[]string{"system_id", "node", "type"}, nil,
+	)
+	ovnControllerLastRecompute = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "last_recompute_timestamp_seconds"),
+		"The time the exporter observed the last recompute of the incremental processing engine of ovn-controller.",
+		[]string{"system_id"}, nil,
+	)
+	ovnControllerNbCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "nb_cfg"),
+		"The sequence number of the northbound configuration for which ovn-controller installed the OpenFlow flows (Open_vSwitch:external_ids:ovn-nb-cfg).",
+		[]string{"system_id"}, nil,
+	)
+	ovnControllerNbCfgTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "nb_cfg_timestamp_seconds"),
+		"The time ovn-controller installed the OpenFlow flows of the northbound configuration (Open_vSwitch:external_ids:ovn-nb-cfg-ts).",
+		[]string{"system_id"}, nil,
+	)
+	ovnControllerSbConnected = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "sb_connected"),
+		"Whether ovn-controller is connected to the southbound database (ovn-appctl connection-status).",
+		[]string{"system_id"}, nil,
+	)
+	ovnControllerSbProbeInterval = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "sb_probe_interval_seconds"),
+		"The inactivity probe interval of the connection of ovn-controller to the southbound database (Open_vSwitch:external_ids:ovn-remote-probe-interval, 5 seconds when unset). 0 disables the probes.",
+		[]string{"system_id"}, nil,
+	)
+	ovnControllerReconnectEvents = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "controller", "reconnect_events_total"),
+		"The number of connection events of ovn-controller logged since the exporter started, by remote and event: connected, dropped, probe_timeout or failed.",
+		[]string{"system_id", "remote", "event"}, nil,
+	)
+	ovnDbConnectionInactivityProbe = prometheus.NewDesc(
+		prometheus.BuildFQName(ovnNamespace, "", "db_connection_inactivity_probe_seconds"),
+		"The inactivity probe interval of a remote of an OVN database (Connection:inactivity_probe). 0 disables the probe.",
+		[]string{"system_id", "database", "target"}, nil,
+	)
 	pid = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "pid"),
 		"The process ID of a running OVN component. If the component is not running, then the ID is 0.",
 		[]string{"system_id", "component", "user", "group"}, nil,
 	)
+	componentRestarts = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "component_restarts_total"),
+		"The number of restarts of an OVS component since the exporter started, detected from the changes of its pidfile between polls.",
+		[]string{"system_id", "component"}, nil,
+	)
 	logFileSize = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "log_file_size_bytes"),
 		"The size of a log file associated with an OVN component.",
@@ -101,6 +283,133 @@ var (
 		"Whether the network port is up (1) or down (0) for database connection.",
 		[]string{"system_id", "component", "usage"}, nil,
 	)
+	// OVSDB Databases
+	ovsdbDatabaseInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_database_info"),
+		"Represents a database served by ovsdb-server with its storage model (standalone, clustered, relay). This metric is always 1.",
+		[]string{"system_id", "database", "model", "cid", "sid"}, nil,
+	)
+	ovsdbDatabaseConnected = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_database_connected"),
+		"Whether the database is connected to its cluster or relay source (1) or not (0). Standalone databases are always connected.",
+		[]string{"system_id", "database"}, nil,
+	)
+	ovsdbDatabaseLeader = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_database_leader"),
+		"Whether the server is the leader of the database cluster (1) or not (0). Standalone databases always report 1.",
+		[]string{"system_id", "database"}, nil,
+	)
+	ovsdbDatabaseIndex = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_database_index"),
+		"The index of the last transaction applied to a clustered database.",
+		[]string{"system_id", "database"}, nil,
+	)
+	ovsdbDatabaseRelayLag = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_database_relay_lag_seconds"),
+		"The number of seconds since a relay database was last observed connected to its source, 0 while it is connected.",
+		[]string{"system_id", "database"}, nil,
+	)
+	ovsdbDatabaseFileSize = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_database_file_size_bytes"),
+		"The size of the file of an additional database served by ovsdb-server.",
+		[]string{"system_id", "database", "filename"}, nil,
+	)
+	ovsdbTransactions = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_transactions_total"),
+		"The number of OVSDB transactions of a daemon by outcome, e.g. success, aborted, try_again or not_locked, from the txn_* coverage counters.",
+		[]string{"system_id", "component", "status"}, nil,
+	)
+	openflowMessages = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "openflow_messages_total"),
+		"The number of OpenFlow messages exchanged with the controllers by type, i.e. received, sent, queued, discarded, overflow, packet_out or packet_in_overflow, from the coverage counters.",
+		[]string{"system_id", "component", "type"}, nil,
+	)
+	revalidations = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "revalidations_total"),
+		"The number of revalidations of the datapath flows by reason, e.g. flow_table when the OpenFlow tables changed, from the rev_* coverage counters.",
+		[]string{"system_id", "component", "reason"}, nil,
+	)
+	datapathFlowOperations = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "datapath_flow_operations_total"),
+		"The number of operations of ovs-vswitchd on the datapath flows by type, e.g. put and del, from the dpif_* coverage counters.",
+		[]string{"system_id", "component", "operation"}, nil,
+	)
+	datapathFlowOperationFailures = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "datapath_flow_operation_failures_total"),
+		"The number of datapath flows ovs-vswitchd failed to install by reason: duplicate_upcall when the flow already existed, flow_limit when the flow limit was reached and ukey_contention when another handler was installing it.",
+		[]string{"system_id", "component", "reason"}, nil,
+	)
+	recirculationFailures = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "recirculation_failures_total"),
+		"The number of packets dropped because they could not be recirculated by reason: depth_exceeded beyond the maximum recirculation depth of the userspace datapath, no_context when the recirculation context was not found and conflict when it conflicted with the packet.",
+		[]string{"system_id", "component", "reason"}, nil,
+	)
+	ovsdbMonitors = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_monitors"),
+		"The number of monitors of the clients of ovsdb-server, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	ovsdbSessions = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_sessions"),
+		"The number of JSON-RPC sessions of ovsdb-server, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	ovsdbJSONCaches = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_json_caches"),
+		"The number of cached JSON monitor updates shared between clients of ovsdb-server, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	ovsdbTriggers = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_triggers"),
+		"The number of transactions of ovsdb-server waiting for a condition, e.g. a wait operation, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	ovsdbBacklog = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_backlog_bytes"),
+		"The bytes queued by ovsdb-server for sending to its clients across all sessions, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	ovsdbTxnHistory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_txn_history"),
+		"The number of transactions kept in the history of ovsdb-server for monitor_cond_since, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	ovsdbRaftBacklog = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_raft_backlog_bytes"),
+		"The bytes queued by ovsdb-server for sending to the other servers of a clustered database, from memory/show.",
+		[]string{"system_id"}, nil,
+	)
+	// OVS vswitchd configuration
+	vswitchdConfigCurCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vswitchd", "config_cur_cfg"),
+		"The sequence number of the configuration applied by ovs-vswitchd (Open_vSwitch cur_cfg column).",
+		[]string{"system_id"}, nil,
+	)
+	vswitchdConfigNextCfg = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vswitchd", "config_next_cfg"),
+		"The sequence number of the configuration requested from ovs-vswitchd (Open_vSwitch next_cfg column).",
+		[]string{"system_id"}, nil,
+	)
+	vswitchdConfigLagging = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vswitchd", "config_lagging"),
+		"Whether ovs-vswitchd has not yet applied the requested configuration, i.e. cur_cfg lags next_cfg (1) or not (0).",
+		[]string{"system_id"}, nil,
+	)
+	vswitchdConfigSeqnoPending = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vswitchd", "config_seqno_pending"),
+		"The number of configuration changes not yet applied by ovs-vswitchd, i.e. next_cfg - cur_cfg.",
+		[]string{"system_id"}, nil,
+	)
+	vswitchdConfigPendingDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vswitchd", "config_pending_seconds"),
+		"The number of seconds configuration changes have been pending, i.e. next_cfg - cur_cfg has been non-zero. It is 0 when ovs-vswitchd is up to date.",
+		[]string{"system_id"}, nil,
+	)
+	vswitchdConfigLastChange = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "vswitchd", "config_last_change_timestamp_seconds"),
+		"The timestamp when the exporter observed ovs-vswitchd applying a configuration change, i.e. a change of cur_cfg.",
+		[]string{"system_id"}, nil,
+	)
 	// OVS Coverage and Memory
 	covAvg = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "coverage_avg"),
@@ -112,6 +421,11 @@ var (
 		"The total number of times particular events occur during a OVSDB daemon's runtime.",
 		[]string{"system_id", "component", "event"}, nil,
 	)
+	covRate = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "coverage_rate_per_minute"),
+		"The number of times particular events occurred per minute since the previous poll, computed by the exporter.",
+		[]string{"system_id", "component", "event"}, nil,
+	)
 	memUsage = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "memory_usage_bytes"),
 		"The memory usage in bytes.",
@@ -123,6 +437,16 @@ var (
 		"Represents an existing datapath interface. This metrics is always 1.",
 		[]string{"system_id", "datapath", "bridge", "name", "ofport", "index", "port_type"}, nil,
 	)
+	patchPortPeer = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "patch_port_peer"),
+		"Represents a patch port of a bridge and its peer (options:peer), i.e. a link between two bridges. The peer bridge is empty when the peer does not exist. This metric is always 1.",
+		[]string{"system_id", "bridge", "port", "peer_bridge", "peer_port"}, nil,
+	)
+	dpPort = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_port"),
+		"Maps the port numbers of a datapath, e.g. the in_port of the datapath flows and of the drop reasons, to the names of their interfaces. This metric is always 1.",
+		[]string{"system_id", "datapath", "port_no", "name"}, nil,
+	)
 	dpBridgeInterfaceTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "dp_bridge_interfaces"),
 		"The number of interfaces attached to a bridge.",
@@ -133,6 +457,181 @@ var (
 		"The number of flows in a datapath.",
 		[]string{"system_id", "datapath"}, nil,
 	)
+	dpFlowAge = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_flow_age_seconds"),
+		"The distribution of the time since the flows in a datapath were last used.",
+		[]string{"system_id", "datapath"}, nil,
+	)
+	dpFlowUnused = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_flows_unused"),
+		"The number of flows in a datapath that were never used.",
+		[]string{"system_id", "datapath"}, nil,
+	)
+	dpOffloadFlows = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_offload_flows"),
+		"The number of flows in a datapath handled by a datapath layer, i.e. ovs for the software path, tc or dpdk, and whether they are offloaded (yes, partial or no).",
+		[]string{"system_id", "datapath", "dp_layer", "offloaded"}, nil,
+	)
+	dpOffloadFlowPackets = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_offload_flow_packets"),
+		"The number of packets matched by the current flows in a datapath by datapath layer and offload state. Evicted flows are not accounted for.",
+		[]string{"system_id", "datapath", "dp_layer", "offloaded"}, nil,
+	)
+	dpOffloadFlowBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_offload_flow_bytes"),
+		"The number of bytes matched by the current flows in a datapath by datapath layer and offload state. Evicted flows are not accounted for.",
+		[]string{"system_id", "datapath", "dp_layer", "offloaded"}, nil,
+	)
+	bondActiveSlaveChanges = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bond", "active_slave_changes_total"),
+		"The number of changes of the active member of a bond (ovs-appctl bond/show) observed by the exporter, including the losses of all members.",
+		[]string{"system_id", "bond"}, nil,
+	)
+	bondActiveSlaveInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bond", "active_slave_info"),
+		"The active member of a bond. Always set to 1. Absent when no member is enabled.",
+		[]string{"system_id", "bond", "mode", "slave"}, nil,
+	)
+	tunnelNeighborEntries = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "tunnel_neighbor_entries"),
+		"The number of entries in the tunnel neighbor (ARP/ND) cache of ovs-vswitchd.",
+		[]string{"system_id", "bridge", "family"}, nil,
+	)
+	systemStatisticsCPUs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "cpus"),
+		"The number of CPU cores of the host reported by ovs-vswitchd (Open_vSwitch:statistics, requires other_config:enable-statistics=true).",
+		[]string{"system_id"}, nil,
+	)
+	systemStatisticsLoadAverage = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "load_average"),
+		"The load average of the host over the period reported by ovs-vswitchd.",
+		[]string{"system_id", "period"}, nil,
+	)
+	systemStatisticsMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "memory_bytes"),
+		"The memory of the host reported by ovs-vswitchd by type: total, used, swap_total and swap_used.",
+		[]string{"system_id", "type"}, nil,
+	)
+	systemStatisticsProcessVirtualMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "process_virtual_memory_bytes"),
+		"The virtual memory size of an OVS daemon reported by ovs-vswitchd.",
+		[]string{"system_id", "process"}, nil,
+	)
+	systemStatisticsProcessResidentMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "process_resident_memory_bytes"),
+		"The resident set size of an OVS daemon reported by ovs-vswitchd.",
+		[]string{"system_id", "process"}, nil,
+	)
+	systemStatisticsProcessCPUTime = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "process_cpu_seconds_total"),
+		"The CPU time used by an OVS daemon reported by ovs-vswitchd.",
+		[]string{"system_id", "process"}, nil,
+	)
+	systemStatisticsProcessCrashes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "process_crashes_total"),
+		"The number of times an OVS daemon crashed and was restarted by its monitor, reported by ovs-vswitchd.",
+		[]string{"system_id", "process"}, nil,
+	)
+	systemStatisticsProcessUptime = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "system_statistics", "process_uptime_seconds"),
+		"The time since an OVS daemon was last started by its monitor, reported by ovs-vswitchd. Not exported for daemons run without --monitor.",
+		[]string{"system_id", "process"}, nil,
+	)
+	kernelModuleInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "kernel_module_info"),
+		"The openvswitch kernel module backing the system datapath. Always set to 1. The version is only reported by out-of-tree modules built from the OVS sources.",
+		[]string{"system_id", "version", "srcversion", "source", "kernel_release"}, nil,
+	)
+	kernelUserspaceVersionMismatch = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "kernel_userspace_version_mismatch"),
+		"Whether the out-of-tree openvswitch kernel module was built from a different OVS major or minor release than ovs-vswitchd.",
+		[]string{"system_id"}, nil,
+	)
+	bridgeOpenFlowProtocol = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridge_openflow_protocol"),
+		"The OpenFlow versions enabled on a bridge (Bridge:protocols). Always set to 1. The protocol is \"default\" when the column is empty.",
+		[]string{"system_id", "bridge", "protocol"}, nil,
+	)
+	ofprotoInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ofproto_info"),
+		"The datapath backing an OpenFlow switch (ofproto) of ovs-vswitchd, named after its bridge, with the type of the datapath and the OpenFlow datapath ID of the bridge. Always set to 1.",
+		[]string{"system_id", "bridge", "datapath", "datapath_type", "datapath_id"}, nil,
+	)
+	bridgeSamplingEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridge_sampling_enabled"),
+		"The flow sampling protocols (sflow, netflow or ipfix) configured on a bridge. Always set to 1.",
+		[]string{"system_id", "bridge", "protocol"}, nil,
+	)
+	bridgeIpfixSampledPackets = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridge_ipfix_sampled_packets_total"),
+		"The number of packets sampled by the IPFIX exporter of a bridge.",
+		[]string{"system_id", "bridge"}, nil,
+	)
+	bridgeIpfixSentPackets = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridge_ipfix_sent_packets_total"),
+		"The number of IPFIX packets sent to the collectors by the IPFIX exporter of a bridge.",
+		[]string{"system_id", "bridge"}, nil,
+	)
+	bridgeIpfixErrors = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridge_ipfix_errors_total"),
+		"The number of samples lost by the IPFIX exporter of a bridge by type: packet for sampled packets that could not be parsed, ipv4 and ipv6 for flow records that could not be built, tx for records that could not be sent.",
+		[]string{"system_id", "bridge", "type"}, nil,
+	)
+	bridgeControllerRtt = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridge_controller_rtt_seconds"),
+		"The round-trip time of an OpenFlow echo request sent to a controller of a bridge.",
+		[]string{"system_id", "bridge", "target"}, nil,
+	)
+	dpdkLogLevel = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dpdk_log_level"),
+		"The log level of a DPDK log type, from 1 (emergency) to 8 (debug).",
+		[]string{"system_id", "type", "level"}, nil,
+	)
+	vlogModules = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "vlog_modules"),
+		"The number of logging modules of an OVS daemon at a log level (off, emer, err, warn, info or dbg) for a log destination, from ovs-appctl vlog/list.",
+		[]string{"system_id", "component", "destination", "level"}, nil,
+	)
+	vlogModuleLevel = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "vlog_module_level"),
+		"The log level of a logging module of an OVS daemon for a log destination, from 0 (off) to 5 (dbg).",
+		[]string{"system_id", "component", "module", "destination", "level"}, nil,
+	)
+	dpdkLogLevelDrift = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dpdk_log_level_drift"),
+		"Whether the log level of a DPDK log type differs from the desired level (1) or not (0).",
+		[]string{"system_id", "type", "desired_level"}, nil,
+	)
+	meterFlows = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "meter_flows"),
+		"The number of flows using an OpenFlow meter.",
+		[]string{"system_id", "bridge", "meter_id"}, nil,
+	)
+	meterPacketsIn = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "meter_packets_total"),
+		"The number of packets processed by an OpenFlow meter.",
+		[]string{"system_id", "bridge", "meter_id"}, nil,
+	)
+	meterBytesIn = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "meter_bytes_total"),
+		"The number of bytes processed by an OpenFlow meter.",
+		[]string{"system_id", "bridge", "meter_id"}, nil,
+	)
+	meterBandRate = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "meter_band_rate"),
+		"The rate of a meter band, in the unit of the meter (kbps or pktps).",
+		[]string{"system_id", "bridge", "meter_id", "band", "type", "unit"}, nil,
+	)
+	meterBandPackets = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "meter_band_packets_total"),
+		"The number of packets that exceeded the rate of a meter band, i.e. dropped by drop bands.",
+		[]string{"system_id", "bridge", "meter_id", "band"}, nil,
+	)
+	meterBandBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "meter_band_bytes_total"),
+		"The number of bytes that exceeded the rate of a meter band, i.e. dropped by drop bands.",
+		[]string{"system_id", "bridge", "meter_id", "band"}, nil,
+	)
 	// OVS Datapath: Lookups
 	dpLookupsHit = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "dp_lookups_hit_total"),
@@ -165,8 +664,64 @@ var (
 		"The average number of masks visited per packet. It is the ration between hit and total number of packets processed by a datapath.",
 		[]string{"system_id", "datapath"}, nil,
 	)
+	dpMasksChurn = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_masks_churn_total"),
+		"The sum of the changes of the number of masks of a datapath between polls, a lower bound of the masks added and removed.",
+		[]string{"system_id", "datapath"}, nil,
+	)
+	dpMasksUsage = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_masks_usage_ratio"),
+		"The number of masks of a datapath relative to the expected maximum set with -datapath.masks.expected-max.",
+		[]string{"system_id", "datapath"}, nil,
+	)
+	// OVSDB Inventory
+	ovsdbTableRows = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ovsdb_table_rows"),
+		"The number of rows in a table of the Open_vSwitch database. A steady growth usually means that rows are leaked, e.g. by a management plane not deleting the QoS of deleted ports.",
+		[]string{"system_id", "table"}, nil,
+	)
+	inventoryBridges = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "bridges"),
+		"The number of rows in the Bridge table.",
+		[]string{"system_id"}, nil,
+	)
+	inventoryPorts = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "ports"),
+		"The number of rows in the Port table.",
+		[]string{"system_id"}, nil,
+	)
+	inventoryInterfaces = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interfaces"),
+		"The number of rows in the Interface table.",
+		[]string{"system_id"}, nil,
+	)
 	// OVS Interface
 	// Reference: http://www.openvswitch.org/support/dist-docs/ovs-vswitchd.conf.db.5.html
+	interfacesAdded = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interfaces_added_total"),
+		"The number of interfaces added between polls, i.e. rows of the Interface table with a new UUID.",
+		[]string{"system_id"}, nil,
+	)
+	interfacesRemoved = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interfaces_removed_total"),
+		"The number of interfaces removed between polls.",
+		[]string{"system_id"}, nil,
+	)
+	interfaceStatisticsAge = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_statistics_age_seconds"),
+		"The estimated age of the statistics of the interfaces: the time since a poll last observed a change of the statistics of any interface.",
+		[]string{"system_id"}, nil,
+	)
+	statsUpdateInterval = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "stats_update_interval_seconds"),
+		"The interval at which ovs-vswitchd writes the statistics of the interfaces to the database (other_config:stats-update-interval).",
+		[]string{"system_id"}, nil,
+	)
+	interfacesTruncated = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interfaces_truncated"),
+		"The number of interfaces of the Interface table left out of the last poll because of the limit on the number of exported interfaces.",
+		[]string{"system_id"}, nil,
+	)
 	interfaceMain = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "interface"),
 		"Represents OVS interface. This is the primary metric for all other interface metrics. This metrics is always 1.",
@@ -182,6 +737,11 @@ var (
 		"The  observed  state of the physical network link of OVS interface. The values are: down(0), up(1), other(2).",
 		[]string{"system_id", "uuid", "name"}, nil,
 	)
+	interfaceInconsistent = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_inconsistent"),
+		"Whether OVS interface was in the inconsistent state of a check for the configured number of consecutive polls (1) or not (0). The checks are admin_up_link_down, admin_down_link_up, ofport_error_link_up and, for patch ports, patch_mtu_mismatch.",
+		[]string{"system_id", "uuid", "name", "check"}, nil,
+	)
 	interfaceIngressPolicingBurst = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "interface_ingress_policing_burst_kilobits"),
 		"Maximum burst size for data received on OVS interface, in kilobits. The default burst size if set to 0 is 8000 kbit.",
@@ -292,11 +852,31 @@ var (
 		[]string{"system_id", "uuid", "name"}, nil,
 	)
 	// OVS Link attributes, e.g. speed, resets, etc.
+	interfaceDpdkLinkTransitions = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_dpdk_link_transitions_total"),
+		"The number of link state transitions of a DPDK physical port to the up or down state observed between polls since the exporter started.",
+		[]string{"system_id", "uuid", "name", "state"}, nil,
+	)
 	interfaceLinkResets = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "interface_link_resets_total"),
 		"The number of times Open vSwitch has observed the link_state of OVS interface change.",
 		[]string{"system_id", "uuid", "name"}, nil,
 	)
+	interfaceKernelCarrierChanges = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_kernel_carrier_changes_total"),
+		"The number of carrier changes of the kernel network device of OVS interface.",
+		[]string{"system_id", "uuid", "name"}, nil,
+	)
+	interfaceKernelOperState = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_kernel_operstate"),
+		"The operational state of the kernel network device of OVS interface. This metric is always 1.",
+		[]string{"system_id", "uuid", "name", "operstate"}, nil,
+	)
+	interfaceKernelLinkSpeed = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_kernel_link_speed_bits_per_second"),
+		"The link speed of the kernel network device of OVS interface in bits per second.",
+		[]string{"system_id", "uuid", "name"}, nil,
+	)
 	interfaceLinkSpeed = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "interface_link_speed_bits_per_second"),
 		"The negotiated speed of the physical network link of OVS interface in bits per second.",
@@ -323,6 +903,86 @@ var (
 		"Represents the number of received multicast packets by OVS interface.",
 		[]string{"system_id", "uuid", "name"}, nil,
 	)
+	interfaceAfxdpRxDropped = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_afxdp_rx_dropped_total"),
+		"The number of received packets dropped by the kernel, e.g. because the socket buffer was full, per queue of an AF_XDP interface (xsk_queue_N_rx_dropped).",
+		[]string{"system_id", "uuid", "name", "queue"}, nil,
+	)
+	interfaceAfxdpRxInvalidDescs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_afxdp_rx_invalid_descs_total"),
+		"The number of invalid descriptors in the rx ring, per queue of an AF_XDP interface (xsk_queue_N_rx_invalid_descs).",
+		[]string{"system_id", "uuid", "name", "queue"}, nil,
+	)
+	interfaceAfxdpTxInvalidDescs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_afxdp_tx_invalid_descs_total"),
+		"The number of invalid descriptors in the tx ring, per queue of an AF_XDP interface (xsk_queue_N_tx_invalid_descs).",
+		[]string{"system_id", "uuid", "name", "queue"}, nil,
+	)
+	interfaceAfxdpRxRingFull = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_afxdp_rx_ring_full_total"),
+		"The number of received packets dropped because the rx ring was full, per queue of an AF_XDP interface (xsk_queue_N_rx_ring_full).",
+		[]string{"system_id", "uuid", "name", "queue"}, nil,
+	)
+	interfaceAfxdpRxFillRingEmptyDescs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_afxdp_rx_fill_ring_empty_descs_total"),
+		"The number of times the fill ring was empty when packets were received, per queue of an AF_XDP interface (xsk_queue_N_rx_fill_ring_empty_descs).",
+		[]string{"system_id", "uuid", "name", "queue"}, nil,
+	)
+	interfaceAfxdpTxRingEmptyDescs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_afxdp_tx_ring_empty_descs_total"),
+		"The number of times the tx ring was empty when the kernel looked for packets to send, per queue of an AF_XDP interface (xsk_queue_N_tx_ring_empty_descs).",
+		[]string{"system_id", "uuid", "name", "queue"}, nil,
+	)
+	interfaceStatWraps = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_stat_wraps_total"),
+		"The number of times a key of the statistics of OVS interface configured as a 32-bit counter wrapped. The exported value of the key is extended to 64 bits.",
+		[]string{"system_id", "uuid", "name", "key"}, nil,
+	)
+	interfaceVhostGuestNotifications = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_vhost_guest_notifications_total"),
+		"The number of notifications OVS sent to the guest on a queue of a vhost-user interface ({rx,tx}_qN_guest_notifications). Guests polling the rings, e.g. DPDK applications, are not notified.",
+		[]string{"system_id", "uuid", "name", "queue", "direction"}, nil,
+	)
+	interfaceVhostInterruptMode = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_vhost_interrupt_mode"),
+		"Whether the guest of a vhost-user interface was notified since the previous poll, i.e. its virtio driver runs in interrupt mode instead of polling the rings.",
+		[]string{"system_id", "uuid", "name"}, nil,
+	)
+	interfaceDpdkRequestedRxQueues = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_dpdk_requested_rx_queues"),
+		"The number of rx queues requested for a DPDK physical port (options:n_rxq, 1 when unset).",
+		[]string{"system_id", "uuid", "name"}, nil,
+	)
+	interfaceDpdkQueues = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_dpdk_queues"),
+		"The number of rx or tx queues configured on a DPDK physical port (status:n_rxq and status:n_txq), which may be lower than requested when the NIC supports fewer queues.",
+		[]string{"system_id", "uuid", "name", "direction"}, nil,
+	)
+	interfaceDpdkQueueDescriptors = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_dpdk_queue_descriptors"),
+		"The number of descriptors of each rx or tx queue of a DPDK physical port (options:n_rxq_desc and options:n_txq_desc, 2048 when unset or invalid).",
+		[]string{"system_id", "uuid", "name", "direction"}, nil,
+	)
+	interfaceDpdkRxSteering = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_dpdk_rx_steering"),
+		"Whether the rx steering mode configured on a DPDK physical port (options:rx-steering) is in effect (1) or unsupported by the NIC (0).",
+		[]string{"system_id", "uuid", "name", "mode"}, nil,
+	)
+	interfaceDpdkRxSteeringQueue = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_dpdk_rx_steering_queue"),
+		"The rx queue of a DPDK physical port receiving the packets steered by rx steering (status:rx_steering_queue).",
+		[]string{"system_id", "uuid", "name"}, nil,
+	)
+	afxdpEvents = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "afxdp_events_total"),
+		"The number of events of the AF_XDP interfaces of ovs-vswitchd by type, i.e. cq_empty, cq_skip, fq_full or tx_full, from the afxdp_* coverage counters.",
+		[]string{"system_id", "component", "event"}, nil,
+	)
+	netlinkSocketEvents = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "netlink_socket_events_total"),
+		"The number of events of the netlink sockets of a daemon by type, i.e. overflow, received, recv_jumbo or sent, from the netlink_* coverage counters. Overflows are receive buffer overruns losing messages, e.g. upcalls of the kernel datapath.",
+		[]string{"system_id", "component", "event"}, nil,
+	)
 	// PMD Performance Metrics
 	pmdCyclesPerIteration = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "pmd_cycles_per_iteration"),
@@ -359,6 +1019,11 @@ var (
 		"Total cycles spent in upcalls from PMD.",
 		[]string{"system_id", "pmd_id", "numa_id"}, nil,
 	)
+	pmdUpcallDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_upcall_duration_seconds"),
+		"The distribution of the duration of the upcalls of a PMD thread, converted from the cycles per upcall histogram of pmd-perf-show. Requires other_config:pmd-perf-metrics=true.",
+		[]string{"system_id", "pmd_id", "numa_id"}, nil,
+	)
 	// vHost specific counters
 	vhostTxRetries = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "vhost_tx_retries_total"),
@@ -386,12 +1051,32 @@ var (
 		"Total cycles where PMD was busy.",
 		[]string{"system_id", "pmd_id", "numa_id"}, nil,
 	)
+	pmdRxQueues = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_rx_queues"),
+		"The number of enabled rx queues polled by a PMD thread (dpif-netdev/pmd-rxq-show).",
+		[]string{"system_id", "pmd_id", "numa_id"}, nil,
+	)
+	pmdNumaRxQueues = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_numa_rx_queues"),
+		"The number of enabled rx queues polled by the PMD threads of a NUMA node.",
+		[]string{"system_id", "numa_id"}, nil,
+	)
+	pmdThreadInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_thread_info"),
+		"Information about the core and NUMA node of PMD thread and whether it only polls the rx queues pinned to it (isolated). Always 1, to group PMD metrics by core or NUMA node.",
+		[]string{"system_id", "pmd_id", "numa_id", "core_id", "isolated"}, nil,
+	)
 	// Enhanced PMD Metrics
 	pmdCPUUtilization = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "pmd_cpu_utilization_ratio"),
 		"CPU utilization ratio of PMD thread (0-1).",
 		[]string{"system_id", "pmd_id", "numa_id", "core_id"}, nil,
 	)
+	pmdOverloaded = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_overloaded"),
+		"Whether the busy ratio of PMD thread exceeded the overload threshold for the configured number of consecutive polls (1) or not (0).",
+		[]string{"system_id", "pmd_id", "numa_id"}, nil,
+	)
 	pmdIdleCycles = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "pmd_idle_cycles_total"),
 		"Total idle cycles for PMD thread.",
@@ -402,6 +1087,16 @@ var (
 		"Total sleep iterations for PMD thread.",
 		[]string{"system_id", "pmd_id", "numa_id"}, nil,
 	)
+	pmdPhaseCycles = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_phase_cycles_total"),
+		"Total cycles spent by PMD thread per phase, i.e. polling without receiving packets and processing packets, from pmd-stats-show.",
+		[]string{"system_id", "pmd_id", "numa_id", "phase"}, nil,
+	)
+	pmdSleepSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_sleep_seconds_total"),
+		"Total time PMD thread slept in idle periods, see pmd-sleep-max.",
+		[]string{"system_id", "pmd_id", "numa_id"}, nil,
+	)
 	// RX Batch Statistics
 	pmdRxBatches = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "pmd_rx_batches_total"),
@@ -488,6 +1183,11 @@ var (
 		"Specific datapath packet drop counters.",
 		[]string{"system_id", "drop_reason"}, nil,
 	)
+	datapathDropsByCategory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "datapath_drops_by_category_total"),
+		"Datapath packet drop counters summed by category of drop reason.",
+		[]string{"system_id", "category"}, nil,
+	)
 	// Flow Cache Performance Metrics
 	emcHitRate = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "flow_cache_emc_hit_ratio"),
@@ -529,31 +1229,275 @@ var (
 		"Total Megaflow cache misses.",
 		[]string{"system_id", "pmd_id", "numa_id"}, nil,
 	)
+	dpCacheDistribution = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dp_cache_distribution_ratio"),
+		"The share of the packets of the userspace datapath handled by each level of the flow cache hierarchy across all PMD threads, i.e. emc, smc, megaflow or upcall (0-1).",
+		[]string{"system_id", "datapath", "cache"}, nil,
+	)
+	flowCacheEmcInsertInvProb = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "flow_cache_emc_insert_inv_prob"),
+		"The inverse probability of inserting a flow into the EMC (other_config:emc-insert-inv-prob). 0 disables the EMC.",
+		[]string{"system_id"}, nil,
+	)
+	flowCacheSmcEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "flow_cache_smc_enabled"),
+		"Whether the Signature Match Cache is enabled (other_config:smc-enable).",
+		[]string{"system_id"}, nil,
+	)
+	datapathTypeSupported = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "datapath_type_supported"),
+		"The datapath types supported by ovs-vswitchd (Open_vSwitch:datapath_types), e.g. system or netdev. The value is always 1.",
+		[]string{"system_id", "type"}, nil,
+	)
+	interfaceTypeSupported = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "interface_type_supported"),
+		"The interface types supported by ovs-vswitchd (Open_vSwitch:iface_types), e.g. dpdk or afxdp. The value is always 1.",
+		[]string{"system_id", "type"}, nil,
+	)
 	flowCacheLookups = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "flow_cache_lookups_total"),
 		"Total flow cache lookups.",
 		[]string{"system_id", "pmd_id", "numa_id"}, nil,
 	)
+	pmdPartialOffloadHits = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "pmd_partial_offload_hits_total"),
+		"Total packets of a PMD thread matched by partial hardware offload, i.e. carrying the flow mark set by the NIC, from the phwol hits of pmd-stats-show.",
+		[]string{"system_id", "pmd_id", "numa_id"}, nil,
+	)
 )
 
 // Exporter collects OVN data from the given server and exports them using
 // the prometheus metrics package.
 type Exporter struct {
 	sync.RWMutex
-	Client               *ovsdb.OvsClient
-	timeout              int
-	pollInterval         int64
-	errors               int64
-	totalRequests        int64
-	errorsLocker         sync.RWMutex
-	nextCollectionTicker int64
-	metrics              []prometheus.Metric
-	logger               log.Logger
+	Client                *ovsdb.OvsClient
+	timeout               int
+	pollInterval          int64
+	errors                int64
+	totalRequests         int64
+	errorsLocker          sync.RWMutex
+	nextCollectionTicker  int64
+	metrics               []prometheus.Metric
+	stream                chan<- prometheus.Metric
+	logger                log.Logger
+	databaseFilePaths     map[string]string
+	relayLag              relayLagTracker
+	pmdOverload           *pmdOverloadTracker
+	lastCurCfg            int64
+	lastConfigChange      time.Time
+	ovnRecompute          ovnRecomputeTracker
+	ovnReconnects         reconnectLogTracker
+	configPendingSince    time.Time
+	commandWrappers       map[string][]string
+	commandEnv            []string
+	ovsdbProbeDuration    *prometheus.HistogramVec
+	vswitchdProbeDuration *prometheus.HistogramVec
+	ovsdbProbeEnabled     bool
+	vswitchdProbeEnabled  bool
+	metersEnabled         bool
+	ofprotoEnabled        bool
+	tnlNeighEnabled       bool
+	bondsEnabled          bool
+	vlogEnabled           bool
+	dpdkLogEnabled        bool
+	megaflowAgeEnabled    bool
+	flowOffloadEnabled    bool
+	ovnControllerEnabled  bool
+	ovnCtZonesEnabled     bool
+	logicalFlowsEnabled   bool
+	ovnMemoryTargets      map[string]string
+	vlogModulesEnabled    bool
+	keyAllowlists         map[string]map[string]bool
+	schemaFeatures        map[string]bool
+	schemaVersions        map[string]string
+	schemaTables          []string
+	stats                 collectionStats
+	dpdkLogLevels         []DesiredDpdkLogLevel
+	backgroundCollection  atomic.Bool
+	pollJitter            time.Duration
+	controllerRttEnabled  bool
+	sysfsPath             string
+	kernelIntfEnabled     bool
+	ovnNorthbound         *ovnDatabase
+	ovnSouthbound         *ovnDatabase
+	lastCollection        time.Time
+	upValue               int
+	metricTimestamps      bool
+	coverageRates         *coverageRateTracker
+	coverageFullPolls     int
+	coverageCache         map[string]*cachedCoverage
+	labelValueReplacement string
+	redactKeys            *regexp.Regexp
+	interfaceStats        map[string]interfaceStat
+	maxInterfaces         int
+	vswitchdPid           int
+	counterSanity         *counterSanityChecker
+	counterWraps          *counterWrapTracker
+	internalZeroStats     *internalZeroStatsTracker
+	componentRestarts     componentRestartTracker
+	pendingSnapshot       *MetricsSnapshot
+	snapshot              *MetricsSnapshot
+	snapshotMu            sync.Mutex
+	dpMaskChurn           dpMaskChurnTracker
+	dpMasksExpectedMax    int
+	interfaceChurn        interfaceChurnTracker
+	interfaceStatsAge     interfaceStatsAgeTracker
+	vhostInterrupt        vhostInterruptTracker
+	dpdkLink              dpdkLinkTracker
+	systemID              systemIDTracker
+	pinnedSystemID        string
+	hostnameLabel         bool
+	interfaceConsistency  *interfaceConsistencyTracker
+	bondActive            bondActiveTracker
+	forcedCollectionMu    sync.Mutex
+	collectorsMu          sync.Mutex
+	collectorRuns         map[string]*collectorRun
+	runningCollector      string
+	runningSince          time.Time
+	lastForcedCollection  time.Time
 }
 
 type Options struct {
 	Timeout int
 	Logger  log.Logger
+	// DatabaseFilePaths maps the names of additional OVSDB databases to
+	// their database files, e.g. OVN_Northbound=/var/lib/ovn/ovnnb_db.db.
+	DatabaseFilePaths map[string]string
+	// PmdOverloadThreshold is the PMD busy ratio (0-1) above which a PMD
+	// thread is considered overloaded.
+	PmdOverloadThreshold float64
+	// PmdOverloadPolls is the number of consecutive polls the threshold
+	// must be exceeded before a PMD thread is reported as overloaded.
+	PmdOverloadPolls int
+	// CommandWrappers maps the names of external commands, or "*" for all
+	// commands, to the command prefix they are run with, e.g. sudo -n.
+	CommandWrappers map[string][]string
+	// MegaflowAgeEnabled enables sampling the age of datapath flows with
+	// ovs-appctl dpctl/dump-flows.
+	MegaflowAgeEnabled bool
+	// FlowOffloadEnabled enables counting the datapath flows by offload
+	// state with ovs-appctl dpctl/dump-flows -m.
+	FlowOffloadEnabled bool
+	// OvsdbProbeEnabled enables timing a read transaction against
+	// ovsdb-server on each poll.
+	OvsdbProbeEnabled bool
+	// VswitchdProbeEnabled enables timing the version unixctl command of
+	// ovs-vswitchd on each poll.
+	VswitchdProbeEnabled bool
+	// MetersEnabled enables exporting the meters of the bridges with
+	// ovs-ofctl dump-meters and meter-stats.
+	MetersEnabled bool
+	// OfprotoEnabled enables exporting the datapath backing the OpenFlow
+	// switch of each bridge with ovs-appctl ofproto/list on each poll.
+	OfprotoEnabled bool
+	// TunnelNeighEnabled enables exporting the entries of the tunnel
+	// neighbor cache with ovs-appctl tnl/neigh/show on each poll.
+	TunnelNeighEnabled bool
+	// BondsEnabled enables exporting the active member of the bonds with
+	// ovs-appctl bond/show on each poll.
+	BondsEnabled bool
+	// VlogEnabled enables exporting the log levels of ovsdb-server and
+	// ovs-vswitchd with ovs-appctl vlog/list on each poll.
+	VlogEnabled bool
+	// DpdkLogEnabled enables exporting the levels of the DPDK log types
+	// with ovs-appctl dpdk/log-list on each poll.
+	DpdkLogEnabled bool
+	// KeyAllowlists maps the interface key/value pair families, i.e.
+	// status, options and external_ids, to the keys exported for them.
+	KeyAllowlists map[string]map[string]bool
+	// DpdkLogLevels holds the desired log levels of the DPDK log types.
+	DpdkLogLevels []DesiredDpdkLogLevel
+	// ControllerRttEnabled enables measuring the OpenFlow echo round-trip
+	// time of the bridge controllers.
+	ControllerRttEnabled bool
+	// SysfsPath is the mount point of sysfs.
+	SysfsPath string
+	// KernelInterfaceEnabled enables reading the link state of system
+	// interfaces from the kernel.
+	KernelInterfaceEnabled bool
+	// OvnNorthboundSocket is the JSON-RPC socket of the OVN_Northbound
+	// database, e.g. unix:/var/run/ovn/ovnnb_db.sock. When empty, the
+	// database is not queried.
+	OvnNorthboundSocket string
+	// OvnSouthboundSocket is the JSON-RPC socket of the OVN_Southbound
+	// database. When empty, the database is not queried.
+	OvnSouthboundSocket string
+	// MetricTimestamps attaches the time of the last collection from OVS
+	// to the cached samples, instead of letting Prometheus use the time of
+	// the scrape.
+	MetricTimestamps bool
+	// CoverageRatesEnabled enables computing per-minute rates of the
+	// coverage counters from the totals of consecutive polls.
+	CoverageRatesEnabled bool
+	// CoverageFullPolls is the number of polls between the refreshes of
+	// the full set of coverage counters, served from a cache in between.
+	// The drop counters are refreshed on every poll. 0 or 1 refreshes the
+	// coverage counters on every poll.
+	CoverageFullPolls int
+	// LabelValueReplacement replaces invalid UTF-8 sequences, control
+	// characters and quotes in label values. When empty, the characters
+	// are removed.
+	LabelValueReplacement string
+	// RedactKeys matches the keys of the interface status, options and
+	// external_ids pairs whose values are replaced with "REDACTED".
+	RedactKeys *regexp.Regexp
+	// InterfaceStats maps additional keys of the Interface:statistics
+	// column, e.g. vendor-specific DPDK counters, to their value type.
+	InterfaceStats map[string]prometheus.ValueType
+	// MaxInterfaces is the maximum number of interfaces exported on each
+	// poll. Only the names and UUIDs of the other interfaces are read,
+	// bounding the memory used on hosts with runaway numbers of
+	// interfaces. 0 disables the limit.
+	MaxInterfaces int
+	// InterfaceInconsistencyPolls is the number of consecutive polls an
+	// interface must be in an inconsistent state, e.g. enabled without
+	// link, before it is reported.
+	InterfaceInconsistencyPolls int
+	// Counter32Keys are shell patterns of the Interface:statistics keys
+	// reported as 32-bit counters by some drivers, whose wraps are
+	// detected and compensated.
+	Counter32Keys []string
+	// InternalZeroStatsSkip skips the statistics of the internal interfaces
+	// after the first poll, as long as they are all zero.
+	InternalZeroStatsSkip bool
+	// DpMasksExpectedMax is the expected maximum number of masks of a
+	// datapath, which ovs_dp_masks_usage_ratio is relative to. 0 disables
+	// the ratio.
+	DpMasksExpectedMax int
+	// OvnControllerEnabled enables collecting the engine statistics and
+	// the installed northbound configuration of ovn-controller.
+	OvnControllerEnabled bool
+	// OvnCtZonesEnabled enables counting the conntrack connections of the
+	// zones assigned by ovn-controller to logical entities with ovs-appctl
+	// dpctl/dump-conntrack.
+	OvnCtZonesEnabled bool
+	// OvnLogicalFlowsEnabled enables counting the OpenFlow flows of the
+	// integration bridge by logical datapath, mapping their cookies to the
+	// logical flows of the southbound database. It requires
+	// OvnSouthboundSocket.
+	OvnLogicalFlowsEnabled bool
+	// VlogModulesEnabled enables exporting the log level of each logging
+	// module of the OVS daemons, in addition to the number of modules at
+	// each level.
+	VlogModulesEnabled bool
+	// OvnMemoryTargets maps the names of OVN daemons, e.g. ovnsb_db, to
+	// their control sockets whose memory/show counters are exported.
+	OvnMemoryTargets map[string]string
+	// LogDedupWindow is the window within which repeated warnings and
+	// errors are not logged again. 0 disables the deduplication.
+	LogDedupWindow time.Duration
+	// Registry is the registry the build information of the exporter is
+	// registered with, e.g. prometheus.DefaultRegisterer. When nil, it is
+	// not registered. The exporter itself is registered by the caller.
+	Registry prometheus.Registerer
+	// SystemID pins the system_id label of the metrics, keeping the series
+	// of the host continuous when the system-id of OVS changes. When empty,
+	// the system-id of OVS is used.
+	SystemID string
+	// HostnameLabel leaves the hostname label out of ovs_info, because the
+	// caller adds it to all series, e.g. with prometheus.WrapRegistererWith
+	// and the hostname returned by Hostname().
+	HostnameLabel bool
 }
 
 // NewLogger returns an instance of logger.
@@ -594,10 +1538,75 @@ func NewExporter(opts Options) *Exporter {
 	client.Timeout = opts.Timeout
 	e.Client = client
 	e.logger = opts.Logger
+	if opts.Logger != nil && opts.LogDedupWindow > 0 {
+		e.logger = newDedupLogger(opts.Logger, opts.LogDedupWindow, &e.stats.suppressedLogs)
+	}
+	e.databaseFilePaths = opts.DatabaseFilePaths
+	e.pinnedSystemID = opts.SystemID
+	e.hostnameLabel = opts.HostnameLabel
+	e.pmdOverload = newPmdOverloadTracker(opts.PmdOverloadThreshold, opts.PmdOverloadPolls)
+	e.commandWrappers = opts.CommandWrappers
+	e.megaflowAgeEnabled = opts.MegaflowAgeEnabled
+	e.flowOffloadEnabled = opts.FlowOffloadEnabled
+	e.ovsdbProbeEnabled = opts.OvsdbProbeEnabled
+	e.vswitchdProbeEnabled = opts.VswitchdProbeEnabled
+	e.metersEnabled = opts.MetersEnabled
+	e.ofprotoEnabled = opts.OfprotoEnabled
+	e.tnlNeighEnabled = opts.TunnelNeighEnabled
+	e.bondsEnabled = opts.BondsEnabled
+	e.vlogEnabled = opts.VlogEnabled
+	e.dpdkLogEnabled = opts.DpdkLogEnabled
+	e.ovnControllerEnabled = opts.OvnControllerEnabled
+	e.ovnCtZonesEnabled = opts.OvnCtZonesEnabled
+	e.logicalFlowsEnabled = opts.OvnLogicalFlowsEnabled
+	e.ovnMemoryTargets = opts.OvnMemoryTargets
+	e.vlogModulesEnabled = opts.VlogModulesEnabled
+	e.keyAllowlists = opts.KeyAllowlists
+	e.dpdkLogLevels = opts.DpdkLogLevels
+	e.controllerRttEnabled = opts.ControllerRttEnabled
+	e.sysfsPath = opts.SysfsPath
+	e.kernelIntfEnabled = opts.KernelInterfaceEnabled
+	e.ovnNorthbound = newOvnDatabase("OVN_Northbound", opts.OvnNorthboundSocket)
+	e.ovnSouthbound = newOvnDatabase("OVN_Southbound", opts.OvnSouthboundSocket)
+	e.metricTimestamps = opts.MetricTimestamps
+	e.labelValueReplacement = opts.LabelValueReplacement
+	e.redactKeys = opts.RedactKeys
+	e.interfaceStats = newInterfaceStats(opts.InterfaceStats)
+	e.maxInterfaces = opts.MaxInterfaces
+	e.interfaceConsistency = newInterfaceConsistencyTracker(opts.InterfaceInconsistencyPolls)
+	e.counterSanity = newCounterSanityChecker()
+	if len(opts.Counter32Keys) > 0 {
+		e.counterWraps = newCounterWrapTracker(opts.Counter32Keys)
+	}
+	e.dpMasksExpectedMax = opts.DpMasksExpectedMax
+	if opts.InternalZeroStatsSkip {
+		e.internalZeroStats = newInternalZeroStatsTracker()
+	}
+	e.coverageFullPolls = opts.CoverageFullPolls
+	if opts.CoverageRatesEnabled {
+		e.coverageRates = newCoverageRateTracker()
+	}
+	e.ovsdbProbeDuration = newProbeDurationHistogram(
+		"ovsdb", "probe_duration_seconds",
+		"The duration of a read transaction performed against ovsdb-server on each poll.",
+	)
+	e.vswitchdProbeDuration = newProbeDurationHistogram(
+		"vswitchd", "probe_duration_seconds",
+		"The duration of the version unixctl command sent to ovs-vswitchd on each poll.",
+	)
+	if opts.Registry != nil {
+		e.registerBuildInfo(opts.Registry)
+	}
 	return &e
 }
 
 func (e *Exporter) Connect() error {
+	remote, err := clientRemote(e.Client.Database.Vswitch.Socket.Remote)
+	if err != nil {
+		return err
+	}
+	e.Client.Database.Vswitch.Socket.Remote = remote
+
 	// Try to get system ID from database first, then fallback to file
 	if err := e.GetSystemID(); err != nil {
 		// Log the error but continue - we'll use "unknown" as system ID
@@ -622,7 +1631,7 @@ func (e *Exporter) Connect() error {
 		"system_id", e.Client.System.ID,
 	)
 
-	if err := e.Client.GetSystemInfo(); err != nil {
+	if err := e.getSystemInfo(); err != nil {
 		level.Warn(e.logger).Log(
 			"msg", "Error occured during GetSystemInfo()",
 			"error", err.Error(),
@@ -630,6 +1639,13 @@ func (e *Exporter) Connect() error {
 		)
 	}
 
+	level.Debug(e.logger).Log(
+		"msg", "NewExporter() calls loadSchemaFeatures()",
+		"system_id", e.Client.System.ID,
+	)
+
+	e.loadSchemaFeatures()
+
 	level.Debug(e.logger).Log(
 		"msg", "NewExporter() initialized successfully",
 		"system_id", e.Client.System.ID,
@@ -641,30 +1657,143 @@ func (e *Exporter) Connect() error {
 // implements prometheus.Collector.
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- up
-	ch <- info
+	if e.hostnameLabel {
+		ch <- infoWithoutHostname
+	} else {
+		ch <- info
+	}
 	ch <- requestErrors
 	ch <- requestsTotal
 	ch <- nextPoll
+	ch <- schemaFeatureInfo
+	ch <- schemaSupported
+	ch <- collectInFlight
+	ch <- lockWaitSeconds
+	ch <- pollCacheHits
+	ch <- pollCacheMisses
+	ch <- dataAgeSeconds
+	ch <- suspectSamples
+	ch <- logMessagesSuppressed
+	ch <- systemIDChanges
+	ch <- collectorPanics
+	ch <- collectorLastError
+	ch <- parseUnmatchedLines
+	ch <- ovnNbGlobalNbCfg
+	ch <- ovnNbGlobalSbCfg
+	ch <- ovnNbGlobalHvCfg
+	ch <- ovnNbCfgLag
+	ch <- ovnNbCfgPropagation
+	ch <- ovnSbGlobalNbCfg
+	ch <- ovnChassisLogicalPorts
+	ch <- ovnChassisUnboundPorts
+	ch <- ovnChassisUnboundPort
+	ch <- ovnChassisCtZones
+	ch <- ovnChassisCtZoneConnections
+	ch <- ovnChassisLogicalDatapathFlows
+	ch <- ovnControllerEngineRuns
+	ch <- ovnControllerLastRecompute
+	ch <- ovnControllerNbCfg
+	ch <- ovnControllerNbCfgTimestamp
+	ch <- ovnControllerSbConnected
+	ch <- ovnControllerSbProbeInterval
+	ch <- ovnControllerReconnectEvents
+	ch <- ovnDbConnectionInactivityProbe
 	ch <- pid
+	ch <- componentRestarts
 	ch <- logFileSize
 	ch <- dbFileSize
 	ch <- logEventStat
 	ch <- networkPortUp
+	ch <- ovsdbDatabaseInfo
+	ch <- ovsdbDatabaseConnected
+	ch <- ovsdbDatabaseLeader
+	ch <- ovsdbDatabaseIndex
+	ch <- ovsdbDatabaseRelayLag
+	ch <- ovsdbDatabaseFileSize
+	ch <- ovsdbTransactions
+	ch <- openflowMessages
+	ch <- revalidations
+	ch <- datapathFlowOperations
+	ch <- datapathFlowOperationFailures
+	ch <- recirculationFailures
+	ch <- ovsdbMonitors
+	ch <- ovsdbSessions
+	ch <- ovsdbJSONCaches
+	ch <- ovsdbTriggers
+	ch <- ovsdbBacklog
+	ch <- ovsdbTxnHistory
+	ch <- ovsdbRaftBacklog
+	ch <- vswitchdConfigCurCfg
+	ch <- vswitchdConfigNextCfg
+	ch <- vswitchdConfigLagging
+	ch <- vswitchdConfigSeqnoPending
+	ch <- vswitchdConfigPendingDuration
+	ch <- vswitchdConfigLastChange
 	ch <- covAvg
 	ch <- covTotal
+	ch <- covRate
 	ch <- memUsage
 	ch <- dpInterface
+	ch <- dpPort
+	ch <- patchPortPeer
 	ch <- dpBridgeInterfaceTotal
 	ch <- dpLookupsHit
 	ch <- dpFlowsTotal
+	ch <- dpFlowAge
+	ch <- dpFlowUnused
+	ch <- dpOffloadFlows
+	ch <- dpOffloadFlowPackets
+	ch <- dpOffloadFlowBytes
+	ch <- bondActiveSlaveChanges
+	ch <- bondActiveSlaveInfo
+	ch <- tunnelNeighborEntries
+	ch <- systemStatisticsCPUs
+	ch <- systemStatisticsLoadAverage
+	ch <- systemStatisticsMemory
+	ch <- systemStatisticsProcessVirtualMemory
+	ch <- systemStatisticsProcessResidentMemory
+	ch <- systemStatisticsProcessCPUTime
+	ch <- systemStatisticsProcessCrashes
+	ch <- systemStatisticsProcessUptime
+	ch <- kernelModuleInfo
+	ch <- kernelUserspaceVersionMismatch
+	ch <- bridgeOpenFlowProtocol
+	ch <- ofprotoInfo
+	ch <- bridgeSamplingEnabled
+	ch <- bridgeIpfixSampledPackets
+	ch <- bridgeIpfixSentPackets
+	ch <- bridgeIpfixErrors
+	ch <- bridgeControllerRtt
+	ch <- dpdkLogLevel
+	ch <- dpdkLogLevelDrift
+	ch <- vlogModules
+	ch <- vlogModuleLevel
+	ch <- meterFlows
+	ch <- meterPacketsIn
+	ch <- meterBytesIn
+	ch <- meterBandRate
+	ch <- meterBandPackets
+	ch <- meterBandBytes
 	ch <- dpLookupsMissed
 	ch <- dpMasksHit
 	ch <- dpMasksTotal
 	ch <- dpMasksHitRatio
+	ch <- dpMasksChurn
+	ch <- dpMasksUsage
 	ch <- dpLookupsLost
+	ch <- ovsdbTableRows
+	ch <- inventoryBridges
+	ch <- inventoryPorts
+	ch <- inventoryInterfaces
+	ch <- interfacesAdded
+	ch <- interfacesRemoved
+	ch <- interfaceStatisticsAge
+	ch <- statsUpdateInterval
+	ch <- interfacesTruncated
 	ch <- interfaceMain
 	ch <- interfaceAdminState
 	ch <- interfaceLinkState
+	ch <- interfaceInconsistent
 	ch <- interfaceIngressPolicingBurst
 	ch <- interfaceIngressPolicingRate
 	ch <- interfaceMacInUse
@@ -687,11 +1816,31 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- interfaceStatTxErrorsTotal
 	ch <- interfaceStatCollisions
 	ch <- interfaceLinkResets
+	ch <- interfaceDpdkLinkTransitions
 	ch <- interfaceLinkSpeed
+	ch <- interfaceKernelCarrierChanges
+	ch <- interfaceKernelOperState
+	ch <- interfaceKernelLinkSpeed
 	ch <- interfaceStatusKeyValuePair
 	ch <- interfaceOptionsKeyValuePair
 	ch <- interfaceExternalIdKeyValuePair
 	ch <- interfaceStateMulticastPackets
+	ch <- interfaceAfxdpRxDropped
+	ch <- interfaceAfxdpRxInvalidDescs
+	ch <- interfaceAfxdpTxInvalidDescs
+	ch <- interfaceAfxdpRxRingFull
+	ch <- interfaceAfxdpRxFillRingEmptyDescs
+	ch <- interfaceAfxdpTxRingEmptyDescs
+	ch <- afxdpEvents
+	ch <- netlinkSocketEvents
+	ch <- interfaceStatWraps
+	ch <- interfaceVhostGuestNotifications
+	ch <- interfaceVhostInterruptMode
+	ch <- interfaceDpdkRequestedRxQueues
+	ch <- interfaceDpdkQueues
+	ch <- interfaceDpdkQueueDescriptors
+	ch <- interfaceDpdkRxSteering
+	ch <- interfaceDpdkRxSteeringQueue
 	// PMD Performance Metrics
 	ch <- pmdCyclesPerIteration
 	ch <- pmdPacketsPerIteration
@@ -700,15 +1849,22 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- pmdMaxVhostQueueLength
 	ch <- pmdUpcalls
 	ch <- pmdUpcallCycles
+	ch <- pmdUpcallDuration
 	ch <- vhostTxRetries
 	ch <- vhostTxContention
 	ch <- vhostTxIrqs
 	ch <- pmdIterations
 	ch <- pmdBusyCycles
 	// Enhanced PMD Metrics
+	ch <- pmdThreadInfo
+	ch <- pmdRxQueues
+	ch <- pmdNumaRxQueues
 	ch <- pmdCPUUtilization
+	ch <- pmdOverloaded
 	ch <- pmdIdleCycles
 	ch <- pmdSleepIterations
+	ch <- pmdPhaseCycles
+	ch <- pmdSleepSeconds
 	ch <- pmdRxBatches
 	ch <- pmdRxPackets
 	ch <- pmdAvgRxBatchSize
@@ -725,6 +1881,7 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- pmdSuspiciousIterations
 	ch <- pmdSuspiciousPercent
 	ch <- datapathDrops
+	ch <- datapathDropsByCategory
 	// Flow Cache Performance Metrics
 	ch <- emcHitRate
 	ch <- emcHits
@@ -735,11 +1892,21 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- megaflowHits
 	ch <- megaflowMisses
 	ch <- flowCacheLookups
+	ch <- pmdPartialOffloadHits
+	ch <- dpCacheDistribution
+	ch <- flowCacheEmcInsertInvProb
+	ch <- flowCacheSmcEnabled
+	ch <- datapathTypeSupported
+	ch <- interfaceTypeSupported
+	e.describeInterfaceStats(ch)
+	e.ovsdbProbeDuration.Describe(ch)
+	e.vswitchdProbeDuration.Describe(ch)
 }
 
 // IncrementErrorCounter increases the counter of failed queries
 // to OVN server.
 func (e *Exporter) IncrementErrorCounter() {
+	e.countCollectorError()
 	e.errorsLocker.Lock()
 	defer e.errorsLocker.Unlock()
 	atomic.AddInt64(&e.errors, 1)
@@ -755,50 +1922,61 @@ func (e *Exporter) IncrementRequestCounter() {
 
 // Collect implements prometheus.Collector.
 func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
-	e.GatherMetrics()
+	e.stats.inFlight.Add(1)
+	defer e.stats.inFlight.Add(-1)
+
+	if e.streaming() {
+		e.gatherMetrics(ch)
+		e.RLock()
+		defer e.RUnlock()
+		e.collectSelfMetrics(ch)
+		return
+	}
+
+	if e.backgroundCollection.Load() {
+		e.stats.cacheHits.Add(1)
+	} else {
+		e.GatherMetrics()
+	}
 
 	level.Debug(e.logger).Log(
 		"msg", "Collect() calls RLock()",
 		"system_id", e.Client.System.ID,
 	)
 
+	lockStart := time.Now()
 	e.RLock()
+	e.stats.observeLockWait(lockStart)
 	defer e.RUnlock()
+	defer e.collectSelfMetrics(ch)
 	if len(e.metrics) == 0 {
 		level.Debug(e.logger).Log(
 			"msg", "Collect() no metrics found",
 			"system_id", e.Client.System.ID,
 		)
 
-		ch <- prometheus.MustNewConstMetric(
+		ch <- e.newConstMetric(
 			up,
 			prometheus.GaugeValue,
 			0,
 		)
-		ch <- prometheus.MustNewConstMetric(
-			info,
-			prometheus.GaugeValue,
-			1,
-			e.Client.System.ID, e.Client.System.RunDir, e.Client.System.Hostname,
-			e.Client.System.Type, e.Client.System.Version,
-			e.Client.Database.Vswitch.Version, e.Client.Database.Vswitch.Schema.Version,
-		)
-		ch <- prometheus.MustNewConstMetric(
+		ch <- e.newInfoMetric()
+		ch <- e.newConstMetric(
 			requestErrors,
 			prometheus.CounterValue,
 			float64(e.errors),
 			e.Client.System.ID,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		ch <- e.newConstMetric(
 			requestsTotal,
 			prometheus.CounterValue,
 			float64(e.totalRequests),
 			e.Client.System.ID,
 		)
-		ch <- prometheus.MustNewConstMetric(
+		ch <- e.newConstMetric(
 			nextPoll,
 			prometheus.GaugeValue,
-			float64(e.nextCollectionTicker),
+			float64(atomic.LoadInt64(&e.nextCollectionTicker)),
 			e.Client.System.ID,
 		)
 		return
@@ -811,27 +1989,74 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	)
 
 	for _, m := range e.metrics {
+		if e.metricTimestamps {
+			m = prometheus.NewMetricWithTimestamp(e.lastCollection, m)
+		}
 		ch <- m
 	}
 }
 
+// streaming returns whether the metrics are sent to the registry as they
+// are collected instead of being stored. Without a poll interval every
+// scrape triggers a collection, so there is no need for a snapshot, which
+// holds all metrics in memory at once, e.g. on hosts with 10k interfaces.
+func (e *Exporter) streaming() bool {
+	return e.pollInterval <= 0 && !e.backgroundCollection.Load()
+}
+
+// emit stores the metric in the snapshot served by Collect, or sends it
+// to the registry when streaming.
+func (e *Exporter) emit(m prometheus.Metric) {
+	if e.stream == nil {
+		e.metrics = append(e.metrics, m)
+		return
+	}
+	if e.metricTimestamps {
+		m = prometheus.NewMetricWithTimestamp(e.lastCollection, m)
+	}
+	e.stream <- m
+}
+
 // GatherMetrics collect data from OVN server and stores them
 // as Prometheus metrics.
 func (e *Exporter) GatherMetrics() {
+	e.gatherMetrics(nil)
+}
+
+// gatherMetrics collects the metrics. When ch is not nil, they are sent to
+// ch instead of being stored.
+func (e *Exporter) gatherMetrics(ch chan<- prometheus.Metric) {
 	level.Debug(e.logger).Log(
 		"msg", "GatherMetrics() called",
 		"system_id", e.Client.System.ID,
 	)
 
-	if time.Now().Unix() < e.nextCollectionTicker {
+	if time.Now().Unix() < atomic.LoadInt64(&e.nextCollectionTicker) {
+		e.stats.cacheHits.Add(1)
 		return
 	}
+	lockStart := time.Now()
 	e.Lock()
+	e.stats.observeLockWait(lockStart)
 	level.Debug(e.logger).Log(
 		"msg", "GatherMetrics() locked",
 		"system_id", e.Client.System.ID,
 	)
 	defer e.Unlock()
+	// Concurrent scrapes wait for the lock while the first one collects,
+	// and then serve its metrics instead of collecting again.
+	if time.Now().Unix() < e.nextCollectionTicker {
+		e.stats.cacheHits.Add(1)
+		return
+	}
+	e.stats.cacheMisses.Add(1)
+	if ch != nil {
+		// Release the snapshot of an earlier collection, e.g. forced with
+		// POST /-/collect.
+		e.metrics = nil
+		e.stream = ch
+		defer func() { e.stream = nil }()
+	}
 	if len(e.metrics) > 0 {
 		e.metrics = e.metrics[:0]
 		level.Debug(e.logger).Log(
@@ -839,11 +2064,72 @@ func (e *Exporter) GatherMetrics() {
 			"system_id", e.Client.System.ID,
 		)
 	}
-	upValue := 1
+	e.lastCollection = time.Now()
+	e.pendingSnapshot = &MetricsSnapshot{Time: e.lastCollection}
+	e.upValue = 1
+
+	for _, c := range collectorRegistry {
+		if c.collect == nil || !c.isEnabled(e) {
+			continue
+		}
+		e.startCollector(c.name)
+		level.Debug(e.logger).Log(
+			"msg", "GatherMetrics() runs a collector",
+			"collector", c.name,
+			"system_id", e.Client.System.ID,
+		)
+		e.runCollector(func() { c.collect(e) })
+		level.Debug(e.logger).Log(
+			"msg", "GatherMetrics() completed a collector",
+			"collector", c.name,
+			"system_id", e.Client.System.ID,
+		)
+	}
+	e.stopCollector()
+
+	e.emit(e.newConstMetric(
+		up,
+		prometheus.GaugeValue,
+		float64(e.upValue),
+	))
+
+	e.emit(e.newInfoMetric())
+
+	e.emit(e.newConstMetric(
+		requestErrors,
+		prometheus.CounterValue,
+		float64(e.errors),
+		e.Client.System.ID,
+	))
+
+	e.emit(e.newConstMetric(
+		requestsTotal,
+		prometheus.CounterValue,
+		float64(e.totalRequests),
+		e.Client.System.ID,
+	))
+
+	e.publishSnapshot(e.upValue == 1)
 
-	var err error
+	e.emit(e.newConstMetric(
+		nextPoll,
+		prometheus.GaugeValue,
+		float64(e.nextCollectionTicker),
+		e.Client.System.ID,
+	))
+
+	atomic.StoreInt64(&e.nextCollectionTicker, time.Now().Add(time.Duration(e.pollInterval)*time.Second).Unix())
 
-	err = e.Client.GetSystemInfo()
+	level.Debug(e.logger).Log(
+		"msg", "GatherMetrics() returns",
+		"system_id", e.Client.System.ID,
+	)
+}
+
+// collectSystemInfo reads the system information of the Open_vSwitch
+// table. The exporter is down when it fails.
+func (e *Exporter) collectSystemInfo() {
+	err := e.getSystemInfo()
 	if err != nil {
 		level.Warn(e.logger).Log(
 			"msg", "GetSystemInfo() failed",
@@ -851,8 +2137,8 @@ func (e *Exporter) GatherMetrics() {
 			"system_id", e.Client.System.ID,
 			"error", err.Error(),
 		)
-		e.IncrementErrorCounter()
-		upValue = 0
+		e.recordCollectorError("GetSystemInfo() failed", err)
+		e.upValue = 0
 	} else {
 		level.Debug(e.logger).Log(
 			"msg", "GetSystemInfo() successful",
@@ -860,7 +2146,11 @@ func (e *Exporter) GatherMetrics() {
 			"system_id", e.Client.System.ID,
 		)
 	}
+}
 
+// collectProcessMetrics exports the process IDs of the OVS daemons. The
+// exporter is down when one of them is not running.
+func (e *Exporter) collectProcessMetrics() {
 	components := []string{
 		"ovsdb-server",
 		"ovs-vswitchd",
@@ -880,10 +2170,13 @@ func (e *Exporter) GatherMetrics() {
 				"system_id", e.Client.System.ID,
 				"error", err.Error(),
 			)
-			e.IncrementErrorCounter()
-			upValue = 0
+			e.recordCollectorError("GetProcessInfo() failed", err)
+			e.upValue = 0
+		}
+		if component == "ovs-vswitchd" && err == nil {
+			e.vswitchdPid = p.ID
 		}
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pid,
 			prometheus.GaugeValue,
 			float64(p.ID),
@@ -898,8 +2191,13 @@ func (e *Exporter) GatherMetrics() {
 			"system_id", e.Client.System.ID,
 		)
 	}
+	e.collectComponentRestartMetrics()
+}
 
-	components = []string{
+// collectLogMetrics exports the size of the log files of the OVS daemons
+// and the number of their events by severity and source.
+func (e *Exporter) collectLogMetrics() {
+	components := []string{
 		"ovsdb-server",
 		"ovs-vswitchd",
 	}
@@ -919,7 +2217,7 @@ func (e *Exporter) GatherMetrics() {
 				"system_id", e.Client.System.ID,
 				"error", err.Error(),
 			)
-			e.IncrementErrorCounter()
+			e.recordCollectorError("GetLogFileInfo() failed", err)
 			continue
 		}
 		level.Debug(e.logger).Log(
@@ -928,7 +2226,7 @@ func (e *Exporter) GatherMetrics() {
 			"system_id", e.Client.System.ID,
 		)
 
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			logFileSize,
 			prometheus.GaugeValue,
 			float64(file.Info.Size()),
@@ -951,7 +2249,7 @@ func (e *Exporter) GatherMetrics() {
 				"system_id", e.Client.System.ID,
 				"error", err.Error(),
 			)
-			e.IncrementErrorCounter()
+			e.recordCollectorError("GetLogFileEventStats() failed", err)
 			continue
 		}
 
@@ -963,7 +2261,7 @@ func (e *Exporter) GatherMetrics() {
 
 		for sev, sources := range eventStats {
 			for source, count := range sources {
-				e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+				e.emit(e.newConstMetric(
 					logEventStat,
 					prometheus.GaugeValue,
 					float64(count),
@@ -975,247 +2273,212 @@ func (e *Exporter) GatherMetrics() {
 			}
 		}
 	}
+}
 
-	components = []string{
+// collectAppctlMetrics exports the coverage counters, the memory usage and
+// the datapaths of the OVS daemons, depending on the commands they support.
+// The memory and datapath parts are recorded as collectors of their own.
+func (e *Exporter) collectAppctlMetrics() {
+	components := []string{
 		"ovsdb-server",
 		"vswitchd-service",
 	}
 
 	for _, component := range components {
-		level.Debug(e.logger).Log(
-			"msg", "GatherMetrics() calls AppListCommands()",
-			"component", component,
-			"system_id", e.Client.System.ID,
-		)
-
-		if cmds, err := e.Client.AppListCommands(component); err != nil {
-			level.Error(e.logger).Log(
-				"msg", "AppListCommands() failed",
-				"component", component,
-				"system_id", e.Client.System.ID,
-				"error", err.Error(),
-			)
-			e.IncrementErrorCounter()
+		e.runCollector(func() {
+			// The failures to list the commands are reported by the coverage
+			// collector.
+			e.startCollector("coverage")
 			level.Debug(e.logger).Log(
-				"msg", "GatherMetrics() completed AppListCommands()",
+				"msg", "GatherMetrics() calls AppListCommands()",
 				"component", component,
 				"system_id", e.Client.System.ID,
 			)
-		} else {
-			level.Debug(e.logger).Log(
-				"msg", "GatherMetrics() completed AppListCommands()",
-				"component", component,
-				"system_id", e.Client.System.ID,
-			)
-			if cmds["coverage/show"] {
-				level.Debug(e.logger).Log(
-					"msg", "GatherMetrics() calls GetAppCoverageMetrics()",
+
+			if cmds, err := e.Client.AppListCommands(component); err != nil {
+				level.Error(e.logger).Log(
+					"msg", "AppListCommands() failed",
 					"component", component,
 					"system_id", e.Client.System.ID,
+					"error", err.Error(),
 				)
-
-				if metrics, err := e.Client.GetAppCoverageMetrics(component); err != nil {
-					level.Error(e.logger).Log(
-						"msg", "GetAppCoverageMetrics() failed",
-						"component", component,
-						"system_id", e.Client.System.ID,
-						"error", err.Error(),
-					)
-					e.IncrementErrorCounter()
-				} else {
-					for event, metric := range metrics {
-						for period, value := range metric {
-							if period == "total" {
-								e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-									covTotal,
-									prometheus.CounterValue,
-									value,
-									e.Client.System.ID,
-									component,
-									event,
-								))
-							} else {
-								e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-									covAvg,
-									prometheus.GaugeValue,
-									value,
-									e.Client.System.ID,
-									component,
-									event,
-									period,
-								))
-							}
-						}
-					}
-				}
+				e.recordCollectorError("AppListCommands() failed", err)
 				level.Debug(e.logger).Log(
-					"msg", "GatherMetrics() completed GetAppCoverageMetrics()",
+					"msg", "GatherMetrics() completed AppListCommands()",
 					"component", component,
 					"system_id", e.Client.System.ID,
 				)
-			}
-			if cmds["memory/show"] {
+			} else {
 				level.Debug(e.logger).Log(
-					"msg", "GatherMetrics() calls GetAppMemoryMetrics()",
+					"msg", "GatherMetrics() completed AppListCommands()",
 					"component", component,
 					"system_id", e.Client.System.ID,
 				)
-				if metrics, err := e.Client.GetAppMemoryMetrics(component); err != nil {
-					level.Error(e.logger).Log(
-						"msg", "GetAppMemoryMetrics() failed",
+				if cmds["coverage/show"] {
+					level.Debug(e.logger).Log(
+						"msg", "GatherMetrics() calls GetAppCoverageMetrics()",
 						"component", component,
 						"system_id", e.Client.System.ID,
-						"error", err.Error(),
 					)
-					e.IncrementErrorCounter()
-				} else {
-					for facility, value := range metrics {
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							memUsage,
-							prometheus.GaugeValue,
-							value,
-							e.Client.System.ID,
-							component,
-							facility,
-						))
+
+					if metrics, cached, err := e.getCoverageMetrics(component); err != nil {
+						level.Error(e.logger).Log(
+							"msg", "GetAppCoverageMetrics() failed",
+							"component", component,
+							"system_id", e.Client.System.ID,
+							"error", err.Error(),
+						)
+						e.recordCollectorError("GetAppCoverageMetrics() failed", err)
+					} else {
+						now := time.Now()
+						for event, metric := range metrics {
+							for period, value := range metric {
+								if period == "total" {
+									e.emit(e.newConstMetric(
+										covTotal,
+										prometheus.CounterValue,
+										value,
+										e.Client.System.ID,
+										component,
+										event,
+									))
+									if e.coverageRates == nil {
+										continue
+									}
+									key := component + "/" + event
+									var rate float64
+									var ok bool
+									if cached {
+										// The rates of the cached counters are
+										// the ones of the last refresh.
+										rate, ok = e.coverageRates.last(key)
+									} else {
+										rate, ok = e.coverageRates.observe(key, value, now)
+									}
+									if ok {
+										e.emit(e.newConstMetric(
+											covRate,
+											prometheus.GaugeValue,
+											rate,
+											e.Client.System.ID,
+											component,
+											event,
+										))
+									}
+								} else {
+									e.emit(e.newConstMetric(
+										covAvg,
+										prometheus.GaugeValue,
+										value,
+										e.Client.System.ID,
+										component,
+										event,
+										period,
+									))
+								}
+							}
+						}
+						e.collectCoverageEvents(component, metrics)
 					}
+					level.Debug(e.logger).Log(
+						"msg", "GatherMetrics() completed GetAppCoverageMetrics()",
+						"component", component,
+						"system_id", e.Client.System.ID,
+					)
 				}
-				level.Debug(e.logger).Log(
-					"msg", "GatherMetrics() completed GetAppMemoryMetrics()",
-					"component", component,
-					"system_id", e.Client.System.ID,
-				)
-			}
-			if cmds["dpif/show"] && (component == "vswitchd-service") {
-				level.Debug(e.logger).Log(
-					"msg", "GatherMetrics() calls GetAppDatapath()",
-					"component", component,
-					"system_id", e.Client.System.ID,
-				)
-
-				if dps, brs, intfs, err := e.Client.GetAppDatapath(component); err != nil {
-					level.Error(e.logger).Log(
-						"msg", "GetAppDatapath() failed",
+				if cmds["memory/show"] {
+					e.startCollector("memory")
+					level.Debug(e.logger).Log(
+						"msg", "GatherMetrics() calls GetAppMemoryMetrics()",
 						"component", component,
 						"system_id", e.Client.System.ID,
-						"error", err.Error(),
 					)
-					e.IncrementErrorCounter()
-				} else {
-					for _, dp := range dps {
-						dpIntefaceCount := 0
-						for _, br := range brs {
-							if dp.Name != br.DatapathName {
-								continue
-							}
-							brIntefaceCount := 0
-							for _, intf := range intfs {
-								if dp.Name != intf.DatapathName || br.Name != intf.BridgeName {
-									continue
-								}
-								dpIntefaceCount += 1
-								brIntefaceCount += 1
-								e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-									dpInterface,
-									prometheus.GaugeValue,
-									1,
-									e.Client.System.ID,
-									dp.Name,
-									br.Name,
-									intf.Name,
-									fmt.Sprintf("%0.f", intf.OfPort),
-									fmt.Sprintf("%0.f", intf.Index),
-									intf.Type,
-								))
-							}
-							// Calculate the total number of interfaces per datapath
-							e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-								dpBridgeInterfaceTotal,
+					if metrics, err := e.Client.GetAppMemoryMetrics(component); err != nil {
+						level.Error(e.logger).Log(
+							"msg", "GetAppMemoryMetrics() failed",
+							"component", component,
+							"system_id", e.Client.System.ID,
+							"error", err.Error(),
+						)
+						e.recordCollectorError("GetAppMemoryMetrics() failed", err)
+					} else {
+						for facility, value := range metrics {
+							e.emit(e.newConstMetric(
+								memUsage,
 								prometheus.GaugeValue,
-								float64(brIntefaceCount),
+								value,
 								e.Client.System.ID,
-								dp.Name,
-								br.Name,
+								component,
+								facility,
 							))
 						}
-						// Add datapath hits and misses
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpLookupsHit,
-							prometheus.CounterValue,
-							dp.Lookups.Hit,
-							e.Client.System.ID,
-							dp.Name,
-						))
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpLookupsMissed,
-							prometheus.CounterValue,
-							dp.Lookups.Missed,
-							e.Client.System.ID,
-							dp.Name,
-						))
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpLookupsLost,
-							prometheus.CounterValue,
-							dp.Lookups.Lost,
-							e.Client.System.ID,
-							dp.Name,
-						))
-						// Add datapath flows
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpFlowsTotal,
-							prometheus.GaugeValue,
-							dp.Flows,
-							e.Client.System.ID,
-							dp.Name,
-						))
-						// Add datapath masks
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpMasksHit,
-							prometheus.CounterValue,
-							dp.Masks.Hit,
-							e.Client.System.ID,
-							dp.Name,
-						))
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpMasksTotal,
-							prometheus.CounterValue,
-							dp.Masks.Total,
-							e.Client.System.ID,
-							dp.Name,
-						))
-						e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-							dpMasksHitRatio,
-							prometheus.GaugeValue,
-							dp.Masks.HitRatio,
-							e.Client.System.ID,
-							dp.Name,
-						))
+						if component == "ovsdb-server" {
+							e.collectOvsdbMemoryFacilities(metrics)
+						}
 					}
+					level.Debug(e.logger).Log(
+						"msg", "GatherMetrics() completed GetAppMemoryMetrics()",
+						"component", component,
+						"system_id", e.Client.System.ID,
+					)
+				}
+				if cmds["dpif/show"] && (component == "vswitchd-service") {
+					e.startCollector("datapath")
+					level.Debug(e.logger).Log(
+						"msg", "GatherMetrics() calls GetAppDatapath()",
+						"component", component,
+						"system_id", e.Client.System.ID,
+					)
+
+					e.collectDatapathMetrics(component)
+					level.Debug(e.logger).Log(
+						"msg", "GatherMetrics() completed GetAppDatapath()",
+						"component", component,
+						"system_id", e.Client.System.ID,
+					)
 				}
-				level.Debug(e.logger).Log(
-					"msg", "GatherMetrics() completed GetAppDatapath()",
-					"component", component,
-					"system_id", e.Client.System.ID,
-				)
 			}
-		}
+		})
 	}
+}
 
-	level.Debug(e.logger).Log(
-		"msg", "GatherMetrics() calls GetDbInterfaces()",
-		"system_id", e.Client.System.ID,
-	)
-
-	if intfs, err := e.Client.GetDbInterfaces(); err != nil {
+// collectInterfaceMetrics exports the metrics of the rows of the Interface
+// table.
+func (e *Exporter) collectInterfaceMetrics() {
+	if intfs, uuids, truncated, err := e.GetInterfaces(); err != nil {
 		level.Error(e.logger).Log(
-			"msg", "GetDbInterfaces() failed",
+			"msg", "GetInterfaces() failed",
 			"system_id", e.Client.System.ID,
 			"error", err.Error(),
 		)
-		e.IncrementErrorCounter()
+		e.recordCollectorError("GetInterfaces() failed", err)
 	} else {
+		if truncated > 0 {
+			level.Warn(e.logger).Log(
+				"msg", "the number of interfaces exceeds the limit",
+				"system_id", e.Client.System.ID,
+				"limit", e.maxInterfaces,
+				"truncated", truncated,
+			)
+		}
+		e.pendingSnapshot.Interfaces = intfs
+		e.emit(e.newConstMetric(
+			interfacesTruncated,
+			prometheus.GaugeValue,
+			float64(truncated),
+			e.Client.System.ID,
+		))
+		e.counterSanity.begin(e.vswitchdPid)
+		if e.counterWraps != nil {
+			e.counterWraps.begin(e.vswitchdPid)
+		}
+		if e.internalZeroStats != nil {
+			e.internalZeroStats.begin()
+		}
+		e.vhostInterrupt.begin()
+		e.dpdkLink.begin()
 		for _, intf := range intfs {
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceMain,
 				prometheus.GaugeValue,
 				1,
@@ -1233,7 +2496,7 @@ func (e *Exporter) GatherMetrics() {
 			default:
 				adminState = 2
 			}
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceAdminState,
 				prometheus.GaugeValue,
 				adminState,
@@ -1250,7 +2513,7 @@ func (e *Exporter) GatherMetrics() {
 			default:
 				linkState = 2
 			}
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceLinkState,
 				prometheus.GaugeValue,
 				linkState,
@@ -1258,7 +2521,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceIngressPolicingBurst,
 				prometheus.GaugeValue,
 				intf.IngressPolicingBurst,
@@ -1266,7 +2529,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceIngressPolicingRate,
 				prometheus.GaugeValue,
 				intf.IngressPolicingRate,
@@ -1274,7 +2537,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceMacInUse,
 				prometheus.GaugeValue,
 				1,
@@ -1283,7 +2546,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.MacInUse,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceMtu,
 				prometheus.GaugeValue,
 				intf.Mtu,
@@ -1292,7 +2555,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.Name,
 			))
 			var linkDuplex float64
-			switch intf.Duplex {
+			switch interfaceDuplexValue(intf.Duplex, intf.Status) {
 			case "half":
 				linkDuplex = 1
 			case "full":
@@ -1300,7 +2563,7 @@ func (e *Exporter) GatherMetrics() {
 			default:
 				linkDuplex = 0
 			}
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceDuplex,
 				prometheus.GaugeValue,
 				linkDuplex,
@@ -1308,7 +2571,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceOfPort,
 				prometheus.GaugeValue,
 				intf.OfPort,
@@ -1316,7 +2579,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceIfIndex,
 				prometheus.GaugeValue,
 				intf.IfIndex,
@@ -1324,7 +2587,7 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceLocalIndex,
 				prometheus.GaugeValue,
 				intf.Index,
@@ -1332,135 +2595,30 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			for key, value := range intf.Statistics {
-				switch key {
-				case "rx_crc_err":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxCrcError,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_dropped":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxDropped,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_frame_err":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxFrameError,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_over_err":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxOverrunError,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_errors":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxErrorsTotal,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_packets":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxPackets,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_bytes":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxBytes,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "tx_packets":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatTxPackets,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "tx_bytes":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatTxBytes,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "tx_dropped":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatTxDropped,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "tx_errors":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatTxErrorsTotal,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "collisions":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatCollisions,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_missed_errors":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStatRxMissedErrors,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				case "rx_multicast_packets":
-					e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-						interfaceStateMulticastPackets,
-						prometheus.CounterValue,
-						float64(value),
-						e.Client.System.ID,
-						intf.UUID,
-						intf.Name,
-					))
-				default:
+			var notifications float64
+			var hasNotifications bool
+			statistics := intf.Statistics
+			if !e.hasSchemaFeature("interface_statistics") {
+				statistics = nil
+			} else if e.internalZeroStats != nil && e.internalZeroStats.skip(intf.UUID, intf.Type, statistics) {
+				statistics = nil
+			}
+			for key, value := range statistics {
+				labels := []string{e.Client.System.ID, intf.UUID, intf.Name}
+				stat, exists := e.interfaceStats[key]
+				if !exists {
+					var queue string
+					if stat, queue, exists = parseAfxdpStatKey(key); exists {
+						labels = append(labels, queue)
+					}
+				}
+				if !exists {
+					var queue, direction string
+					if stat, queue, direction, exists = parseVhostNotificationKey(key); exists {
+						labels = append(labels, queue, direction)
+					}
+				}
+				if !exists {
 					level.Debug(e.logger).Log(
 						"msg", "detected malformed interface statistics",
 						"system_id", e.Client.System.ID,
@@ -1468,9 +2626,52 @@ func (e *Exporter) GatherMetrics() {
 						"value", value,
 						"error", "OVS interface statistics has unsupported key",
 					)
+					continue
+				}
+				sample := float64(value)
+				if stat.valueType == prometheus.CounterValue && e.counterWraps != nil && e.counterWraps.matches(key) {
+					var wraps float64
+					sample, wraps = e.counterWraps.unwrap(intf.UUID+"/"+key, sample)
+					e.emit(e.newConstMetric(
+						interfaceStatWraps,
+						prometheus.CounterValue,
+						wraps,
+						e.Client.System.ID,
+						intf.UUID,
+						intf.Name,
+						key,
+					))
+				}
+				if stat.valueType == prometheus.CounterValue {
+					var suspect bool
+					if sample, suspect = e.counterSanity.check(intf.UUID+"/"+key, sample); suspect {
+						e.stats.suspectSamples.Add(1)
+						level.Debug(e.logger).Log(
+							"msg", "interface statistics counter decreased",
+							"system_id", e.Client.System.ID,
+							"uuid", intf.UUID,
+							"name", intf.Name,
+							"key", key,
+							"value", value,
+							"previous", sample,
+						)
+					}
+				}
+				if stat.desc == interfaceVhostGuestNotifications {
+					notifications += sample
+					hasNotifications = true
 				}
+				e.emit(e.newConstMetric(
+					stat.desc,
+					stat.valueType,
+					sample,
+					labels...,
+				))
+			}
+			if hasNotifications {
+				e.collectVhostInterruptMode(intf.UUID, intf.Name, notifications)
 			}
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceLinkResets,
 				prometheus.CounterValue,
 				intf.LinkResets,
@@ -1478,62 +2679,88 @@ func (e *Exporter) GatherMetrics() {
 				intf.UUID,
 				intf.Name,
 			))
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				interfaceLinkSpeed,
 				prometheus.GaugeValue,
-				intf.LinkSpeed,
+				interfaceLinkSpeedValue(intf.LinkSpeed, intf.Status),
 				e.Client.System.ID,
 				intf.UUID,
 				intf.Name,
 			))
+			if intf.Type == "dpdk" {
+				e.collectDpdkQueueMetrics(intf.UUID, intf.Name, intf.Options, intf.Status)
+				e.collectDpdkRxSteeringMetrics(intf.UUID, intf.Name, intf.Options, intf.Status)
+				e.collectDpdkLinkMetrics(intf.UUID, intf.Name, intf.LinkState)
+			}
 			for key, value := range intf.Status {
-				e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+				if !e.isKeyAllowed("status", key) {
+					continue
+				}
+				e.emit(e.newConstMetric(
 					interfaceStatusKeyValuePair,
 					prometheus.GaugeValue,
 					1,
 					e.Client.System.ID,
 					intf.UUID,
 					key,
-					value,
+					e.keyValue(key, value),
 					intf.Name,
 				))
 			}
 			for key, value := range intf.Options {
-				e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+				if !e.isKeyAllowed("options", key) {
+					continue
+				}
+				e.emit(e.newConstMetric(
 					interfaceOptionsKeyValuePair,
 					prometheus.GaugeValue,
 					1,
 					e.Client.System.ID,
 					intf.UUID,
 					key,
-					value,
+					e.keyValue(key, value),
 					intf.Name,
 				))
 			}
 			for key, value := range intf.ExternalIDs {
-				e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+				if !e.isKeyAllowed("external_ids", key) {
+					continue
+				}
+				e.emit(e.newConstMetric(
 					interfaceExternalIdKeyValuePair,
 					prometheus.GaugeValue,
 					1,
 					e.Client.System.ID,
 					intf.UUID,
 					key,
-					value,
+					e.keyValue(key, value),
 					intf.Name,
 				))
 			}
 		}
+		e.counterSanity.end()
+		if e.counterWraps != nil {
+			e.counterWraps.end()
+		}
+		if e.internalZeroStats != nil {
+			e.internalZeroStats.end()
+		}
+		e.vhostInterrupt.end()
+		e.dpdkLink.end()
+		e.collectInterfaceConsistencyMetrics(intfs)
+		e.collectInterfaceChurnMetrics(uuids)
+		if e.hasSchemaFeature("interface_statistics") {
+			e.collectInterfaceStatsAgeMetrics(intfs)
+		}
 	}
+}
 
-	level.Debug(e.logger).Log(
-		"msg", "GatherMetrics() completed GetDbInterfaces()",
-		"system_id", e.Client.System.ID,
-	)
-
-	components = []string{
+// collectNetworkPortMetrics exports whether the default and SSL ports of
+// ovsdb-server are listening.
+func (e *Exporter) collectNetworkPortMetrics() {
+	components := []string{
 		"ovsdb-server",
 	}
-
 	for _, component := range components {
 		level.Debug(e.logger).Log(
 			"msg", "GatherMetrics() calls IsDefaultPortUp()",
@@ -1548,9 +2775,9 @@ func (e *Exporter) GatherMetrics() {
 				"system_id", e.Client.System.ID,
 				"error", err.Error(),
 			)
-			e.IncrementErrorCounter()
+			e.recordCollectorError("IsDefaultPortUp() failed", err)
 		}
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			networkPortUp,
 			prometheus.GaugeValue,
 			float64(defaultPortUp),
@@ -1577,9 +2804,9 @@ func (e *Exporter) GatherMetrics() {
 				"system_id", e.Client.System.ID,
 				"error", err.Error(),
 			)
-			e.IncrementErrorCounter()
+			e.recordCollectorError("IsSslPortUp() failed", err)
 		}
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			networkPortUp,
 			prometheus.GaugeValue,
 			float64(sslPortUp),
@@ -1593,56 +2820,11 @@ func (e *Exporter) GatherMetrics() {
 			"system_id", e.Client.System.ID,
 		)
 	}
-
-	e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-		up,
-		prometheus.GaugeValue,
-		float64(upValue),
-	))
-
-	e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-		info,
-		prometheus.GaugeValue,
-		1,
-		e.Client.System.ID, e.Client.System.RunDir, e.Client.System.Hostname,
-		e.Client.System.Type, e.Client.System.Version,
-		e.Client.Database.Vswitch.Version, e.Client.Database.Vswitch.Schema.Version,
-	))
-
-	e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-		requestErrors,
-		prometheus.CounterValue,
-		float64(e.errors),
-		e.Client.System.ID,
-	))
-
-	e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-		requestsTotal,
-		prometheus.CounterValue,
-		float64(e.totalRequests),
-		e.Client.System.ID,
-	))
-
-	// Collect PMD Performance Metrics (for DPDK deployments)
-	e.CollectPMDMetrics()
-
-	e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
-		nextPoll,
-		prometheus.GaugeValue,
-		float64(e.nextCollectionTicker),
-		e.Client.System.ID,
-	))
-
-	e.nextCollectionTicker = time.Now().Add(time.Duration(e.pollInterval) * time.Second).Unix()
-
-	level.Debug(e.logger).Log(
-		"msg", "GatherMetrics() returns",
-		"system_id", e.Client.System.ID,
-	)
 }
 
-func init() {
-	// Register version info as a metric
+// registerBuildInfo registers the build information of the exporter with
+// the registry. It is registered once by the exporters sharing a registry.
+func (e *Exporter) registerBuildInfo(registry prometheus.Registerer) {
 	versionInfo := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace + "_exporter",
@@ -1651,8 +2833,15 @@ func init() {
 		},
 		[]string{"version", "revision", "branch", "goversion"},
 	)
-	prometheus.MustRegister(versionInfo)
 	versionInfo.WithLabelValues(version.Version, version.Revision, version.Branch, version.GoVersion).Set(1)
+	if err := registry.Register(versionInfo); err != nil {
+		if _, registered := err.(prometheus.AlreadyRegisteredError); !registered {
+			level.Error(e.logger).Log(
+				"msg", "failed to register build information",
+				"error", err.Error(),
+			)
+		}
+	}
 }
 
 // GetVersionInfo returns exporter info.
@@ -0,0 +1,248 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OvsdbDatabaseInfo represents a row of the Database table of the
+// _Server database exposed by ovsdb-server.
+type OvsdbDatabaseInfo struct {
+	Name      string
+	Model     string
+	Connected bool
+	Leader    bool
+	ClusterID string
+	ServerID  string
+	Index     int64
+}
+
+// ParseDatabaseFilePaths parses a comma-separated list of NAME=PATH pairs
+// mapping OVSDB database names to their database files.
+func ParseDatabaseFilePaths(s string) (map[string]string, error) {
+	paths := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("malformed database file path entry %q, expected NAME=PATH", entry)
+		}
+		paths[kv[0]] = kv[1]
+	}
+	return paths, nil
+}
+
+// GetOvsdbDatabases returns the databases served by ovsdb-server together
+// with their storage model and clustering details.
+func (e *Exporter) GetOvsdbDatabases() ([]OvsdbDatabaseInfo, error) {
+	query := "SELECT name, model, connected, leader, cid, sid, index FROM Database"
+	result, err := e.Client.Database.Vswitch.Client.Transact("_Server", query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseOvsdbDatabases(result), nil
+}
+
+// parseOvsdbDatabases converts the rows of the _Server Database table.
+func parseOvsdbDatabases(result ovsdb.Result) []OvsdbDatabaseInfo {
+	var dbs []OvsdbDatabaseInfo
+	for _, row := range result.Rows {
+		db := OvsdbDatabaseInfo{}
+		if r, dt, err := row.GetColumnValue("name", result.Columns); err != nil || dt != "string" {
+			continue
+		} else {
+			db.Name = r.(string)
+		}
+		if r, dt, err := row.GetColumnValue("model", result.Columns); err == nil && dt == "string" {
+			db.Model = r.(string)
+		}
+		if r, dt, err := row.GetColumnValue("connected", result.Columns); err == nil && dt == "bool" {
+			db.Connected = r.(bool)
+		}
+		if r, dt, err := row.GetColumnValue("leader", result.Columns); err == nil && dt == "bool" {
+			db.Leader = r.(bool)
+		}
+		if r, dt, err := row.GetColumnValue("cid", result.Columns); err == nil && dt == "string" {
+			db.ClusterID = r.(string)
+		}
+		if r, dt, err := row.GetColumnValue("sid", result.Columns); err == nil && dt == "string" {
+			db.ServerID = r.(string)
+		}
+		if r, dt, err := row.GetColumnValue("index", result.Columns); err == nil && dt == "integer" {
+			db.Index = r.(int64)
+		}
+		dbs = append(dbs, db)
+	}
+	sort.Slice(dbs, func(i, j int) bool { return dbs[i].Name < dbs[j].Name })
+	return dbs
+}
+
+// relayLagTracker records when the relay databases were last connected to
+// their source.
+type relayLagTracker struct {
+	lastConnected map[string]time.Time
+}
+
+// observe records the connection state of a relay database and returns
+// the seconds since it was last connected, false until it has been
+// observed connected.
+func (t *relayLagTracker) observe(name string, connected bool, now time.Time) (float64, bool) {
+	if t.lastConnected == nil {
+		t.lastConnected = make(map[string]time.Time)
+	}
+	if connected {
+		t.lastConnected[name] = now
+		return 0, true
+	}
+	last, exists := t.lastConnected[name]
+	if !exists {
+		return 0, false
+	}
+	return now.Sub(last).Seconds(), true
+}
+
+// collectDatabaseMetrics collects the metrics of all databases served by
+// the ovsdb-server, including the sizes of their database files.
+func (e *Exporter) collectDatabaseMetrics() {
+	var dbs []OvsdbDatabaseInfo
+	if e.hasSchemaFeature("server_databases") {
+		var err error
+		e.IncrementRequestCounter()
+		dbs, err = e.GetOvsdbDatabases()
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetOvsdbDatabases() failed",
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetOvsdbDatabases() failed", err)
+		}
+	}
+
+	now := time.Now()
+	for _, db := range dbs {
+		e.emit(e.newConstMetric(
+			ovsdbDatabaseInfo,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID,
+			db.Name,
+			db.Model,
+			db.ClusterID,
+			db.ServerID,
+		))
+		var connected, leader float64
+		if db.Connected {
+			connected = 1
+		}
+		if db.Leader {
+			leader = 1
+		}
+		e.emit(e.newConstMetric(
+			ovsdbDatabaseConnected,
+			prometheus.GaugeValue,
+			connected,
+			e.Client.System.ID,
+			db.Name,
+		))
+		e.emit(e.newConstMetric(
+			ovsdbDatabaseLeader,
+			prometheus.GaugeValue,
+			leader,
+			e.Client.System.ID,
+			db.Name,
+		))
+		switch db.Model {
+		case "clustered":
+			// The index is only maintained for clustered databases.
+			e.emit(e.newConstMetric(
+				ovsdbDatabaseIndex,
+				prometheus.GaugeValue,
+				float64(db.Index),
+				e.Client.System.ID,
+				db.Name,
+			))
+		case "relay":
+			if lag, known := e.relayLag.observe(db.Name, db.Connected, now); known {
+				e.emit(e.newConstMetric(
+					ovsdbDatabaseRelayLag,
+					prometheus.GaugeValue,
+					lag,
+					e.Client.System.ID,
+					db.Name,
+				))
+			}
+		}
+	}
+
+	// The Open_vSwitch database file belongs to the ovsdb-server component,
+	// like its log file.
+	if path := e.Client.Database.Vswitch.File.Data.Path; path != "" {
+		if fi, err := os.Stat(path); err != nil {
+			level.Debug(e.logger).Log(
+				"msg", "failed to stat database file",
+				"database", e.Client.Database.Vswitch.Name,
+				"filename", path,
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+		} else {
+			e.emit(e.newConstMetric(
+				dbFileSize,
+				prometheus.GaugeValue,
+				float64(fi.Size()),
+				e.Client.System.ID,
+				"ovsdb-server",
+				path,
+			))
+		}
+	}
+	for name, path := range e.databaseFilePaths {
+		if name == e.Client.Database.Vswitch.Name {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			level.Debug(e.logger).Log(
+				"msg", "failed to stat database file",
+				"database", name,
+				"filename", path,
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+			continue
+		}
+		e.emit(e.newConstMetric(
+			ovsdbDatabaseFileSize,
+			prometheus.GaugeValue,
+			float64(fi.Size()),
+			e.Client.System.ID,
+			name,
+			path,
+		))
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestParseOvsdbDatabases(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{
+			"name":      "string",
+			"model":     "string",
+			"connected": "boolean",
+			"leader":    "boolean",
+			"cid":       "uuid",
+			"sid":       "uuid",
+			"index":     "integer",
+		},
+		Rows: []ovsdb.Row{
+			{
+				"name":      "Open_vSwitch",
+				"model":     "standalone",
+				"connected": true,
+				"leader":    true,
+				"cid":       []interface{}{"set", []interface{}{}},
+				"sid":       []interface{}{"set", []interface{}{}},
+				"index":     []interface{}{"set", []interface{}{}},
+			},
+			{
+				"name":      "OVN_Northbound",
+				"model":     "clustered",
+				"connected": true,
+				"leader":    false,
+				"cid":       []interface{}{"uuid", "0e2f5c2d-8d1e-4c3a-9f3b-6f4b1b7a1c11"},
+				"sid":       []interface{}{"uuid", "7a1b0c55-2c7e-4b7b-8a53-0c5f4a6e9d22"},
+				"index":     float64(4242),
+			},
+		},
+	}
+
+	dbs := parseOvsdbDatabases(result)
+	if len(dbs) != 2 {
+		t.Fatalf("Expected 2 databases, got %d", len(dbs))
+	}
+
+	nb := dbs[0]
+	if nb.Name != "OVN_Northbound" {
+		t.Fatalf("Expected databases sorted by name, got %s first", nb.Name)
+	}
+	if nb.Model != "clustered" || !nb.Connected || nb.Leader {
+		t.Errorf("Unexpected OVN_Northbound state: %+v", nb)
+	}
+	if nb.ClusterID != "0e2f5c2d-8d1e-4c3a-9f3b-6f4b1b7a1c11" {
+		t.Errorf("Expected cid to be parsed, got %q", nb.ClusterID)
+	}
+	if nb.ServerID != "7a1b0c55-2c7e-4b7b-8a53-0c5f4a6e9d22" {
+		t.Errorf("Expected sid to be parsed, got %q", nb.ServerID)
+	}
+	if nb.Index != 4242 {
+		t.Errorf("Expected Index=4242, got %d", nb.Index)
+	}
+
+	vs := dbs[1]
+	if vs.Model != "standalone" || vs.ClusterID != "" || vs.Index != 0 {
+		t.Errorf("Unexpected Open_vSwitch state: %+v", vs)
+	}
+}
+
+func TestParseDatabaseFilePaths(t *testing.T) {
+	paths, err := ParseDatabaseFilePaths("OVN_Northbound=/var/lib/ovn/ovnnb_db.db, OVN_Southbound=/var/lib/ovn/ovnsb_db.db")
+	if err != nil {
+		t.Fatalf("ParseDatabaseFilePaths() returned error: %v", err)
+	}
+	if len(paths) != 2 || paths["OVN_Southbound"] != "/var/lib/ovn/ovnsb_db.db" {
+		t.Errorf("Unexpected paths: %v", paths)
+	}
+
+	if paths, err := ParseDatabaseFilePaths(""); err != nil || len(paths) != 0 {
+		t.Errorf("Expected empty map for empty input, got %v, %v", paths, err)
+	}
+
+	if _, err := ParseDatabaseFilePaths("OVN_Northbound"); err == nil {
+		t.Error("ParseDatabaseFilePaths() should return error for malformed entry")
+	}
+}
+
+func TestRelayLagTracker(t *testing.T) {
+	var tracker relayLagTracker
+	start := time.Unix(1700000000, 0)
+
+	if _, known := tracker.observe("OVN_Southbound", false, start); known {
+		t.Error("Expected no lag before the relay was observed connected")
+	}
+	if lag, known := tracker.observe("OVN_Southbound", true, start); !known || lag != 0 {
+		t.Errorf("Expected no lag while connected, got %v (known=%v)", lag, known)
+	}
+	if lag, known := tracker.observe("OVN_Southbound", false, start.Add(30*time.Second)); !known || lag != 30 {
+		t.Errorf("Expected a lag of 30s after the disconnection, got %v (known=%v)", lag, known)
+	}
+	if lag, _ := tracker.observe("OVN_Southbound", true, start.Add(45*time.Second)); lag != 0 {
+		t.Errorf("Expected the lag to reset on reconnection, got %v", lag)
+	}
+}
+
+func TestCollectDatabaseFileSizes(t *testing.T) {
+	dir := t.TempDir()
+	vswitch := filepath.Join(dir, "conf.db")
+	nb := filepath.Join(dir, "ovnnb_db.db")
+	if err := os.WriteFile(vswitch, make([]byte, 10), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(nb, make([]byte, 20), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	e := &Exporter{
+		Client: ovsdb.NewOvsClient(),
+		logger: log.NewNopLogger(),
+		// No server_databases feature, so that _Server is not queried.
+		schemaFeatures: map[string]bool{},
+	}
+	e.Client.Database.Vswitch.File.Data.Path = vswitch
+	e.databaseFilePaths = map[string]string{
+		e.Client.Database.Vswitch.Name: vswitch,
+		"OVN_Northbound":               nb,
+	}
+
+	e.collectDatabaseMetrics()
+
+	var component, additional int
+	for _, m := range e.metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+		switch m.Desc() {
+		case dbFileSize:
+			component++
+			if pb.GetGauge().GetValue() != 10 {
+				t.Errorf("Expected the size of the Open_vSwitch database file, got %v", pb.GetGauge().GetValue())
+			}
+		case ovsdbDatabaseFileSize:
+			additional++
+			if pb.GetGauge().GetValue() != 20 {
+				t.Errorf("Expected the size of the OVN_Northbound database file, got %v", pb.GetGauge().GetValue())
+			}
+		}
+	}
+	if component != 1 || additional != 1 {
+		t.Errorf("Expected the Open_vSwitch database file once and one additional file, got %d and %d", component, additional)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ovsdbMemoryFacility is the metric of a facility reported by the
+// memory/show command of ovsdb-server.
+type ovsdbMemoryFacility struct {
+	desc  *prometheus.Desc
+	scale float64
+}
+
+// ovsdbMemoryFacilities maps the memory/show facilities of ovsdb-server to
+// dedicated metrics. Facilities reported in kB are scaled to bytes.
+var ovsdbMemoryFacilities = map[string]ovsdbMemoryFacility{
+	"monitors":        {ovsdbMonitors, 1},
+	"sessions":        {ovsdbSessions, 1},
+	"json-caches":     {ovsdbJSONCaches, 1},
+	"triggers":        {ovsdbTriggers, 1},
+	"backlog":         {ovsdbBacklog, 1},
+	"txn-history":     {ovsdbTxnHistory, 1},
+	"raft-backlog-kB": {ovsdbRaftBacklog, 1024},
+}
+
+// collectOvsdbMemoryFacilities exports the facilities of the memory/show
+// output of ovsdb-server with dedicated metrics. All facilities remain
+// available as ovs_memory_usage_bytes.
+func (e *Exporter) collectOvsdbMemoryFacilities(metrics map[string]float64) {
+	for name, value := range metrics {
+		facility, exists := ovsdbMemoryFacilities[name]
+		if !exists {
+			continue
+		}
+		e.emit(e.newConstMetric(
+			facility.desc,
+			prometheus.GaugeValue,
+			value*facility.scale,
+			e.Client.System.ID,
+		))
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollectOvsdbMemoryFacilities(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.collectOvsdbMemoryFacilities(map[string]float64{
+		"atoms":           12345,
+		"cells":           23456,
+		"monitors":        4,
+		"sessions":        3,
+		"raft-backlog-kB": 2,
+	})
+
+	values := make(map[string]float64)
+	for _, m := range e.metrics {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatal(err)
+		}
+		values[m.Desc().String()] = pb.GetGauge().GetValue()
+	}
+	if len(values) != 3 {
+		t.Fatalf("Expected 3 metrics, got %d", len(values))
+	}
+	if values[ovsdbMonitors.String()] != 4 || values[ovsdbSessions.String()] != 3 {
+		t.Errorf("Unexpected monitors or sessions: %v", values)
+	}
+	if values[ovsdbRaftBacklog.String()] != 2048 {
+		t.Errorf("Expected a raft backlog of 2048 bytes, got %v", values[ovsdbRaftBacklog.String()])
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"sync"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The names of the parsers of ovs-appctl output tracking the lines they
+// could not interpret.
+const (
+	pmdPerfParser         = "pmd_perf"
+	pmdPerfEnhancedParser = "pmd_perf_enhanced"
+	coverageParser        = "coverage"
+)
+
+// lineParsers are the parsers reported even before any unmatched line is
+// found, so that rate() and increase() work from the first occurrence.
+var lineParsers = []string{pmdPerfParser, pmdPerfEnhancedParser, coverageParser}
+
+// unmatchedLineStats counts, per parser, the lines of command output that
+// did not match any of the expected patterns. A change in the output format
+// of OVS otherwise results in silently missing metrics.
+type unmatchedLineStats struct {
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// add increments the number of unmatched lines of the parser.
+func (s *unmatchedLineStats) add(parser string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[string]uint64)
+	}
+	s.counts[parser] += uint64(n)
+}
+
+// snapshot returns a copy of the counts, including the known parsers
+// without unmatched lines.
+func (s *unmatchedLineStats) snapshot() map[string]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]uint64, len(lineParsers))
+	for _, parser := range lineParsers {
+		counts[parser] = 0
+	}
+	for parser, n := range s.counts {
+		counts[parser] = n
+	}
+	return counts
+}
+
+// recordUnmatchedLines accounts the lines a parser could not interpret and
+// logs the first of them as a sample.
+func (e *Exporter) recordUnmatchedLines(parser string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	e.stats.unmatchedLines.add(parser, len(lines))
+	level.Debug(e.logger).Log(
+		"msg", "parser encountered unmatched lines",
+		"system_id", e.Client.System.ID,
+		"parser", parser,
+		"count", len(lines),
+		"sample", lines[0],
+	)
+}
+
+// collectParseMetrics sends the number of unmatched lines per parser.
+func (e *Exporter) collectParseMetrics(ch chan<- prometheus.Metric) {
+	for parser, n := range e.stats.unmatchedLines.snapshot() {
+		ch <- e.newConstMetric(
+			parseUnmatchedLines,
+			prometheus.CounterValue,
+			float64(n),
+			e.Client.System.ID, parser,
+		)
+	}
+}
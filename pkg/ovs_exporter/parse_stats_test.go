@@ -0,0 +1,75 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+)
+
+func TestScanPmdPerfOutputUnmatched(t *testing.T) {
+	output := `Time: 13:54:40.655
+Measurement duration: 1.004 s
+
+pmd thread numa_id 0 core_id 2:
+  iterations:        12345678 (123.45 us/it)
+  Rx packets:        1000 (1 Kpps, 848 cycles/pkt)
+
+  vhost tx irqs:     123`
+
+	metrics, unmatched, err := scanPmdPerfOutput(output)
+	if err != nil {
+		t.Fatalf("scanPmdPerfOutput() returned error: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Iterations != 12345678 || metrics[0].TxIrqs != 123 {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+	if len(unmatched) != 1 || unmatched[0] != "  Rx packets:        1000 (1 Kpps, 848 cycles/pkt)" {
+		t.Errorf("Expected only the Rx packets line to be unmatched, got %q", unmatched)
+	}
+}
+
+func TestScanEnhancedPmdOutputUnmatched(t *testing.T) {
+	output := `pmd thread numa_id 0 core_id 2:
+  iterations:        100 (1.00 us/it)
+  cycles histogram:
+    0-1000: 5
+  unknown counter:   7`
+
+	metrics, unmatched := scanEnhancedPmdOutput(output)
+	if len(metrics) != 1 || metrics[0].CyclesHistogram["0-1000"] != 5 {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+	if len(unmatched) != 1 || unmatched[0] != "  unknown counter:   7" {
+		t.Errorf("Expected only the unknown counter line to be unmatched, got %q", unmatched)
+	}
+}
+
+func TestRecordUnmatchedLines(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+	e.recordUnmatchedLines(coverageParser, []string{"a", "b"})
+	e.recordUnmatchedLines(coverageParser, []string{"c"})
+	e.recordUnmatchedLines(pmdPerfParser, nil)
+
+	counts := e.stats.unmatchedLines.snapshot()
+	if counts[coverageParser] != 3 {
+		t.Errorf("Expected 3 unmatched coverage lines, got %d", counts[coverageParser])
+	}
+	if n, exists := counts[pmdPerfParser]; !exists || n != 0 {
+		t.Errorf("Expected the pmd_perf parser to be reported with 0 lines, got %d (exists: %v)", n, exists)
+	}
+}
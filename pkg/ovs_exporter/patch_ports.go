@@ -0,0 +1,182 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PatchPort is a patch port connecting two bridges, e.g. the integration
+// bridge of OVN to a provider bridge, with the port named by its
+// options:peer. The bridge of the peer is empty when the peer does not
+// exist.
+type PatchPort struct {
+	Bridge     string
+	Port       string
+	PeerBridge string
+	PeerPort   string
+}
+
+// GetPatchPorts returns the patch ports of all bridges.
+func (e *Exporter) GetPatchPorts() ([]PatchPort, error) {
+	var results [3]ovsdb.Result
+	for i, query := range []string{
+		"SELECT _uuid, name, type, options FROM Interface",
+		"SELECT _uuid, name, interfaces FROM Port",
+		"SELECT name, ports FROM Bridge",
+	} {
+		result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+		if err != nil {
+			return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+		}
+		results[i] = result
+	}
+	return parsePatchPorts(results[0], results[1], results[2]), nil
+}
+
+// parsePatchPorts resolves the ports and bridges of the patch interfaces
+// and of their peers from the rows of the Interface, Port and Bridge
+// tables.
+func parsePatchPorts(interfaces, ports, bridges ovsdb.Result) []PatchPort {
+	// The ports of the interfaces and the bridges of the ports, by uuid.
+	interfacePorts := make(map[string]string)
+	interfacePortUUIDs := make(map[string]string)
+	for _, row := range ports.Rows {
+		name, dt, err := row.GetColumnValue("name", ports.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		uuid, dt, err := row.GetColumnValue("_uuid", ports.Columns)
+		hasUUID := err == nil && dt == "string"
+		for _, intf := range rowUUIDs(row, "interfaces", ports.Columns) {
+			interfacePorts[intf] = name.(string)
+			if hasUUID {
+				interfacePortUUIDs[intf] = uuid.(string)
+			}
+		}
+	}
+	bridgeNames := make(map[string]string)
+	for _, row := range bridges.Rows {
+		name, dt, err := row.GetColumnValue("name", bridges.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		for _, port := range rowUUIDs(row, "ports", bridges.Columns) {
+			bridgeNames[port] = name.(string)
+		}
+	}
+
+	// The bridge and port of the interfaces, by interface name.
+	type location struct{ bridge, port string }
+	locations := make(map[string]location)
+	peers := make(map[string]string)
+	for _, row := range interfaces.Rows {
+		uuid, dt, err := row.GetColumnValue("_uuid", interfaces.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		name, dt, err := row.GetColumnValue("name", interfaces.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		port, exists := interfacePorts[uuid.(string)]
+		if !exists {
+			continue
+		}
+		locations[name.(string)] = location{bridgeNames[interfacePortUUIDs[uuid.(string)]], port}
+		if t, _, err := row.GetColumnValue("type", interfaces.Columns); err != nil || t != "patch" {
+			continue
+		}
+		r, _, err := row.GetColumnValue("options", interfaces.Columns)
+		if err != nil {
+			continue
+		}
+		// An empty map is returned as an empty set.
+		if options, ok := r.(map[string]string); ok {
+			peers[name.(string)] = options["peer"]
+		}
+	}
+
+	var patchPorts []PatchPort
+	for name, peer := range peers {
+		local := locations[name]
+		remote, exists := locations[peer]
+		if !exists {
+			remote.port = peer
+		}
+		patchPorts = append(patchPorts, PatchPort{
+			Bridge:     local.bridge,
+			Port:       local.port,
+			PeerBridge: remote.bridge,
+			PeerPort:   remote.port,
+		})
+	}
+	sort.Slice(patchPorts, func(i, j int) bool {
+		if patchPorts[i].Bridge != patchPorts[j].Bridge {
+			return patchPorts[i].Bridge < patchPorts[j].Bridge
+		}
+		return patchPorts[i].Port < patchPorts[j].Port
+	})
+	return patchPorts
+}
+
+// rowUUIDs returns the uuids of a set column. A set with a single uuid is
+// returned as a string.
+func rowUUIDs(row ovsdb.Row, column string, columns map[string]string) []string {
+	r, dt, err := row.GetColumnValue(column, columns)
+	if err != nil {
+		return nil
+	}
+	switch dt {
+	case "string":
+		return []string{r.(string)}
+	case "[]string":
+		return r.([]string)
+	}
+	return nil
+}
+
+// collectPatchPortMetrics exports the patch ports and their peers, i.e. the
+// topology of the bridges.
+func (e *Exporter) collectPatchPortMetrics() {
+	e.IncrementRequestCounter()
+	patchPorts, err := e.GetPatchPorts()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetPatchPorts() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetPatchPorts() failed", err)
+		return
+	}
+	for _, p := range patchPorts {
+		e.emit(e.newConstMetric(
+			patchPortPeer,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID,
+			p.Bridge,
+			p.Port,
+			p.PeerBridge,
+			p.PeerPort,
+		))
+	}
+}
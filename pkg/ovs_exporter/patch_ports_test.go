@@ -0,0 +1,69 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParsePatchPorts(t *testing.T) {
+	interfaces := ovsdb.Result{
+		Columns: map[string]string{"_uuid": "string", "name": "string", "type": "string", "options": "map[string]string"},
+		Rows: []ovsdb.Row{
+			{"_uuid": []interface{}{"uuid", "i1"}, "name": "patch-br-int-to-br-ex", "type": "patch",
+				"options": []interface{}{"map", []interface{}{[]interface{}{"peer", "patch-br-ex-to-br-int"}}}},
+			{"_uuid": []interface{}{"uuid", "i2"}, "name": "patch-br-ex-to-br-int", "type": "patch",
+				"options": []interface{}{"map", []interface{}{[]interface{}{"peer", "patch-br-int-to-br-ex"}}}},
+			{"_uuid": []interface{}{"uuid", "i3"}, "name": "patch-dangling", "type": "patch",
+				"options": []interface{}{"map", []interface{}{[]interface{}{"peer", "patch-missing"}}}},
+			{"_uuid": []interface{}{"uuid", "i4"}, "name": "eth0", "type": "",
+				"options": []interface{}{"set", []interface{}{}}},
+		},
+	}
+	ports := ovsdb.Result{
+		Columns: map[string]string{"_uuid": "string", "name": "string", "interfaces": "[]string"},
+		Rows: []ovsdb.Row{
+			{"_uuid": []interface{}{"uuid", "p1"}, "name": "patch-br-int-to-br-ex", "interfaces": []interface{}{"uuid", "i1"}},
+			{"_uuid": []interface{}{"uuid", "p2"}, "name": "patch-br-ex-to-br-int", "interfaces": []interface{}{"uuid", "i2"}},
+			{"_uuid": []interface{}{"uuid", "p3"}, "name": "patch-dangling", "interfaces": []interface{}{"uuid", "i3"}},
+			{"_uuid": []interface{}{"uuid", "p4"}, "name": "eth0", "interfaces": []interface{}{"uuid", "i4"}},
+		},
+	}
+	bridges := ovsdb.Result{
+		Columns: map[string]string{"name": "string", "ports": "[]string"},
+		Rows: []ovsdb.Row{
+			{"name": "br-int", "ports": []interface{}{"set", []interface{}{
+				[]interface{}{"uuid", "p1"},
+				[]interface{}{"uuid", "p3"},
+			}}},
+			{"name": "br-ex", "ports": []interface{}{"set", []interface{}{
+				[]interface{}{"uuid", "p2"},
+				[]interface{}{"uuid", "p4"},
+			}}},
+		},
+	}
+
+	expected := []PatchPort{
+		{Bridge: "br-ex", Port: "patch-br-ex-to-br-int", PeerBridge: "br-int", PeerPort: "patch-br-int-to-br-ex"},
+		{Bridge: "br-int", Port: "patch-br-int-to-br-ex", PeerBridge: "br-ex", PeerPort: "patch-br-ex-to-br-int"},
+		{Bridge: "br-int", Port: "patch-dangling", PeerBridge: "", PeerPort: "patch-missing"},
+	}
+	if patchPorts := parsePatchPorts(interfaces, ports, bridges); !reflect.DeepEqual(patchPorts, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, patchPorts)
+	}
+}
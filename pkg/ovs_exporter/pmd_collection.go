@@ -47,54 +47,95 @@ func (e *Exporter) CollectPMDMetrics() {
 		)
 		return
 	}
+	if e.pendingSnapshot != nil {
+		e.pendingSnapshot.PMDs = enhancedMetrics
+	}
 	
+	seen := make(map[string]bool)
 	for _, pmd := range enhancedMetrics {
 		// CPU Utilization (convert from percentage to ratio)
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdCPUUtilization,
 			prometheus.GaugeValue,
 			pmd.CPUUtilization / 100.0, // Convert percentage to ratio (0-1)
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID, pmd.CoreID,
 		))
 		
+		// Overload detection with hysteresis over consecutive polls
+		pmdKey := pmd.NumaID + "/" + pmd.PmdID
+		seen[pmdKey] = true
+		var overloaded float64
+		if e.pmdOverload.observe(pmdKey, pmd.CPUUtilization/100.0) {
+			overloaded = 1
+		}
+		e.emit(e.newConstMetric(
+			pmdOverloaded,
+			prometheus.GaugeValue,
+			overloaded,
+			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
+		))
+		
 		// Idle and Sleep metrics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdIdleCycles,
 			prometheus.CounterValue,
 			float64(pmd.IdleCycles),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdSleepIterations,
 			prometheus.CounterValue,
 			float64(pmd.SleepIterations),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
+		e.emit(e.newConstMetric(
+			pmdSleepSeconds,
+			prometheus.CounterValue,
+			float64(pmd.SleepMicroseconds) / 1e6,
+			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
+		))
+		
+		// Cycles per phase, distinguishing polling from processing
+		if pmd.HasPhaseCycles {
+			e.emit(e.newConstMetric(
+				pmdPhaseCycles,
+				prometheus.CounterValue,
+				float64(pmd.PollingCycles),
+				e.Client.System.ID, pmd.PmdID, pmd.NumaID, "polling",
+			))
+			e.emit(e.newConstMetric(
+				pmdPhaseCycles,
+				prometheus.CounterValue,
+				float64(pmd.ProcessingCycles),
+				e.Client.System.ID, pmd.PmdID, pmd.NumaID, "processing",
+			))
+		}
+		
 		// Core performance metrics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdCyclesPerIteration,
 			prometheus.GaugeValue,
 			pmd.CyclesPerIteration,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdPacketsPerIteration,
 			prometheus.GaugeValue,
 			pmd.PacketsPerIteration,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdCyclesPerPacket,
 			prometheus.GaugeValue,
 			pmd.CyclesPerPacket,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdPacketsPerBatch,
 			prometheus.GaugeValue,
 			pmd.PacketsPerBatch,
@@ -102,28 +143,28 @@ func (e *Exporter) CollectPMDMetrics() {
 		))
 		
 		// RX Batch Statistics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdRxBatches,
 			prometheus.CounterValue,
 			float64(pmd.RxBatches),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdRxPackets,
 			prometheus.CounterValue,
 			float64(pmd.RxPackets),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdAvgRxBatchSize,
 			prometheus.GaugeValue,
 			pmd.AvgRxBatchSize,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdMaxRxBatchSize,
 			prometheus.GaugeValue,
 			float64(pmd.MaxRxBatchSize),
@@ -131,21 +172,21 @@ func (e *Exporter) CollectPMDMetrics() {
 		))
 		
 		// TX Batch Statistics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdTxBatches,
 			prometheus.CounterValue,
 			float64(pmd.TxBatches),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdTxPackets,
 			prometheus.CounterValue,
 			float64(pmd.TxPackets),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdAvgTxBatchSize,
 			prometheus.GaugeValue,
 			pmd.AvgTxBatchSize,
@@ -153,21 +194,21 @@ func (e *Exporter) CollectPMDMetrics() {
 		))
 		
 		// vHost Queue Metrics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdMaxVhostQueueLength,
 			prometheus.GaugeValue,
 			float64(pmd.MaxVhostQueueLength),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdAvgVhostQueueLength,
 			prometheus.GaugeValue,
 			pmd.AvgVhostQueueLength,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdVhostQueueFull,
 			prometheus.CounterValue,
 			float64(pmd.VhostQueueFull),
@@ -175,36 +216,47 @@ func (e *Exporter) CollectPMDMetrics() {
 		))
 		
 		// Upcalls
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdUpcalls,
 			prometheus.CounterValue,
 			float64(pmd.Upcalls),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdUpcallCycles,
 			prometheus.CounterValue,
 			float64(pmd.UpcallCycles),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
+		if pmd.UpcallDurations != nil {
+			count, sum, buckets := pmd.UpcallDurations.seconds()
+			e.emit(e.newConstHistogram(
+				pmdUpcallDuration,
+				count,
+				sum,
+				buckets,
+				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
+			))
+		}
+		
 		// vHost TX metrics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			vhostTxRetries,
 			prometheus.CounterValue,
 			float64(pmd.VhostTxRetries),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			vhostTxContention,
 			prometheus.CounterValue,
 			float64(pmd.VhostTxContention),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			vhostTxIrqs,
 			prometheus.CounterValue,
 			float64(pmd.VhostTxIrqs),
@@ -212,14 +264,14 @@ func (e *Exporter) CollectPMDMetrics() {
 		))
 		
 		// Iterations
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdIterations,
 			prometheus.CounterValue,
 			float64(pmd.Iterations),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdBusyCycles,
 			prometheus.CounterValue,
 			float64(pmd.BusyCycles),
@@ -227,28 +279,28 @@ func (e *Exporter) CollectPMDMetrics() {
 		))
 		
 		// Hit/Miss Statistics
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdExactMatchHit,
 			prometheus.CounterValue,
 			float64(pmd.ExactMatchHit),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdMaskedHit,
 			prometheus.CounterValue,
 			float64(pmd.MaskedHit),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdMiss,
 			prometheus.CounterValue,
 			float64(pmd.Miss),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdLost,
 			prometheus.CounterValue,
 			float64(pmd.Lost),
@@ -257,14 +309,14 @@ func (e *Exporter) CollectPMDMetrics() {
 		
 		// Suspicious Iterations
 		if pmd.SuspiciousIterations > 0 {
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				pmdSuspiciousIterations,
 				prometheus.CounterValue,
 				float64(pmd.SuspiciousIterations),
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				pmdSuspiciousPercent,
 				prometheus.GaugeValue,
 				pmd.SuspiciousPercent / 100.0, // Convert percentage to ratio (0-1)
@@ -274,21 +326,21 @@ func (e *Exporter) CollectPMDMetrics() {
 		
 		// Flow Cache Metrics
 		if pmd.EMCHitRate > 0 || pmd.EMCHits > 0 {
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				emcHitRate,
 				prometheus.GaugeValue,
 				pmd.EMCHitRate / 100.0, // Convert percentage to ratio (0-1)
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 			
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				emcHits,
 				prometheus.CounterValue,
 				float64(pmd.EMCHits),
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 			
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				emcInserts,
 				prometheus.CounterValue,
 				float64(pmd.EMCInserts),
@@ -297,14 +349,14 @@ func (e *Exporter) CollectPMDMetrics() {
 		}
 		
 		if pmd.SMCHitRate > 0 || pmd.SMCHits > 0 {
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				smcHitRate,
 				prometheus.GaugeValue,
 				pmd.SMCHitRate / 100.0, // Convert percentage to ratio (0-1)
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 			
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				smcHits,
 				prometheus.CounterValue,
 				float64(pmd.SMCHits),
@@ -313,21 +365,21 @@ func (e *Exporter) CollectPMDMetrics() {
 		}
 		
 		if pmd.MegaflowHitRate > 0 || pmd.MegaflowHits > 0 || pmd.MegaflowMisses > 0 {
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				megaflowHitRate,
 				prometheus.GaugeValue,
 				pmd.MegaflowHitRate / 100.0, // Convert percentage to ratio (0-1)
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 			
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				megaflowHits,
 				prometheus.CounterValue,
 				float64(pmd.MegaflowHits),
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 			
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				megaflowMisses,
 				prometheus.CounterValue,
 				float64(pmd.MegaflowMisses),
@@ -336,15 +388,27 @@ func (e *Exporter) CollectPMDMetrics() {
 		}
 		
 		if pmd.FlowCacheLookups > 0 {
-			e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+			e.emit(e.newConstMetric(
 				flowCacheLookups,
 				prometheus.CounterValue,
 				float64(pmd.FlowCacheLookups),
 				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 			))
 		}
+		
+		if pmd.HasPartialOffload {
+			e.emit(e.newConstMetric(
+				pmdPartialOffloadHits,
+				prometheus.CounterValue,
+				float64(pmd.PartialOffloadHits),
+				e.Client.System.ID, pmd.PmdID, pmd.NumaID,
+			))
+		}
 	}
 	
+	e.pmdOverload.prune(seen)
+	e.collectCacheDistribution(enhancedMetrics)
+	
 	level.Debug(e.logger).Log(
 		"msg", "Enhanced PMD metrics collected successfully",
 		"system_id", e.Client.System.ID,
@@ -365,84 +429,84 @@ func (e *Exporter) collectBasicPMDMetrics() {
 	
 	for _, pmd := range pmdMetrics {
 		// Add basic metrics as before
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdCyclesPerIteration,
 			prometheus.GaugeValue,
 			pmd.CyclesPerIteration,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdPacketsPerIteration,
 			prometheus.GaugeValue,
 			pmd.PacketsPerIteration,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdCyclesPerPacket,
 			prometheus.GaugeValue,
 			pmd.CyclesPerPacket,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdPacketsPerBatch,
 			prometheus.GaugeValue,
 			pmd.PacketsPerBatch,
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdMaxVhostQueueLength,
 			prometheus.GaugeValue,
 			float64(pmd.MaxVhostQueueLength),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdUpcalls,
 			prometheus.CounterValue,
 			float64(pmd.Upcalls),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdUpcallCycles,
 			prometheus.CounterValue,
 			float64(pmd.UpcallCycles),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			vhostTxRetries,
 			prometheus.CounterValue,
 			float64(pmd.TxRetries),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			vhostTxContention,
 			prometheus.CounterValue,
 			float64(pmd.TxContention),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			vhostTxIrqs,
 			prometheus.CounterValue,
 			float64(pmd.TxIrqs),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdIterations,
 			prometheus.CounterValue,
 			float64(pmd.Iterations),
 			e.Client.System.ID, pmd.PmdID, pmd.NumaID,
 		))
 		
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			pmdBusyCycles,
 			prometheus.CounterValue,
 			float64(pmd.BusyCycles),
@@ -465,13 +529,21 @@ func (e *Exporter) collectDropCounters() {
 	}
 	
 	for dropReason, count := range dropCounters {
-		e.metrics = append(e.metrics, prometheus.MustNewConstMetric(
+		e.emit(e.newConstMetric(
 			datapathDrops,
 			prometheus.CounterValue,
 			float64(count),
 			e.Client.System.ID, dropReason,
 		))
 	}
+	for category, count := range summarizeDropCounters(dropCounters) {
+		e.emit(e.newConstMetric(
+			datapathDropsByCategory,
+			prometheus.CounterValue,
+			float64(count),
+			e.Client.System.ID, category,
+		))
+	}
 	
 	if len(dropCounters) > 0 {
 		level.Debug(e.logger).Log(
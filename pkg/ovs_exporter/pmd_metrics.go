@@ -17,7 +17,6 @@ package ovs_exporter
 import (
 	"bufio"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -43,7 +42,7 @@ type PmdPerformanceMetrics struct {
 
 // GetPmdPerfMetrics retrieves PMD performance metrics using ovs-appctl
 func (e *Exporter) GetPmdPerfMetrics() ([]PmdPerformanceMetrics, error) {
-	cmd := exec.Command("ovs-appctl", "dpif-netdev/pmd-perf-show")
+	cmd := e.vswitchdAppctl("dpif-netdev/pmd-perf-show")
 	output, err := cmd.Output()
 	if err != nil {
 		// Check if the command is not available (e.g., non-DPDK deployment)
@@ -54,12 +53,22 @@ func (e *Exporter) GetPmdPerfMetrics() ([]PmdPerformanceMetrics, error) {
 		return nil, fmt.Errorf("failed to execute pmd-perf-show: %w", err)
 	}
 
-	return parsePmdPerfOutput(string(output))
+	metrics, unmatched, err := scanPmdPerfOutput(string(output))
+	e.recordUnmatchedLines(pmdPerfParser, unmatched)
+	return metrics, err
 }
 
 // parsePmdPerfOutput parses the output of dpif-netdev/pmd-perf-show
 func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
+	metrics, _, err := scanPmdPerfOutput(output)
+	return metrics, err
+}
+
+// scanPmdPerfOutput parses the output of dpif-netdev/pmd-perf-show and
+// returns the lines of the PMD sections it could not interpret.
+func scanPmdPerfOutput(output string) ([]PmdPerformanceMetrics, []string, error) {
 	var metrics []PmdPerformanceMetrics
+	var unmatched []string
 	scanner := bufio.NewScanner(strings.NewReader(output))
 	
 	// Regular expressions for parsing different sections
@@ -97,8 +106,11 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 			continue
 		}
 		
+		matched := false
+		
 		// Parse iterations
 		if matches := iterationsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.Iterations = val
 			}
@@ -106,6 +118,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse busy cycles
 		if matches := busyCyclesRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[2], 64); err == nil {
 				currentMetric.BusyCycles = uint64(val * 1000000) // Convert Mcycles to cycles
 			}
@@ -113,6 +126,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse cycles per iteration
 		if matches := cyclesPerItRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.CyclesPerIteration = val
 			}
@@ -120,6 +134,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse packets per iteration
 		if matches := pktsPerItRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.PacketsPerIteration = val
 			}
@@ -127,6 +142,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse cycles per packet
 		if matches := cyclesPerPktRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.CyclesPerPacket = val
 			}
@@ -134,6 +150,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse packets per batch
 		if matches := pktsPerBatchRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.PacketsPerBatch = val
 			}
@@ -141,6 +158,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse max vhost queue length
 		if matches := maxVhostQRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.MaxVhostQueueLength = val
 			}
@@ -148,6 +166,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse upcalls
 		if matches := upcallsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.Upcalls = val
 			}
@@ -158,6 +177,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse vhost tx retries
 		if matches := txRetriesRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.TxRetries = val
 			}
@@ -165,6 +185,7 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse vhost tx contention
 		if matches := txContentionRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.TxContention = val
 			}
@@ -172,10 +193,15 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		
 		// Parse vhost tx IRQs
 		if matches := txIrqsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.TxIrqs = val
 			}
 		}
+		
+		if !matched && strings.TrimSpace(line) != "" {
+			unmatched = append(unmatched, line)
+		}
 	}
 	
 	// Add the last metric if exists
@@ -183,12 +209,12 @@ func parsePmdPerfOutput(output string) ([]PmdPerformanceMetrics, error) {
 		metrics = append(metrics, *currentMetric)
 	}
 	
-	return metrics, nil
+	return metrics, unmatched, nil
 }
 
 // GetPmdStatsMetrics retrieves PMD statistics using ovs-appctl dpif-netdev/pmd-stats-show
 func (e *Exporter) GetPmdStatsMetrics() ([]PmdPerformanceMetrics, error) {
-	cmd := exec.Command("ovs-appctl", "dpif-netdev/pmd-stats-show")
+	cmd := e.vswitchdAppctl("dpif-netdev/pmd-stats-show")
 	output, err := cmd.Output()
 	if err != nil {
 		// Check if the command is not available
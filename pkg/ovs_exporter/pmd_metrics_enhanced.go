@@ -17,7 +17,6 @@ package ovs_exporter
 import (
 	"bufio"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -40,6 +39,20 @@ type EnhancedPmdMetrics struct {
 	Iterations           uint64
 	SleepIterations     uint64
 	BusyIterations      uint64
+	SleepMicroseconds   uint64
+	
+	// Cycles per phase from pmd-stats-show, set when HasPhaseCycles
+	PollingCycles       uint64
+	ProcessingCycles    uint64
+	HasPhaseCycles      bool
+	
+	// Misses with successful or failed upcalls from pmd-stats-show
+	MissUpcalls         uint64
+	
+	// Packets matched by partial hardware offload (flow mark) from
+	// pmd-stats-show, set when HasPartialOffload
+	PartialOffloadHits  uint64
+	HasPartialOffload   bool
 	CyclesPerIteration  float64
 	UsPerIteration      float64
 	
@@ -69,6 +82,7 @@ type EnhancedPmdMetrics struct {
 	Upcalls              uint64
 	UpcallCycles         uint64
 	AvgUpcallCycles      float64
+	UpcallDurations      *pmdUpcallHistogram
 	
 	// vHost specific
 	VhostTxRetries       uint64
@@ -105,7 +119,7 @@ type EnhancedPmdMetrics struct {
 // GetEnhancedPmdMetrics retrieves comprehensive PMD metrics
 func (e *Exporter) GetEnhancedPmdMetrics() ([]EnhancedPmdMetrics, error) {
 	// First try detailed metrics
-	cmd := exec.Command("ovs-appctl", "dpif-netdev/pmd-perf-show")
+	cmd := e.vswitchdAppctl("dpif-netdev/pmd-perf-show")
 	output, err := cmd.Output()
 	if err != nil {
 		// If not available, return empty
@@ -115,10 +129,21 @@ func (e *Exporter) GetEnhancedPmdMetrics() ([]EnhancedPmdMetrics, error) {
 		return nil, fmt.Errorf("failed to execute pmd-perf-show: %w", err)
 	}
 
-	metrics := parseEnhancedPmdOutput(string(output))
+	metrics, unmatched := scanEnhancedPmdOutput(string(output))
+	histograms := parsePmdUpcallHistograms(string(output))
+	for i := range metrics {
+		metrics[i].UpcallDurations = histograms[metrics[i].NumaID+"/"+metrics[i].CoreID]
+	}
+	lines := unmatched[:0]
+	for _, line := range unmatched {
+		if !isPmdHistogramLine(line) {
+			lines = append(lines, line)
+		}
+	}
+	e.recordUnmatchedLines(pmdPerfEnhancedParser, lines)
 	
 	// Also get pmd-stats-show for additional metrics
-	statsCmd := exec.Command("ovs-appctl", "dpif-netdev/pmd-stats-show")
+	statsCmd := e.vswitchdAppctl("dpif-netdev/pmd-stats-show")
 	statsOutput, err := statsCmd.Output()
 	if err == nil {
 		enrichWithStats(metrics, string(statsOutput))
@@ -129,7 +154,15 @@ func (e *Exporter) GetEnhancedPmdMetrics() ([]EnhancedPmdMetrics, error) {
 
 // parseEnhancedPmdOutput parses comprehensive PMD performance output
 func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
+	metrics, _ := scanEnhancedPmdOutput(output)
+	return metrics
+}
+
+// scanEnhancedPmdOutput parses comprehensive PMD performance output and
+// returns the lines of the PMD sections it could not interpret.
+func scanEnhancedPmdOutput(output string) ([]EnhancedPmdMetrics, []string) {
 	var metrics []EnhancedPmdMetrics
+	var unmatched []string
 	scanner := bufio.NewScanner(strings.NewReader(output))
 	
 	// Enhanced regex patterns
@@ -141,6 +174,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 	busyCyclesRe := regexp.MustCompile(`busy cycles:\s+([\d.]+)%.*\(([\d.]+) Mcycles`)
 	iterationsRe := regexp.MustCompile(`iterations:\s+(\d+)\s+\(([\d.]+) us/it\)`)
 	sleepIterRe := regexp.MustCompile(`sleep iterations:\s+(\d+)\s+\(([\d.]+)%`)
+	sleepTimeRe := regexp.MustCompile(`Sleep time \(us\):\s+(\d+)`)
 	
 	// Packet processing patterns
 	cyclesPerItRe := regexp.MustCompile(`cycles/it:\s+([\d.]+)\s+\(([\d.]+) Mcycles\)`)
@@ -220,8 +254,11 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 			continue
 		}
 		
+		matched := false
+		
 		// Parse CPU utilization
 		if matches := cpuUtilRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.CPUUtilization = val
 			}
@@ -229,6 +266,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse idle cycles
 		if matches := idleCyclesRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[2], 64); err == nil {
 				currentMetric.IdleCycles = uint64(val * 1000000) // Convert Mcycles to cycles
 			}
@@ -236,6 +274,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse busy cycles
 		if matches := busyCyclesRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if percent, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.CPUUtilization = percent // Busy percentage is CPU utilization
 			}
@@ -246,6 +285,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse iterations
 		if matches := iterationsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.Iterations = val
 			}
@@ -256,14 +296,24 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse sleep iterations
 		if matches := sleepIterRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.SleepIterations = val
 				currentMetric.BusyIterations = currentMetric.Iterations - val
 			}
 		}
 		
+		// Parse sleep time
+		if matches := sleepTimeRe.FindStringSubmatch(line); matches != nil {
+			matched = true
+			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
+				currentMetric.SleepMicroseconds = val
+			}
+		}
+		
 		// Parse cycles per iteration
 		if matches := cyclesPerItRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.CyclesPerIteration = val
 			}
@@ -271,6 +321,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse packets per iteration
 		if matches := pktsPerItRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.PacketsPerIteration = val
 			}
@@ -278,6 +329,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse cycles per packet
 		if matches := cyclesPerPktRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.CyclesPerPacket = val
 			}
@@ -285,6 +337,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse packets per batch
 		if matches := pktsPerBatchRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.PacketsPerBatch = val
 			}
@@ -292,6 +345,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse RX batch statistics
 		if matches := rxBatchRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.RxBatches = val
 			}
@@ -305,6 +359,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse RX packets
 		if matches := rxPacketsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.RxPackets = val
 			}
@@ -312,6 +367,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse TX batch statistics
 		if matches := txBatchRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.TxBatches = val
 			}
@@ -322,6 +378,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse TX packets
 		if matches := txPacketsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.TxPackets = val
 			}
@@ -329,18 +386,21 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse vHost queue metrics
 		if matches := maxVhostQRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.MaxVhostQueueLength = val
 			}
 		}
 		
 		if matches := avgVhostQRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.AvgVhostQueueLength = val
 			}
 		}
 		
 		if matches := vhostFullRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.VhostQueueFull = val
 			}
@@ -348,6 +408,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse upcalls
 		if matches := upcallsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.Upcalls = val
 			}
@@ -357,6 +418,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		}
 		
 		if matches := avgUpcallRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.AvgUpcallCycles = val
 			}
@@ -364,18 +426,21 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse vHost TX metrics
 		if matches := txRetriesRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.VhostTxRetries = val
 			}
 		}
 		
 		if matches := txContentionRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.VhostTxContention = val
 			}
 		}
 		
 		if matches := txIrqsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.VhostTxIrqs = val
 			}
@@ -383,24 +448,28 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse hit/miss statistics
 		if matches := exactHitRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.ExactMatchHit = val
 			}
 		}
 		
 		if matches := maskedHitRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.MaskedHit = val
 			}
 		}
 		
 		if matches := missRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.Miss = val
 			}
 		}
 		
 		if matches := lostRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.Lost = val
 			}
@@ -408,6 +477,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse suspicious iterations
 		if matches := suspiciousRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.SuspiciousIterations = val
 			}
@@ -418,46 +488,55 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse flow cache metrics
 		if matches := emcHitRateRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.EMCHitRate = val
 			}
 		}
 		if matches := emcHitsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.EMCHits = val
 			}
 		}
 		if matches := emcInsertsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.EMCInserts = val
 			}
 		}
 		if matches := smcHitRateRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.SMCHitRate = val
 			}
 		}
 		if matches := smcHitsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.SMCHits = val
 			}
 		}
 		if matches := megaflowHitRateRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseFloat(matches[1], 64); err == nil {
 				currentMetric.MegaflowHitRate = val
 			}
 		}
 		if matches := megaflowHitsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.MegaflowHits = val
 			}
 		}
 		if matches := megaflowMissesRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.MegaflowMisses = val
 			}
 		}
 		if matches := flowLookupsRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
 				currentMetric.FlowCacheLookups = val
 			}
@@ -465,6 +544,7 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		
 		// Parse histograms
 		if matches := histogramRe.FindStringSubmatch(line); matches != nil {
+			matched = true
 			histogramType = strings.ToLower(matches[1])
 			switch histogramType {
 			case "cycles":
@@ -482,11 +562,16 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		// Parse histogram entries
 		if currentHistogram != nil {
 			if matches := histogramEntryRe.FindStringSubmatch(line); matches != nil {
+				matched = true
 				if val, err := strconv.ParseUint(matches[2], 10, 64); err == nil {
 					(*currentHistogram)[matches[1]] = val
 				}
 			}
 		}
+		
+		if !matched && strings.TrimSpace(line) != "" {
+			unmatched = append(unmatched, line)
+		}
 	}
 	
 	// Add the last metric if exists
@@ -501,19 +586,71 @@ func parseEnhancedPmdOutput(output string) []EnhancedPmdMetrics {
 		metrics = append(metrics, *currentMetric)
 	}
 	
-	return metrics
+	return metrics, unmatched
 }
 
-// enrichWithStats adds additional statistics from pmd-stats-show
+// enrichWithStats adds the cycles per phase from pmd-stats-show, i.e. the
+// idle cycles spent polling without receiving packets and the processing
+// cycles, and the misses resulting in upcalls to the metrics of the same
+// PMD threads.
 func enrichWithStats(metrics []EnhancedPmdMetrics, statsOutput string) {
-	// Parse additional stats and enrich the metrics
-	// This would parse pmd-stats-show output for additional details
-	// Implementation depends on the specific format of stats output
+	pmdHeaderRe := regexp.MustCompile(`pmd thread numa_id (\d+) core_id (\d+):`)
+	idleCyclesRe := regexp.MustCompile(`^\s*idle cycles:\s+(\d+)`)
+	processingCyclesRe := regexp.MustCompile(`^\s*processing cycles:\s+(\d+)`)
+	missUpcallRe := regexp.MustCompile(`^\s*miss with (?:success|failed) upcall:\s+(\d+)`)
+	phwolHitsRe := regexp.MustCompile(`^\s*phwol hits:\s+(\d+)`)
+	
+	var current *EnhancedPmdMetrics
+	scanner := bufio.NewScanner(strings.NewReader(statsOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if matches := pmdHeaderRe.FindStringSubmatch(line); matches != nil {
+			current = nil
+			for i := range metrics {
+				if metrics[i].NumaID == matches[1] && metrics[i].CoreID == matches[2] {
+					current = &metrics[i]
+					break
+				}
+			}
+			continue
+		}
+		// Other sections, e.g. the main thread, are not PMD threads
+		if !strings.HasPrefix(line, " ") {
+			current = nil
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if matches := idleCyclesRe.FindStringSubmatch(line); matches != nil {
+			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
+				current.PollingCycles = val
+				current.HasPhaseCycles = true
+			}
+		}
+		if matches := processingCyclesRe.FindStringSubmatch(line); matches != nil {
+			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
+				current.ProcessingCycles = val
+				current.HasPhaseCycles = true
+			}
+		}
+		if matches := missUpcallRe.FindStringSubmatch(line); matches != nil {
+			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
+				current.MissUpcalls += val
+			}
+		}
+		if matches := phwolHitsRe.FindStringSubmatch(line); matches != nil {
+			if val, err := strconv.ParseUint(matches[1], 10, 64); err == nil {
+				current.PartialOffloadHits = val
+				current.HasPartialOffload = true
+			}
+		}
+	}
 }
 
 // GetDropCounters retrieves specific drop counters from coverage
 func (e *Exporter) GetDropCounters() (map[string]uint64, error) {
-	cmd := exec.Command("ovs-appctl", "coverage/show")
+	cmd := e.vswitchdAppctl("coverage/show")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get coverage: %w", err)
@@ -558,18 +695,28 @@ func (e *Exporter) GetDropCounters() (map[string]uint64, error) {
 	
 	// Pattern to match coverage lines
 	coverageRe := regexp.MustCompile(`^(\S+)\s+(\d+)`)
+	// Pattern to match the header and the trailer of the output
+	coverageSummaryRe := regexp.MustCompile(`^(Event coverage|\d+ events never hit)`)
+	var unmatched []string
 	
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if matches := coverageRe.FindStringSubmatch(line); matches != nil {
-			eventName := matches[1]
-			if dropTypeMap[eventName] {
-				if val, err := strconv.ParseUint(matches[2], 10, 64); err == nil {
-					dropCounters[eventName] = val
-				}
+		if line == "" || coverageSummaryRe.MatchString(line) {
+			continue
+		}
+		matches := coverageRe.FindStringSubmatch(line)
+		if matches == nil {
+			unmatched = append(unmatched, line)
+			continue
+		}
+		eventName := matches[1]
+		if dropTypeMap[eventName] {
+			if val, err := strconv.ParseUint(matches[2], 10, 64); err == nil {
+				dropCounters[eventName] = val
 			}
 		}
 	}
+	e.recordUnmatchedLines(coverageParser, unmatched)
 	
 	return dropCounters, nil
 }
\ No newline at end of file
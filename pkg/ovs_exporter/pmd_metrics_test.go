@@ -132,4 +132,53 @@ Some random text here`
 	if len(metrics) != 0 {
 		t.Fatalf("Expected 0 PMD metrics for invalid output, got %d", len(metrics))
 	}
-}
\ No newline at end of file
+}
+
+func TestParseEnhancedPmdOutputSleepTime(t *testing.T) {
+	output := `pmd thread numa_id 0 core_id 2:
+  iterations:        12345678 (123.45 us/it)
+  Sleep time (us):   1500000  (  12 us/iteration avg.)`
+
+	metrics := parseEnhancedPmdOutput(output)
+	if len(metrics) != 1 {
+		t.Fatalf("Expected 1 PMD, got %d", len(metrics))
+	}
+	if metrics[0].SleepMicroseconds != 1500000 {
+		t.Errorf("Expected SleepMicroseconds=1500000, got %d", metrics[0].SleepMicroseconds)
+	}
+}
+
+func TestEnrichWithStats(t *testing.T) {
+	metrics := []EnhancedPmdMetrics{
+		{PmdID: "2", NumaID: "0", CoreID: "2"},
+		{PmdID: "3", NumaID: "1", CoreID: "3"},
+	}
+	output := `main thread:
+  packets received: 0
+  idle cycles: 0 (0.00%)
+pmd thread numa_id 0 core_id 2:
+  packets received: 1000
+  emc hits: 900
+  miss with success upcall: 40
+  miss with failed upcall: 2
+  phwol hits: 120
+  idle cycles: 2036015926 (96.12%)
+  processing cycles: 82300000 (3.88%)
+  avg cycles per packet: 82300.00 (2118315926/1000)
+pmd thread numa_id 1 core_id 4:
+  idle cycles: 1 (100.00%)`
+
+	enrichWithStats(metrics, output)
+	if !metrics[0].HasPhaseCycles || metrics[0].PollingCycles != 2036015926 || metrics[0].ProcessingCycles != 82300000 {
+		t.Errorf("Unexpected cycles per phase of PMD 2: %+v", metrics[0])
+	}
+	if metrics[0].MissUpcalls != 42 {
+		t.Errorf("Expected MissUpcalls=42, got %d", metrics[0].MissUpcalls)
+	}
+	if !metrics[0].HasPartialOffload || metrics[0].PartialOffloadHits != 120 {
+		t.Errorf("Expected PartialOffloadHits=120, got %+v", metrics[0])
+	}
+	if metrics[1].HasPhaseCycles {
+		t.Errorf("Expected no cycles per phase of PMD 3, got %+v", metrics[1])
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// pmdOverloadTracker counts the number of consecutive polls in which
+// the busy ratio of a PMD thread exceeded a threshold.
+type pmdOverloadTracker struct {
+	threshold float64
+	polls     int
+	streaks   map[string]int
+}
+
+// newPmdOverloadTracker returns a tracker, defaulting to a 0.9 busy ratio
+// threshold when none is provided.
+func newPmdOverloadTracker(threshold float64, polls int) *pmdOverloadTracker {
+	if threshold <= 0 {
+		threshold = 0.9
+	}
+	if polls < 1 {
+		polls = 1
+	}
+	return &pmdOverloadTracker{
+		threshold: threshold,
+		polls:     polls,
+		streaks:   make(map[string]int),
+	}
+}
+
+// observe records the busy ratio (0-1) of a PMD thread identified by key
+// and returns whether the thread is considered overloaded.
+func (t *pmdOverloadTracker) observe(key string, busyRatio float64) bool {
+	if busyRatio > t.threshold {
+		t.streaks[key]++
+	} else {
+		t.streaks[key] = 0
+	}
+	return t.streaks[key] >= t.polls
+}
+
+// prune forgets PMD threads not seen during the last poll.
+func (t *pmdOverloadTracker) prune(seen map[string]bool) {
+	for key := range t.streaks {
+		if !seen[key] {
+			delete(t.streaks, key)
+		}
+	}
+}
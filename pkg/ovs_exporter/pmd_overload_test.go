@@ -0,0 +1,46 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestPmdOverloadTracker(t *testing.T) {
+	tracker := newPmdOverloadTracker(0.8, 3)
+
+	// Two polls above the threshold are not enough.
+	for i := 0; i < 2; i++ {
+		if tracker.observe("0/2", 0.95) {
+			t.Fatalf("Expected PMD not to be overloaded after %d polls", i+1)
+		}
+	}
+	if !tracker.observe("0/2", 0.95) {
+		t.Fatal("Expected PMD to be overloaded after 3 consecutive polls")
+	}
+
+	// A single poll below the threshold resets the streak.
+	if tracker.observe("0/2", 0.5) {
+		t.Fatal("Expected PMD not to be overloaded after busy ratio dropped")
+	}
+	if tracker.observe("0/2", 0.95) {
+		t.Fatal("Expected streak to restart after reset")
+	}
+
+	tracker.prune(map[string]bool{})
+	if len(tracker.streaks) != 0 {
+		t.Errorf("Expected stale PMDs to be pruned, got %v", tracker.streaks)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PmdThread is a PMD thread of the userspace datapath reported by
+// ovs-appctl dpif-netdev/pmd-rxq-show. The PMD threads are identified by
+// their core, which the exporter also uses as PMD ID. Isolated threads only
+// poll the rx queues pinned with other_config:pmd-rxq-affinity. RxQueues
+// is the number of rx queues the thread polls, leaving out the disabled
+// queues of vhost-user ports, which the guest has not enabled.
+type PmdThread struct {
+	NumaID   string
+	CoreID   string
+	Isolated bool
+	RxQueues int
+}
+
+var (
+	pmdThreadHeaderRegex   = regexp.MustCompile(`^pmd thread numa_id (\d+) core_id (\d+):`)
+	pmdThreadIsolatedRegex = regexp.MustCompile(`^\s+isolated\s*:\s*(true|false)`)
+	pmdThreadRxQueueRegex  = regexp.MustCompile(`^\s+port:\s*\S+\s+queue-id:\s*\d+(?:\s+\((enabled|disabled)\))?`)
+)
+
+// GetPmdThreads returns the PMD threads of ovs-vswitchd using ovs-appctl
+// dpif-netdev/pmd-rxq-show. Without the userspace datapath, there are no
+// PMD threads.
+func (e *Exporter) GetPmdThreads() ([]PmdThread, error) {
+	output, err := e.vswitchdAppctl("dpif-netdev/pmd-rxq-show").Output()
+	if err != nil {
+		if strings.Contains(err.Error(), "exit status") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to execute pmd-rxq-show: %w", err)
+	}
+	return parsePmdThreads(string(output)), nil
+}
+
+// parsePmdThreads parses the output of pmd-rxq-show, e.g.:
+//
+//	pmd thread numa_id 0 core_id 2:
+//	  isolated : false
+//	  port: dpdk0             queue-id:  0 (enabled)   pmd usage:  7 %
+//	  overhead:  0 %
+func parsePmdThreads(output string) []PmdThread {
+	var threads []PmdThread
+	for _, line := range strings.Split(output, "\n") {
+		if m := pmdThreadHeaderRegex.FindStringSubmatch(line); m != nil {
+			threads = append(threads, PmdThread{NumaID: m[1], CoreID: m[2]})
+			continue
+		}
+		if len(threads) == 0 {
+			continue
+		}
+		if m := pmdThreadIsolatedRegex.FindStringSubmatch(line); m != nil {
+			threads[len(threads)-1].Isolated = m[1] == "true"
+		}
+		if m := pmdThreadRxQueueRegex.FindStringSubmatch(line); m != nil && m[1] != "disabled" {
+			threads[len(threads)-1].RxQueues++
+		}
+	}
+	return threads
+}
+
+// collectPmdThreadMetrics exports the core and NUMA node of the PMD
+// threads as an info metric to join the PMD metrics with, and the number
+// of rx queues polled by each thread and on each NUMA node.
+func (e *Exporter) collectPmdThreadMetrics() {
+	e.IncrementRequestCounter()
+	threads, err := e.GetPmdThreads()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetPmdThreads() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetPmdThreads() failed", err)
+		return
+	}
+	numaRxQueues := make(map[string]int)
+	for _, thread := range threads {
+		numaRxQueues[thread.NumaID] += thread.RxQueues
+		e.emit(e.newConstMetric(
+			pmdRxQueues,
+			prometheus.GaugeValue,
+			float64(thread.RxQueues),
+			e.Client.System.ID,
+			thread.CoreID,
+			thread.NumaID,
+		))
+		e.emit(e.newConstMetric(
+			pmdThreadInfo,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID,
+			thread.CoreID,
+			thread.NumaID,
+			thread.CoreID,
+			strconv.FormatBool(thread.Isolated),
+		))
+	}
+	for numaID, rxQueues := range numaRxQueues {
+		e.emit(e.newConstMetric(
+			pmdNumaRxQueues,
+			prometheus.GaugeValue,
+			float64(rxQueues),
+			e.Client.System.ID,
+			numaID,
+		))
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePmdThreads(t *testing.T) {
+	output := `Displaying last 60 seconds pmd usage %
+pmd thread numa_id 0 core_id 2:
+  isolated : false
+  port: dpdk0             queue-id:  0 (enabled)   pmd usage:  7 %
+  port: dpdk0             queue-id:  1 (enabled)   pmd usage:  5 %
+  port: vhu1a2b3c4d-5e    queue-id:  1 (disabled)  pmd usage:  0 %
+  overhead:  0 %
+pmd thread numa_id 1 core_id 3:
+  isolated : true
+  port: vhu1a2b3c4d-5e    queue-id:  0 (enabled)   pmd usage: 12 %
+  overhead:  1 %
+`
+	expected := []PmdThread{
+		{NumaID: "0", CoreID: "2", Isolated: false, RxQueues: 2},
+		{NumaID: "1", CoreID: "3", Isolated: true, RxQueues: 1},
+	}
+	if threads := parsePmdThreads(output); !reflect.DeepEqual(threads, expected) {
+		t.Errorf("Expected %v, got %v", expected, threads)
+	}
+	if threads := parsePmdThreads(""); len(threads) != 0 {
+		t.Errorf("Expected no PMD threads, got %v", threads)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pmdHistogramColumns is the number of histograms printed side by side by
+// pmd-perf-show, the distribution of the cycles per upcall being the last.
+const pmdHistogramColumns = 7
+
+var (
+	pmdPerfHeaderRe     = regexp.MustCompile(`pmd thread numa_id (\d+) core_id (\d+):`)
+	pmdPerfIterationsRe = regexp.MustCompile(`^\s*Iterations:\s+(\d+)\s+\(\s*([\d.]+) us/it\)`)
+	pmdPerfTscCyclesRe  = regexp.MustCompile(`^\s*- Used TSC cycles:\s+(\d+)`)
+)
+
+// pmdUpcallHistogram is the distribution of the TSC cycles spent per upcall
+// by a PMD thread, reported by pmd-perf-show when the detailed statistics
+// are enabled with other_config:pmd-perf-metrics=true.
+type pmdUpcallHistogram struct {
+	// bounds are the upper bounds, in cycles, of the bins but the last.
+	bounds []float64
+	// counts are the upcalls of each bin, the last being unbounded.
+	counts []uint64
+	// avgCycles is the average number of cycles per upcall.
+	avgCycles float64
+	// tscHz is the TSC frequency, derived from the iterations of the PMD
+	// thread, or 0 when unknown.
+	tscHz float64
+}
+
+// seconds returns the histogram with the bounds converted to seconds and
+// the counts made cumulative, as expected by prometheus.NewConstHistogram.
+func (h *pmdUpcallHistogram) seconds() (uint64, float64, map[float64]uint64) {
+	buckets := make(map[float64]uint64, len(h.bounds))
+	var count uint64
+	for i, n := range h.counts {
+		count += n
+		if i < len(h.bounds) {
+			buckets[h.bounds[i]/h.tscHz] = count
+		}
+	}
+	return count, h.avgCycles * float64(count) / h.tscHz, buckets
+}
+
+// parsePmdUpcallHistograms returns the distributions of the cycles per
+// upcall of the PMD threads, keyed by NUMA and core ID, from the histograms
+// of pmd-perf-show, e.g.:
+//
+//	pmd thread numa_id 0 core_id 1:
+//
+//	  Iterations:             10000  (100.00 us/it)
+//	  - Used TSC cycles:   2300000000  ( 99.8 % of total cycles)
+//	  ...
+//	  Histograms
+//	     cycles/it             packets/it            ...  cycles/upcall
+//	     499       0           0         9000        ...  999       10
+//	     ...
+//	     >         0           >         0           ...  >         1
+//	  -----------------------------------------------------------------
+//	     cycles/it             packets/it            ...  cycles/upcall
+//	     2310.1                1.0                   ...  5012.3
+//
+// The histograms of the threads whose TSC frequency cannot be derived from
+// their iterations are ignored, as their cycles cannot be converted to
+// seconds.
+func parsePmdUpcallHistograms(output string) map[string]*pmdUpcallHistogram {
+	histograms := make(map[string]*pmdUpcallHistogram)
+	var key string
+	var iterations, usPerIteration, tscCycles float64
+	var current *pmdUpcallHistogram
+	averages := false
+	for _, line := range strings.Split(output, "\n") {
+		if m := pmdPerfHeaderRe.FindStringSubmatch(line); m != nil {
+			key = m[1] + "/" + m[2]
+			iterations, usPerIteration, tscCycles = 0, 0, 0
+			current, averages = nil, false
+			continue
+		}
+		if m := pmdPerfIterationsRe.FindStringSubmatch(line); m != nil {
+			iterations, _ = strconv.ParseFloat(m[1], 64)
+			usPerIteration, _ = strconv.ParseFloat(m[2], 64)
+			continue
+		}
+		if m := pmdPerfTscCyclesRe.FindStringSubmatch(line); m != nil {
+			tscCycles, _ = strconv.ParseFloat(m[1], 64)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case key == "":
+			continue
+		case trimmed == "Histograms":
+			current = &pmdUpcallHistogram{}
+			if iterations > 0 && usPerIteration > 0 {
+				current.tscHz = tscCycles / (iterations * usPerIteration) * 1e6
+			}
+			continue
+		case current == nil:
+			continue
+		case strings.HasPrefix(trimmed, "---"):
+			averages = true
+			continue
+		}
+		fields := strings.Fields(line)
+		switch {
+		case !averages && len(fields) == 2*pmdHistogramColumns:
+			bound, count := fields[len(fields)-2], fields[len(fields)-1]
+			n, err := strconv.ParseUint(count, 10, 64)
+			if err != nil {
+				continue
+			}
+			current.counts = append(current.counts, n)
+			if bound != ">" {
+				b, err := strconv.ParseFloat(bound, 64)
+				if err != nil {
+					current.counts = current.counts[:len(current.counts)-1]
+					continue
+				}
+				current.bounds = append(current.bounds, b)
+			}
+		case averages && len(fields) == pmdHistogramColumns:
+			avg, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+			if err != nil {
+				continue
+			}
+			current.avgCycles = avg
+			if current.tscHz > 0 && len(current.counts) == len(current.bounds)+1 {
+				histograms[key] = current
+			}
+			current = nil
+		}
+	}
+	return histograms
+}
+
+// isPmdHistogramLine reports whether a line of pmd-perf-show belongs to its
+// histograms, which are parsed by parsePmdUpcallHistograms.
+func isPmdHistogramLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "Histograms" || strings.HasPrefix(trimmed, "---") || strings.Contains(trimmed, "cycles/upcall") {
+		return true
+	}
+	fields := strings.Fields(trimmed)
+	if len(fields) != pmdHistogramColumns && len(fields) != 2*pmdHistogramColumns {
+		return false
+	}
+	for _, field := range fields {
+		if _, err := strconv.ParseFloat(field, 64); err != nil && field != ">" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const pmdPerfShowHistograms = `Time: 13:34:19.734
+Measurement duration: 1.512 s
+
+pmd thread numa_id 0 core_id 1:
+
+  Iterations:                 10000  (100.00 us/it)
+  - Used TSC cycles:     2000000000  ( 99.8 % of total cycles)
+  - idle iterations:           9000  ( 90.0 % of used cycles)
+  - busy iterations:           1000  ( 10.0 % of used cycles)
+  Rx packets:                 20000
+  Tx packets:                 20000
+  Upcalls:                       16  (  0.1 % of rx'd pkts,    3.5 us/upcall)
+
+  Histograms
+     cycles/it              packets/it             cycles/pkt             pkts/batch             max vhost qlen         upcalls/it             cycles/upcall
+     499        0           0          9000        0          0           0          0           0          0           0          9990        1000       4
+     716        0           1          500         11         0           1          0           1          0           1          10          2000       10
+     >          0           >          0           >          0           >          0           >          0           >          0           >          2
+-----------------------------------------------------------------------------------------------------------------------------------------------------------------
+     cycles/it              packets/it             cycles/pkt             pkts/batch             vhost qlen             upcalls/it             cycles/upcall
+     200000.0               2.0                    1000.0                 1.5                    0.0                    0.0                    1750.0
+
+pmd thread numa_id 1 core_id 3:
+
+  Iterations:                     0  (  0.00 us/it)
+`
+
+func TestParsePmdUpcallHistograms(t *testing.T) {
+	histograms := parsePmdUpcallHistograms(pmdPerfShowHistograms)
+	if len(histograms) != 1 {
+		t.Fatalf("Expected the histogram of one PMD thread, got %v", histograms)
+	}
+	h := histograms["0/1"]
+	if h == nil {
+		t.Fatalf("Expected the histogram of core 1, got %v", histograms)
+	}
+	// 2e9 cycles in 10000 iterations of 100us.
+	if h.tscHz != 2e9 {
+		t.Errorf("Expected a TSC frequency of 2GHz, got %v", h.tscHz)
+	}
+
+	count, sum, buckets := h.seconds()
+	expected := map[float64]uint64{1000 / 2e9: 4, 2000 / 2e9: 14}
+	if count != 16 || !reflect.DeepEqual(buckets, expected) {
+		t.Errorf("Expected 16 upcalls in %v, got %d in %v", expected, count, buckets)
+	}
+	if math.Abs(sum-16*1750/2e9) > 1e-12 {
+		t.Errorf("Expected a sum of %v, got %v", 16*1750/2e9, sum)
+	}
+}
+
+func TestIsPmdHistogramLine(t *testing.T) {
+	histogram := 0
+	for _, line := range strings.Split(pmdPerfShowHistograms, "\n") {
+		if isPmdHistogramLine(line) {
+			histogram++
+		}
+	}
+	if histogram != 8 {
+		t.Errorf("Expected 8 histogram lines, got %d", histogram)
+	}
+}
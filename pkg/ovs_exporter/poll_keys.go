@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+// pollKeys is the state kept across polls for the keys of a poll, e.g. the
+// last sample of the counters of each interface. The keys not looked up
+// during a poll, e.g. of removed interfaces, are forgotten at its end.
+type pollKeys[V any] struct {
+	values map[string]V
+	seen   map[string]bool
+}
+
+// begin starts a poll.
+func (p *pollKeys[V]) begin() {
+	if p.values == nil {
+		p.values = make(map[string]V)
+	}
+	p.seen = make(map[string]bool)
+}
+
+// reset forgets the state of all the keys, e.g. after a restart of the
+// daemon reporting them.
+func (p *pollKeys[V]) reset() {
+	p.values = make(map[string]V)
+}
+
+// get returns the state of the key and marks it seen during the poll.
+func (p *pollKeys[V]) get(key string) (V, bool) {
+	p.seen[key] = true
+	value, exists := p.values[key]
+	return value, exists
+}
+
+// set stores the state of the key and marks it seen during the poll.
+func (p *pollKeys[V]) set(key string, value V) {
+	p.seen[key] = true
+	p.values[key] = value
+}
+
+// end forgets the keys not seen during the poll.
+func (p *pollKeys[V]) end() {
+	for key := range p.values {
+		if !p.seen[key] {
+			delete(p.values, key)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestPollKeys(t *testing.T) {
+	var p pollKeys[int]
+	p.begin()
+	p.set("a", 1)
+	p.set("b", 2)
+	p.end()
+
+	// A key only looked up is kept, a key not seen is forgotten.
+	p.begin()
+	if value, exists := p.get("a"); !exists || value != 1 {
+		t.Errorf("Expected a to be 1, got %v (exists=%v)", value, exists)
+	}
+	p.end()
+	if _, exists := p.values["b"]; exists {
+		t.Error("Expected b, not seen during the poll, to be forgotten")
+	}
+
+	p.reset()
+	p.begin()
+	if _, exists := p.get("a"); exists {
+		t.Error("Expected a to be forgotten after reset()")
+	}
+	p.end()
+}
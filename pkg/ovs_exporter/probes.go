@@ -0,0 +1,102 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// probeDurationBuckets are the histogram buckets, in seconds, of the
+// control plane responsiveness probes.
+var probeDurationBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+// newProbeDurationHistogram returns a histogram of probe durations
+// labeled with the system id.
+func newProbeDurationHistogram(subsystem, name, help string) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   probeDurationBuckets,
+	}, []string{"system_id"})
+}
+
+// ProbeOvsdb performs a trivial read transaction against ovsdb-server
+// and returns its duration.
+func (e *Exporter) ProbeOvsdb() (time.Duration, error) {
+	query := fmt.Sprintf("SELECT cur_cfg FROM %s", e.Client.Database.Vswitch.Name)
+	start := time.Now()
+	if _, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query); err != nil {
+		return 0, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return time.Since(start), nil
+}
+
+// collectOvsdbProbeMetrics records the duration of an ovsdb-server read
+// transaction in the ovs_ovsdb_probe_duration_seconds histogram.
+func (e *Exporter) collectOvsdbProbeMetrics() {
+	e.IncrementRequestCounter()
+	duration, err := e.ProbeOvsdb()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "ProbeOvsdb() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("ProbeOvsdb() failed", err)
+		return
+	}
+	histogram := e.ovsdbProbeDuration.WithLabelValues(e.Client.System.ID)
+	histogram.Observe(duration.Seconds())
+	e.emit(histogram.(prometheus.Metric))
+}
+
+// ProbeVswitchd runs the "version" unixctl command against ovs-vswitchd
+// and returns its duration. The command is served by the main thread of
+// ovs-vswitchd, hence a slow response indicates that it is blocked.
+func (e *Exporter) ProbeVswitchd() (time.Duration, error) {
+	cmd := e.vswitchdAppctl("version")
+	start := time.Now()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return 0, fmt.Errorf("ovs-appctl version failed: %s: %s", err, strings.TrimSpace(string(output)))
+	}
+	return time.Since(start), nil
+}
+
+// collectVswitchdProbeMetrics records the duration of the ovs-vswitchd
+// "version" unixctl command in the ovs_vswitchd_probe_duration_seconds
+// histogram.
+func (e *Exporter) collectVswitchdProbeMetrics() {
+	e.IncrementRequestCounter()
+	duration, err := e.ProbeVswitchd()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "ProbeVswitchd() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("ProbeVswitchd() failed", err)
+		return
+	}
+	histogram := e.vswitchdProbeDuration.WithLabelValues(e.Client.System.ID)
+	histogram.Observe(duration.Seconds())
+	e.emit(histogram.(prometheus.Metric))
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestProbeDurationHistogram(t *testing.T) {
+	vec := newProbeDurationHistogram("ovsdb", "probe_duration_seconds", "test")
+	histogram := vec.WithLabelValues("test-system")
+	histogram.Observe(0.002)
+	histogram.Observe(0.3)
+
+	m := &dto.Metric{}
+	if err := histogram.(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if got := m.GetHistogram().GetSampleCount(); got != 2 {
+		t.Errorf("Expected 2 samples, got %d", got)
+	}
+	for _, b := range m.GetHistogram().GetBucket() {
+		if b.GetUpperBound() == 0.0025 && b.GetCumulativeCount() != 1 {
+			t.Errorf("Expected 1 sample in the 2.5ms bucket, got %d", b.GetCumulativeCount())
+		}
+	}
+}
+
+func TestProbeVswitchd(t *testing.T) {
+	// The wrapper checks the arguments of ovs-appctl instead of running it.
+	e := &Exporter{
+		Client: ovsdb.NewOvsClient(),
+		commandWrappers: map[string][]string{
+			"ovs-appctl": {"sh", "-c", `test "$2 $3 $4" = "-t /run/ovs-sandbox/ovs-vswitchd.4242.ctl version"`, "sh"},
+		},
+	}
+	e.Client.System.RunDir = "/run/ovs-sandbox"
+	e.Client.Service.Vswitchd.Process.ID = 4242
+	if _, err := e.ProbeVswitchd(); err != nil {
+		t.Errorf("Expected the version command to be sent to the control socket of ovs-vswitchd, got %v", err)
+	}
+
+	e.Client.System.RunDir = "/run/other"
+	if _, err := e.ProbeVswitchd(); err == nil {
+		t.Error("ProbeVswitchd() should return error when ovs-appctl fails")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ovsdbDefaultPort is the port of ovsdb-server for tcp remotes without
+// one.
+const ovsdbDefaultPort = "6640"
+
+// ParseRemote converts an OVSDB remote, e.g. unix:/var/run/openvswitch/db.sock,
+// tcp:192.0.2.1:6640 or tcp:[2001:db8::1]:6640, to a network and address.
+// IPv6 literals must be bracketed, as in the remotes of OVS, and the port
+// defaults to 6640. Remotes without a type, e.g. 192.0.2.1:6640, are tcp
+// remotes. Passive and ssl remotes are not supported.
+func ParseRemote(remote string) (string, string, error) {
+	parts := strings.SplitN(remote, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("malformed remote %q", remote)
+	}
+	switch parts[0] {
+	case "unix":
+		return "unix", parts[1], nil
+	case "tcp":
+		address, err := tcpRemoteAddress(parts[1])
+		if err != nil {
+			return "", "", fmt.Errorf("malformed remote %q: %s", remote, err)
+		}
+		return "tcp", address, nil
+	case "ssl", "punix", "ptcp", "pssl":
+		return "", "", fmt.Errorf("unsupported remote type %q", parts[0])
+	default:
+		if _, _, err := net.SplitHostPort(remote); err != nil {
+			return "", "", fmt.Errorf("malformed remote %q: %s", remote, err)
+		}
+		return "tcp", remote, nil
+	}
+}
+
+// tcpRemoteAddress returns the host and port of a tcp remote, adding the
+// default port to a remote without one.
+func tcpRemoteAddress(s string) (string, error) {
+	if _, _, err := net.SplitHostPort(s); err == nil {
+		return s, nil
+	}
+	host := s
+	if strings.HasPrefix(s, "[") {
+		if !strings.HasSuffix(s, "]") {
+			return "", fmt.Errorf("unterminated IPv6 literal")
+		}
+		host = s[1 : len(s)-1]
+	} else if strings.Contains(s, ":") {
+		return "", fmt.Errorf("IPv6 literals must be bracketed")
+	}
+	if host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+	return net.JoinHostPort(host, ovsdbDefaultPort), nil
+}
+
+// clientRemote returns the remote in the form dialed by the ovsdb library,
+// which dials unix: remotes as sockets and any other remote as a TCP
+// address.
+func clientRemote(remote string) (string, error) {
+	network, address, err := ParseRemote(remote)
+	if err != nil {
+		return "", err
+	}
+	if network == "unix" {
+		return "unix:" + address, nil
+	}
+	return address, nil
+}
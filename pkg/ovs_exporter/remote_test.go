@@ -0,0 +1,65 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseRemote(t *testing.T) {
+	for remote, expected := range map[string][2]string{
+		"unix:/var/run/openvswitch/db.sock": {"unix", "/var/run/openvswitch/db.sock"},
+		"tcp:192.0.2.1:6640":                {"tcp", "192.0.2.1:6640"},
+		"tcp:192.0.2.1":                     {"tcp", "192.0.2.1:6640"},
+		"tcp:[2001:db8::1]:6641":            {"tcp", "[2001:db8::1]:6641"},
+		"tcp:[2001:db8::1]":                 {"tcp", "[2001:db8::1]:6640"},
+		"tcp:ovsdb.example.com:6640":        {"tcp", "ovsdb.example.com:6640"},
+		"192.0.2.1:6640":                    {"tcp", "192.0.2.1:6640"},
+		"[::1]:6640":                        {"tcp", "[::1]:6640"},
+	} {
+		network, address, err := ParseRemote(remote)
+		if err != nil {
+			t.Errorf("ParseRemote(%q) returned error: %v", remote, err)
+			continue
+		}
+		if network != expected[0] || address != expected[1] {
+			t.Errorf("Expected %s %s for %q, got %s %s", expected[0], expected[1], remote, network, address)
+		}
+	}
+
+	for _, remote := range []string{
+		"",
+		"unix:",
+		"tcp:2001:db8::1:6640",
+		"tcp:[2001:db8::1",
+		"ssl:192.0.2.1:6640",
+		"ptcp:6640",
+		"db.sock",
+	} {
+		if _, _, err := ParseRemote(remote); err == nil {
+			t.Errorf("Expected an error for %q", remote)
+		}
+	}
+}
+
+func TestClientRemote(t *testing.T) {
+	for remote, expected := range map[string]string{
+		"unix:/var/run/openvswitch/db.sock": "unix:/var/run/openvswitch/db.sock",
+		"tcp:[2001:db8::1]:6640":            "[2001:db8::1]:6640",
+		"192.0.2.1:6640":                    "192.0.2.1:6640",
+	} {
+		if address, err := clientRemote(remote); err != nil || address != expected {
+			t.Errorf("Expected %s for %q, got %s (%v)", expected, remote, address, err)
+		}
+	}
+}
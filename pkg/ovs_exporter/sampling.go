@@ -0,0 +1,205 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// samplingProtocols are the columns of the Bridge table referencing the
+// configuration of a flow sampling protocol.
+var samplingProtocols = []string{"sflow", "netflow", "ipfix"}
+
+// ipfixErrors maps the error counters of ovs-ofctl dump-ipfix-bridge to the
+// type label of ovs_bridge_ipfix_errors_total.
+var ipfixErrors = map[string]string{
+	"pkts errs": "packet",
+	"ipv4 errs": "ipv4",
+	"ipv6 errs": "ipv6",
+	"tx errs":   "tx",
+}
+
+// IpfixBridgeStats holds the statistics of the IPFIX exporter of a bridge.
+type IpfixBridgeStats struct {
+	SampledPackets float64
+	SentPackets    float64
+	Errors         map[string]float64
+}
+
+// GetBridgeSampling returns the flow sampling protocols configured on each
+// bridge.
+func (e *Exporter) GetBridgeSampling() (map[string][]string, error) {
+	query := "SELECT name, sflow, netflow, ipfix FROM Bridge"
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseBridgeSampling(result), nil
+}
+
+// parseBridgeSampling extracts the sampling protocols referenced by the
+// bridges. An unset reference is returned as an empty set.
+func parseBridgeSampling(result ovsdb.Result) map[string][]string {
+	sampling := make(map[string][]string)
+	for _, row := range result.Rows {
+		name, dt, err := row.GetColumnValue("name", result.Columns)
+		if err != nil || dt != "string" {
+			continue
+		}
+		protocols := []string{}
+		for _, protocol := range samplingProtocols {
+			if _, exists := row[protocol]; !exists {
+				continue
+			}
+			if _, dt, err := row.GetColumnValue(protocol, result.Columns); err == nil && dt == "string" {
+				protocols = append(protocols, protocol)
+			}
+		}
+		sampling[name.(string)] = protocols
+	}
+	return sampling
+}
+
+// GetIpfixBridgeStats returns the statistics of the IPFIX exporter of a
+// bridge.
+func (e *Exporter) GetIpfixBridgeStats(bridge string) (*IpfixBridgeStats, error) {
+	output, err := e.command("ovs-ofctl", "dump-ipfix-bridge", bridge).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dump-ipfix-bridge for %s: %w", bridge, err)
+	}
+	return parseIpfixBridgeStats(string(output))
+}
+
+// parseIpfixBridgeStats parses the output of ovs-ofctl dump-ipfix-bridge,
+// e.g.:
+//
+//	NXST_IPFIX_BRIDGE reply (xid=0x2):
+//	  bridge ipfix: flows=2, current flows=0, sampled pkts=120, ipv4 ok=118, ipv6 ok=0, tx pkts=118
+//	                pkts errs=2, ipv4 errs=0, ipv6 errs=0, tx errs=0
+func parseIpfixBridgeStats(s string) (*IpfixBridgeStats, error) {
+	i := strings.Index(s, "bridge ipfix:")
+	if i < 0 {
+		return nil, fmt.Errorf("no bridge ipfix statistics in %q", s)
+	}
+	stats := &IpfixBridgeStats{Errors: make(map[string]float64)}
+	fields := strings.FieldsFunc(s[i+len("bridge ipfix:"):], func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	for _, field := range fields {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.Join(strings.Fields(kv[0]), " ")
+		value, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value of %q: %s", key, err)
+		}
+		switch key {
+		case "sampled pkts":
+			stats.SampledPackets = value
+		case "tx pkts":
+			stats.SentPackets = value
+		default:
+			if errorType, exists := ipfixErrors[key]; exists {
+				stats.Errors[errorType] = value
+			}
+		}
+	}
+	return stats, nil
+}
+
+// collectSamplingMetrics exports the flow sampling protocols configured on
+// each bridge and the statistics of the IPFIX exporters, so that missing
+// samples can be alerted on. OVS does not count the sFlow and NetFlow
+// samples it fails to send.
+func (e *Exporter) collectSamplingMetrics() {
+	e.IncrementRequestCounter()
+	sampling, err := e.GetBridgeSampling()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetBridgeSampling() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetBridgeSampling() failed", err)
+		return
+	}
+	bridges := make([]string, 0, len(sampling))
+	for bridge := range sampling {
+		bridges = append(bridges, bridge)
+	}
+	sort.Strings(bridges)
+	for _, bridge := range bridges {
+		ipfix := false
+		for _, protocol := range sampling[bridge] {
+			e.emit(e.newConstMetric(
+				bridgeSamplingEnabled,
+				prometheus.GaugeValue,
+				1,
+				e.Client.System.ID,
+				bridge,
+				protocol,
+			))
+			ipfix = ipfix || protocol == "ipfix"
+		}
+		if !ipfix {
+			continue
+		}
+		e.IncrementRequestCounter()
+		stats, err := e.GetIpfixBridgeStats(bridge)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetIpfixBridgeStats() failed",
+				"system_id", e.Client.System.ID,
+				"bridge", bridge,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetIpfixBridgeStats() failed", err)
+			continue
+		}
+		e.emit(e.newConstMetric(
+			bridgeIpfixSampledPackets,
+			prometheus.CounterValue,
+			stats.SampledPackets,
+			e.Client.System.ID,
+			bridge,
+		))
+		e.emit(e.newConstMetric(
+			bridgeIpfixSentPackets,
+			prometheus.CounterValue,
+			stats.SentPackets,
+			e.Client.System.ID,
+			bridge,
+		))
+		for errorType, value := range stats.Errors {
+			e.emit(e.newConstMetric(
+				bridgeIpfixErrors,
+				prometheus.CounterValue,
+				value,
+				e.Client.System.ID,
+				bridge,
+				errorType,
+			))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseBridgeSampling(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"name": "string", "sflow": "string", "netflow": "string", "ipfix": "string"},
+		Rows: []ovsdb.Row{
+			{
+				"name":    "br-int",
+				"sflow":   []interface{}{"uuid", "1f1b1e0c-1c55-4ee6-9ad5-4f2b0b3c9a01"},
+				"netflow": []interface{}{"set", []interface{}{}},
+				"ipfix":   []interface{}{"uuid", "2d7c5a44-6a41-4b8e-8e5b-8d0b3f6c2e02"},
+			},
+			{
+				"name":    "br-ex",
+				"sflow":   []interface{}{"set", []interface{}{}},
+				"netflow": []interface{}{"set", []interface{}{}},
+				"ipfix":   []interface{}{"set", []interface{}{}},
+			},
+		},
+	}
+
+	expected := map[string][]string{
+		"br-int": {"sflow", "ipfix"},
+		"br-ex":  {},
+	}
+	if sampling := parseBridgeSampling(result); !reflect.DeepEqual(sampling, expected) {
+		t.Errorf("Expected %v, got %v", expected, sampling)
+	}
+}
+
+func TestParseIpfixBridgeStats(t *testing.T) {
+	output := `NXST_IPFIX_BRIDGE reply (xid=0x2):
+  bridge ipfix: flows=2, current flows=0, sampled pkts=120, ipv4 ok=118, ipv6 ok=0, tx pkts=118
+                pkts errs=2, ipv4 errs=0, ipv6 errs=0, tx errs=3
+`
+	stats, err := parseIpfixBridgeStats(output)
+	if err != nil {
+		t.Fatalf("parseIpfixBridgeStats() returned error: %v", err)
+	}
+	expected := &IpfixBridgeStats{
+		SampledPackets: 120,
+		SentPackets:    118,
+		Errors:         map[string]float64{"packet": 2, "ipv4": 0, "ipv6": 0, "tx": 3},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	if _, err := parseIpfixBridgeStats("ovs-ofctl: br-int: no such bridge"); err == nil {
+		t.Error("Expected an error for an output without statistics")
+	}
+}
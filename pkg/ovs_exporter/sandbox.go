@@ -0,0 +1,72 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseRunDirs expands a comma-separated list of shell patterns of the run
+// directories of OVS instances, e.g. the sandboxes of ovs-sandbox, to the
+// directories keyed by their sandbox name, i.e. their base name.
+func ParseRunDirs(s string) (map[string]string, error) {
+	dirs := make(map[string]string)
+	for _, pattern := range strings.Split(s, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("malformed run directory pattern %q: %s", pattern, err)
+		}
+		for _, dir := range matches {
+			if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+				continue
+			}
+			name := filepath.Base(dir)
+			if other, exists := dirs[name]; exists && other != dir {
+				return nil, fmt.Errorf("run directories %s and %s have the same sandbox name %q", other, dir, name)
+			}
+			dirs[name] = dir
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no run directory matches %q", s)
+	}
+	return dirs, nil
+}
+
+// UseRunDir points the exporter to an OVS instance whose sockets, pid, log
+// and database files are all in dir, like the sandboxes of ovs-sandbox and
+// of the OVS test suite. The external commands, e.g. ovs-appctl, are run
+// with OVS_RUNDIR, OVS_LOGDIR and OVS_DBDIR set to dir to reach the same
+// instance.
+func (e *Exporter) UseRunDir(dir string) {
+	e.Client.System.RunDir = dir
+	e.Client.Database.Vswitch.Socket.Remote = "unix:" + filepath.Join(dir, "db.sock")
+	e.Client.Database.Vswitch.File.Data.Path = filepath.Join(dir, "conf.db")
+	e.Client.Database.Vswitch.File.Log.Path = filepath.Join(dir, "ovsdb-server.log")
+	e.Client.Database.Vswitch.File.Pid.Path = filepath.Join(dir, "ovsdb-server.pid")
+	e.Client.Database.Vswitch.File.SystemID.Path = filepath.Join(dir, "system-id.conf")
+	e.Client.Service.Vswitchd.File.Log.Path = filepath.Join(dir, "ovs-vswitchd.log")
+	e.Client.Service.Vswitchd.File.Pid.Path = filepath.Join(dir, "ovs-vswitchd.pid")
+	e.Client.Service.OvnController.File.Log.Path = filepath.Join(dir, "ovn-controller.log")
+	e.Client.Service.OvnController.File.Pid.Path = filepath.Join(dir, "ovn-controller.pid")
+	e.commandEnv = []string{"OVS_RUNDIR=" + dir, "OVS_LOGDIR=" + dir, "OVS_DBDIR=" + dir}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+)
+
+func TestParseRunDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"ci/job1/sandbox1", "ci/job2/sandbox2", "lab/sandbox3"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "ci", "job1", "sandbox.log"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, err := ParseRunDirs(filepath.Join(root, "ci/*/sandbox*") + ", " + filepath.Join(root, "lab/sandbox3"))
+	if err != nil {
+		t.Fatalf("ParseRunDirs() returned error: %v", err)
+	}
+	expected := map[string]string{
+		"sandbox1": filepath.Join(root, "ci/job1/sandbox1"),
+		"sandbox2": filepath.Join(root, "ci/job2/sandbox2"),
+		"sandbox3": filepath.Join(root, "lab/sandbox3"),
+	}
+	if !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("Expected %v, got %v", expected, dirs)
+	}
+
+	if _, err := ParseRunDirs(filepath.Join(root, "missing/*")); err == nil {
+		t.Error("Expected an error when no run directory matches")
+	}
+	if err := os.MkdirAll(filepath.Join(root, "lab/sandbox1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseRunDirs(filepath.Join(root, "*/*/sandbox1") + "," + filepath.Join(root, "lab/*")); err == nil || !strings.Contains(err.Error(), "same sandbox name") {
+		t.Errorf("Expected an error for duplicate sandbox names, got %v", err)
+	}
+}
+
+func TestUseRunDir(t *testing.T) {
+	e := NewExporter(Options{Timeout: 2, Logger: log.NewNopLogger()})
+	e.UseRunDir("/tmp/sandbox1")
+	if e.Client.Database.Vswitch.Socket.Remote != "unix:/tmp/sandbox1/db.sock" {
+		t.Errorf("Unexpected database socket %s", e.Client.Database.Vswitch.Socket.Remote)
+	}
+	if e.Client.Service.Vswitchd.File.Pid.Path != "/tmp/sandbox1/ovs-vswitchd.pid" {
+		t.Errorf("Unexpected vswitchd pid file %s", e.Client.Service.Vswitchd.File.Pid.Path)
+	}
+	env := e.command("ovs-appctl", "version").Env
+	if len(env) == 0 || env[len(env)-1] != "OVS_DBDIR=/tmp/sandbox1" || env[len(env)-3] != "OVS_RUNDIR=/tmp/sandbox1" {
+		t.Errorf("Expected the command to run in the environment of the sandbox, got %v", env)
+	}
+}
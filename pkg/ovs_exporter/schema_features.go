@@ -0,0 +1,224 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// schemaFeature is a set of columns of a table that a collector depends
+// on. An empty database refers to the Open_vSwitch database.
+type schemaFeature struct {
+	name     string
+	database string
+	table    string
+	columns  []string
+}
+
+// schemaFeatures lists the schema dependencies of the collectors that
+// query tables directly, so that they can be skipped on older schemas.
+var schemaFeatures = []schemaFeature{
+	{"vswitchd_config", "", "Open_vSwitch", []string{"cur_cfg", "next_cfg"}},
+	{"server_databases", "_Server", "Database", []string{"name", "model", "connected", "leader", "cid", "sid", "index"}},
+	{"interface_statistics", "", "Interface", []string{"statistics"}},
+}
+
+// schemaVersionRange is the range of the major and minor numbers of the
+// versions of a database schema that the exporter was tested with. An
+// empty database refers to the Open_vSwitch database.
+type schemaVersionRange struct {
+	database string
+	min      [2]int
+	max      [2]int
+}
+
+// testedSchemaVersions lists the schema versions the exporter was tested
+// with, from OVS 2.9 to OVS 3.5.
+var testedSchemaVersions = []schemaVersionRange{
+	{"", [2]int{7, 15}, [2]int{8, 8}},
+	{"_Server", [2]int{1, 0}, [2]int{1, 2}},
+}
+
+// schemaVersionSupported returns whether the exporter was tested with a
+// version of the schema of a database.
+func schemaVersionSupported(database, version string) bool {
+	m := majorMinorVersionRe.FindStringSubmatch(version)
+	if m == nil {
+		return false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	for _, r := range testedSchemaVersions {
+		if r.database != database {
+			continue
+		}
+		if major < r.min[0] || (major == r.min[0] && minor < r.min[1]) {
+			return false
+		}
+		if major > r.max[0] || (major == r.max[0] && minor > r.max[1]) {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// detectSchemaFeatures returns whether the schemas provide the tables and
+// columns of each feature.
+func detectSchemaFeatures(schemas map[string]ovsdb.Schema, vswitchName string) map[string]bool {
+	features := make(map[string]bool, len(schemaFeatures))
+	for _, f := range schemaFeatures {
+		db := f.database
+		if db == "" {
+			db = vswitchName
+		}
+		supported := false
+		if schema, exists := schemas[db]; exists {
+			if table, exists := schema.Tables[f.table]; exists {
+				supported = true
+				for _, column := range f.columns {
+					if _, exists := table.Columns[column]; !exists {
+						supported = false
+						break
+					}
+				}
+			}
+		}
+		features[f.name] = supported
+	}
+	return features
+}
+
+// loadSchemaFeatures retrieves the schemas of the Open_vSwitch and
+// _Server databases and records the features they support. On failure,
+// all features are assumed to be supported.
+func (e *Exporter) loadSchemaFeatures() {
+	schemas := make(map[string]ovsdb.Schema)
+	for _, db := range []string{e.Client.Database.Vswitch.Name, "_Server"} {
+		schema, err := e.Client.Database.Vswitch.Client.GetSchema(db)
+		if err != nil {
+			level.Warn(e.logger).Log(
+				"msg", "failed to retrieve database schema",
+				"database", db,
+				"system_id", e.Client.System.ID,
+				"error", err.Error(),
+			)
+			if db == e.Client.Database.Vswitch.Name {
+				return
+			}
+			continue
+		}
+		schemas[db] = schema
+	}
+	e.schemaVersions = make(map[string]string, len(schemas))
+	for db, schema := range schemas {
+		e.schemaVersions[db] = schema.Version
+		database := db
+		if db == e.Client.Database.Vswitch.Name {
+			database = ""
+		}
+		if !schemaVersionSupported(database, schema.Version) {
+			level.Warn(e.logger).Log(
+				"msg", "database schema version was not tested with the exporter",
+				"database", db,
+				"version", schema.Version,
+				"system_id", e.Client.System.ID,
+			)
+		}
+	}
+	e.schemaFeatures = detectSchemaFeatures(schemas, e.Client.Database.Vswitch.Name)
+	e.schemaTables = schemaTables(schemas[e.Client.Database.Vswitch.Name])
+	for name, supported := range e.schemaFeatures {
+		if !supported {
+			level.Info(e.logger).Log(
+				"msg", "schema feature unavailable, skipping dependent collection",
+				"feature", name,
+				"system_id", e.Client.System.ID,
+			)
+		}
+	}
+}
+
+// schemaTables returns the names of the tables of a schema, in name order.
+func schemaTables(schema ovsdb.Schema) []string {
+	tables := make([]string, 0, len(schema.Tables))
+	for table := range schema.Tables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// hasSchemaFeature returns whether the database schema supports a
+// feature. Features are assumed to be supported until the schema is known.
+func (e *Exporter) hasSchemaFeature(name string) bool {
+	if e.schemaFeatures == nil {
+		return true
+	}
+	return e.schemaFeatures[name]
+}
+
+// collectSchemaFeatureMetrics exports the features supported by the
+// database schema and whether the versions of the schemas were tested with
+// the exporter.
+func (e *Exporter) collectSchemaFeatureMetrics() {
+	names := make([]string, 0, len(e.schemaFeatures))
+	for name := range e.schemaFeatures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var value float64
+		if e.schemaFeatures[name] {
+			value = 1
+		}
+		e.emit(e.newConstMetric(
+			schemaFeatureInfo,
+			prometheus.GaugeValue,
+			value,
+			e.Client.System.ID,
+			name,
+		))
+	}
+
+	databases := make([]string, 0, len(e.schemaVersions))
+	for db := range e.schemaVersions {
+		databases = append(databases, db)
+	}
+	sort.Strings(databases)
+	for _, db := range databases {
+		database := db
+		if db == e.Client.Database.Vswitch.Name {
+			database = ""
+		}
+		var value float64
+		if schemaVersionSupported(database, e.schemaVersions[db]) {
+			value = 1
+		}
+		e.emit(e.newConstMetric(
+			schemaSupported,
+			prometheus.GaugeValue,
+			value,
+			e.Client.System.ID,
+			db,
+			e.schemaVersions[db],
+		))
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestDetectSchemaFeatures(t *testing.T) {
+	schemas := map[string]ovsdb.Schema{
+		"Open_vSwitch": {
+			Tables: map[string]ovsdb.Table{
+				"Open_vSwitch": {Columns: map[string]ovsdb.Column{"cur_cfg": {}, "next_cfg": {}}},
+				"Bridge":       {Columns: map[string]ovsdb.Column{"name": {}, "stp_enable": {}}},
+				"Interface":    {Columns: map[string]ovsdb.Column{"name": {}, "statistics": {}}},
+			},
+		},
+	}
+
+	features := detectSchemaFeatures(schemas, "Open_vSwitch")
+	expected := map[string]bool{
+		"vswitchd_config":      true,
+		"interface_statistics": true,
+		"server_databases":     false,
+	}
+	for name, want := range expected {
+		if got, exists := features[name]; !exists || got != want {
+			t.Errorf("feature %s: expected %v, got %v (exists=%v)", name, want, got, exists)
+		}
+	}
+
+	if tables := schemaTables(schemas["Open_vSwitch"]); !reflect.DeepEqual(tables, []string{"Bridge", "Interface", "Open_vSwitch"}) {
+		t.Errorf("Unexpected schema tables %v", tables)
+	}
+
+	e := &Exporter{}
+	if !e.hasSchemaFeature("server_databases") {
+		t.Error("Expected features to be assumed supported before the schema is known")
+	}
+	e.schemaFeatures = features
+	if e.hasSchemaFeature("server_databases") {
+		t.Error("Expected server_databases to be unsupported")
+	}
+}
+
+func TestSchemaVersionSupported(t *testing.T) {
+	tests := []struct {
+		database string
+		version  string
+		want     bool
+	}{
+		{"", "8.3.0", true},
+		{"", "7.15.1", true},
+		{"", "7.14.0", false},
+		{"", "9.0.0", false},
+		{"_Server", "1.2.0", true},
+		{"_Server", "2.0.0", false},
+		{"OVN_Southbound", "20.33.0", false},
+		{"", "unknown", false},
+	}
+	for _, test := range tests {
+		if got := schemaVersionSupported(test.database, test.version); got != test.want {
+			t.Errorf("schemaVersionSupported(%q, %q): expected %v, got %v", test.database, test.version, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectionStats holds the internal state of the exporter used to debug
+// scrape contention. It is updated without holding the exporter mutex.
+type collectionStats struct {
+	inFlight    atomic.Int64
+	lockWait    atomic.Int64
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+	// suspectSamples counts the counter samples replaced because they
+	// decreased without a restart.
+	suspectSamples atomic.Uint64
+	// suppressedLogs counts the warnings and errors not logged because
+	// they were repeated within the deduplication window.
+	suppressedLogs atomic.Uint64
+
+	unmatchedLines unmatchedLineStats
+}
+
+// observeLockWait records the time spent waiting on the exporter mutex
+// since start.
+func (s *collectionStats) observeLockWait(start time.Time) {
+	s.lockWait.Add(int64(time.Since(start)))
+}
+
+// collectSelfMetrics sends the exporter self-metrics. They are not cached,
+// because they change with every Collect() call. The caller must hold the
+// exporter mutex.
+func (e *Exporter) collectSelfMetrics(ch chan<- prometheus.Metric) {
+	ch <- e.newConstMetric(
+		collectInFlight,
+		prometheus.GaugeValue,
+		float64(e.stats.inFlight.Load()),
+		e.Client.System.ID,
+	)
+	ch <- e.newConstMetric(
+		lockWaitSeconds,
+		prometheus.CounterValue,
+		time.Duration(e.stats.lockWait.Load()).Seconds(),
+		e.Client.System.ID,
+	)
+	ch <- e.newConstMetric(
+		pollCacheHits,
+		prometheus.CounterValue,
+		float64(e.stats.cacheHits.Load()),
+		e.Client.System.ID,
+	)
+	ch <- e.newConstMetric(
+		pollCacheMisses,
+		prometheus.CounterValue,
+		float64(e.stats.cacheMisses.Load()),
+		e.Client.System.ID,
+	)
+	ch <- e.newConstMetric(
+		suspectSamples,
+		prometheus.CounterValue,
+		float64(e.stats.suspectSamples.Load()),
+		e.Client.System.ID,
+	)
+	ch <- e.newConstMetric(
+		logMessagesSuppressed,
+		prometheus.CounterValue,
+		float64(e.stats.suppressedLogs.Load()),
+		e.Client.System.ID,
+	)
+	ch <- e.newConstMetric(
+		systemIDChanges,
+		prometheus.CounterValue,
+		float64(e.systemID.changes),
+		e.Client.System.ID,
+	)
+	if !e.lastCollection.IsZero() {
+		ch <- e.newConstMetric(
+			dataAgeSeconds,
+			prometheus.GaugeValue,
+			time.Since(e.lastCollection).Seconds(),
+			e.Client.System.ID,
+		)
+	}
+	e.collectParseMetrics(ch)
+	e.collectCollectorPanicMetrics(ch)
+	e.collectCollectorErrorMetrics(ch)
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollectSelfMetrics(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient()}
+	e.stats.inFlight.Add(2)
+	e.stats.cacheHits.Add(3)
+	e.stats.cacheMisses.Add(1)
+	e.stats.observeLockWait(time.Now().Add(-time.Second))
+	e.lastCollection = time.Now().Add(-10 * time.Second)
+
+	ch := make(chan prometheus.Metric, 20)
+	e.collectSelfMetrics(ch)
+	close(ch)
+
+	values := make(map[*prometheus.Desc]float64)
+	for m := range ch {
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+		if pb.GetGauge() != nil {
+			values[m.Desc()] = pb.GetGauge().GetValue()
+		} else {
+			values[m.Desc()] = pb.GetCounter().GetValue()
+		}
+	}
+	if values[collectInFlight] != 2 {
+		t.Errorf("Expected 2 in-flight collections, got %v", values[collectInFlight])
+	}
+	if values[pollCacheHits] != 3 || values[pollCacheMisses] != 1 {
+		t.Errorf("Unexpected cache hits/misses: %v/%v", values[pollCacheHits], values[pollCacheMisses])
+	}
+	if values[lockWaitSeconds] < 1 {
+		t.Errorf("Expected at least 1s lock wait, got %v", values[lockWaitSeconds])
+	}
+	if values[dataAgeSeconds] < 10 {
+		t.Errorf("Expected a data age of at least 10s, got %v", values[dataAgeSeconds])
+	}
+}
+
+func TestCollectMetricTimestamps(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger(), metricTimestamps: true}
+	e.backgroundCollection.Store(true)
+	e.lastCollection = time.UnixMilli(1700000000000)
+	e.metrics = []prometheus.Metric{
+		prometheus.MustNewConstMetric(requestsTotal, prometheus.CounterValue, 1, e.Client.System.ID),
+	}
+
+	ch := make(chan prometheus.Metric, 20)
+	e.Collect(ch)
+	close(ch)
+
+	for m := range ch {
+		if m.Desc() != requestsTotal {
+			continue
+		}
+		pb := &dto.Metric{}
+		if err := m.Write(pb); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+		if pb.GetTimestampMs() != 1700000000000 {
+			t.Errorf("Expected the collection timestamp, got %d", pb.GetTimestampMs())
+		}
+		return
+	}
+	t.Error("Collect() did not send the cached metric")
+}
+
+func TestEmitStreaming(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), metricTimestamps: true}
+	e.lastCollection = time.UnixMilli(1700000000000)
+	if !e.streaming() {
+		t.Error("Expected the metrics to be streamed without a poll interval")
+	}
+
+	ch := make(chan prometheus.Metric, 1)
+	e.stream = ch
+	e.emit(prometheus.MustNewConstMetric(requestsTotal, prometheus.CounterValue, 1, e.Client.System.ID))
+	if len(e.metrics) != 0 {
+		t.Errorf("Expected no stored metrics when streaming, got %d", len(e.metrics))
+	}
+	pb := &dto.Metric{}
+	if err := (<-ch).Write(pb); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if pb.GetTimestampMs() != 1700000000000 {
+		t.Errorf("Expected the collection timestamp, got %d", pb.GetTimestampMs())
+	}
+
+	e.SetPollInterval(15)
+	if e.streaming() {
+		t.Error("Expected a snapshot of the metrics with a poll interval")
+	}
+}
+
+func TestGatherMetricsConcurrentScrapes(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger()}
+
+	// A scrape waiting for the lock of a collection in progress.
+	e.Lock()
+	done := make(chan struct{})
+	go func() {
+		e.GatherMetrics()
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	atomic.StoreInt64(&e.nextCollectionTicker, time.Now().Add(time.Minute).Unix())
+	e.Unlock()
+	<-done
+
+	if e.stats.cacheHits.Load() != 1 || e.stats.cacheMisses.Load() != 0 {
+		t.Errorf("Expected the waiting scrape to be served from cache, got %d hits and %d misses",
+			e.stats.cacheHits.Load(), e.stats.cacheMisses.Load())
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"time"
+
+	"github.com/greenpau/ovsdb"
+)
+
+// MetricsSnapshot is the OVS state parsed by a collection, for programs
+// embedding the exporter that consume it without parsing the exposition
+// format. The snapshot is shared by the callers of Snapshot and must not be
+// modified.
+type MetricsSnapshot struct {
+	// SystemID is the system-id of the OVS instance.
+	SystemID string
+	// Time is the start time of the collection.
+	Time time.Time
+	// Up is whether OVS was reachable during the collection.
+	Up bool
+	// Interfaces are the rows of the Interface table, limited to the
+	// interfaces exported when -interface.max is set.
+	Interfaces []*ovsdb.OvsInterface
+	// Datapaths are the datapaths of ovs-vswitchd, from dpif/show.
+	Datapaths []*ovsdb.OvsDatapath
+	// PMDs are the PMD threads of the userspace datapath.
+	PMDs []EnhancedPmdMetrics
+}
+
+// Snapshot returns the state parsed by the last completed collection, or
+// nil before the first collection completed. Sections whose collection
+// failed or is disabled are empty.
+func (e *Exporter) Snapshot() *MetricsSnapshot {
+	e.snapshotMu.Lock()
+	defer e.snapshotMu.Unlock()
+	return e.snapshot
+}
+
+// publishSnapshot makes the snapshot of the collection returned by
+// Snapshot.
+func (e *Exporter) publishSnapshot(up bool) {
+	if e.pendingSnapshot == nil {
+		return
+	}
+	e.pendingSnapshot.SystemID = e.Client.System.ID
+	e.pendingSnapshot.Up = up
+	e.snapshotMu.Lock()
+	e.snapshot = e.pendingSnapshot
+	e.snapshotMu.Unlock()
+	e.pendingSnapshot = nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestSnapshot(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient()}
+	e.Client.System.ID = "6f3c3a55-8c3b-4d1e-9b1a-3c2f1e0d9a71"
+	if e.Snapshot() != nil {
+		t.Fatal("Expected no snapshot before the first collection")
+	}
+
+	// Publishing without a collection in progress keeps the snapshot.
+	e.publishSnapshot(true)
+	if e.Snapshot() != nil {
+		t.Fatal("Expected no snapshot without a collection")
+	}
+
+	start := time.Now()
+	e.pendingSnapshot = &MetricsSnapshot{Time: start}
+	e.pendingSnapshot.Interfaces = []*ovsdb.OvsInterface{{UUID: "0a1b2c3d-0000-4000-8000-000000000001", Name: "br-int"}}
+	e.pendingSnapshot.PMDs = []EnhancedPmdMetrics{{PmdID: "2", NumaID: "0", CoreID: "2"}}
+	e.publishSnapshot(false)
+
+	snapshot := e.Snapshot()
+	if snapshot == nil {
+		t.Fatal("Expected a snapshot after the collection")
+	}
+	if snapshot.SystemID != e.Client.System.ID || !snapshot.Time.Equal(start) || snapshot.Up {
+		t.Errorf("Unexpected snapshot %+v", snapshot)
+	}
+	if len(snapshot.Interfaces) != 1 || snapshot.Interfaces[0].Name != "br-int" {
+		t.Errorf("Unexpected interfaces %+v", snapshot.Interfaces)
+	}
+	if len(snapshot.PMDs) != 1 || len(snapshot.Datapaths) != 0 {
+		t.Errorf("Unexpected PMDs %+v and datapaths %+v", snapshot.PMDs, snapshot.Datapaths)
+	}
+	if e.pendingSnapshot != nil {
+		t.Error("Expected the pending snapshot to be released")
+	}
+}
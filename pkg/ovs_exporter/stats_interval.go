@@ -0,0 +1,123 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// minStatsUpdateInterval is the default and the minimum interval at which
+// ovs-vswitchd writes the statistics of the interfaces to the database.
+const minStatsUpdateInterval = 5 * time.Second
+
+// GetStatsUpdateInterval returns the interval at which ovs-vswitchd
+// refreshes the Interface:statistics column.
+func (e *Exporter) GetStatsUpdateInterval() (time.Duration, error) {
+	query := fmt.Sprintf("SELECT other_config FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return 0, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseStatsUpdateInterval(result)
+}
+
+// parseStatsUpdateInterval extracts other_config:stats-update-interval, in
+// milliseconds, from the first row of the Open_vSwitch table. ovs-vswitchd
+// raises intervals below the minimum to the minimum.
+func parseStatsUpdateInterval(result ovsdb.Result) (time.Duration, error) {
+	if len(result.Rows) == 0 {
+		return 0, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	r, _, err := result.Rows[0].GetColumnValue("other_config", result.Columns)
+	if err != nil {
+		return 0, fmt.Errorf("parsing 'other_config' failed: %s", err)
+	}
+	// An empty map is returned as an empty set.
+	otherConfig, _ := r.(map[string]string)
+	value, exists := otherConfig["stats-update-interval"]
+	if !exists {
+		return minStatsUpdateInterval, nil
+	}
+	ms, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("malformed stats-update-interval %q", value)
+	}
+	if interval := time.Duration(ms) * time.Millisecond; interval > minStatsUpdateInterval {
+		return interval, nil
+	}
+	return minStatsUpdateInterval, nil
+}
+
+// interfaceStatsAgeTracker estimates the age of the interface statistics
+// from the last poll observing a change of the statistics of any interface.
+type interfaceStatsAgeTracker struct {
+	sum     int64
+	changed time.Time
+}
+
+// observe records the statistics of the interfaces of a poll and returns
+// the time since they were last observed changing. The statistics of the
+// first poll are considered fresh.
+func (t *interfaceStatsAgeTracker) observe(intfs []*ovsdb.OvsInterface, now time.Time) time.Duration {
+	var sum int64
+	for _, intf := range intfs {
+		for _, value := range intf.Statistics {
+			sum += int64(value)
+		}
+	}
+	if t.changed.IsZero() || sum != t.sum {
+		t.sum = sum
+		t.changed = now
+	}
+	return now.Sub(t.changed)
+}
+
+// collectInterfaceStatsAgeMetrics exports the interval at which
+// ovs-vswitchd refreshes the interface statistics and the estimated age of
+// the statistics of the poll. The age is a multiple of the poll interval:
+// it stays at zero while the statistics change between polls, and grows
+// beyond the refresh interval when ovs-vswitchd stops refreshing them.
+func (e *Exporter) collectInterfaceStatsAgeMetrics(intfs []*ovsdb.OvsInterface) {
+	age := e.interfaceStatsAge.observe(intfs, time.Now())
+	e.emit(e.newConstMetric(
+		interfaceStatisticsAge,
+		prometheus.GaugeValue,
+		age.Seconds(),
+		e.Client.System.ID,
+	))
+	e.IncrementRequestCounter()
+	interval, err := e.GetStatsUpdateInterval()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetStatsUpdateInterval() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetStatsUpdateInterval() failed", err)
+		return
+	}
+	e.emit(e.newConstMetric(
+		statsUpdateInterval,
+		prometheus.GaugeValue,
+		interval.Seconds(),
+		e.Client.System.ID,
+	))
+}
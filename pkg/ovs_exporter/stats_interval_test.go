@@ -0,0 +1,67 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseStatsUpdateInterval(t *testing.T) {
+	columns := map[string]string{"other_config": "map[string]string"}
+	for _, test := range []struct {
+		otherConfig interface{}
+		expected    time.Duration
+	}{
+		{[]interface{}{"set", []interface{}{}}, 5 * time.Second},
+		{[]interface{}{"map", []interface{}{[]interface{}{"stats-update-interval", "30000"}}}, 30 * time.Second},
+		{[]interface{}{"map", []interface{}{[]interface{}{"stats-update-interval", "1000"}}}, 5 * time.Second},
+	} {
+		result := ovsdb.Result{Columns: columns, Rows: []ovsdb.Row{{"other_config": test.otherConfig}}}
+		interval, err := parseStatsUpdateInterval(result)
+		if err != nil {
+			t.Fatalf("parseStatsUpdateInterval() returned error: %v", err)
+		}
+		if interval != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, interval)
+		}
+	}
+
+	result := ovsdb.Result{Columns: columns, Rows: []ovsdb.Row{{"other_config": []interface{}{"map", []interface{}{
+		[]interface{}{"stats-update-interval", "fast"},
+	}}}}}
+	if _, err := parseStatsUpdateInterval(result); err == nil {
+		t.Error("Expected an error for a malformed interval")
+	}
+}
+
+func TestInterfaceStatsAgeTracker(t *testing.T) {
+	start := time.Unix(1760000000, 0)
+	intf := &ovsdb.OvsInterface{Statistics: map[string]int{"rx_packets": 10}}
+	tracker := interfaceStatsAgeTracker{}
+
+	if age := tracker.observe([]*ovsdb.OvsInterface{intf}, start); age != 0 {
+		t.Errorf("Expected the statistics of the first poll to be fresh, got %v", age)
+	}
+	if age := tracker.observe([]*ovsdb.OvsInterface{intf}, start.Add(15*time.Second)); age != 15*time.Second {
+		t.Errorf("Expected unchanged statistics to age, got %v", age)
+	}
+	intf.Statistics["rx_packets"] = 20
+	if age := tracker.observe([]*ovsdb.OvsInterface{intf}, start.Add(30*time.Second)); age != 0 {
+		t.Errorf("Expected changed statistics to be fresh, got %v", age)
+	}
+}
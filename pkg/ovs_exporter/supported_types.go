@@ -0,0 +1,109 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SupportedTypes holds the datapath and interface types supported by the
+// running ovs-vswitchd, i.e. the features it was built with, e.g. the
+// netdev datapath and the dpdk interfaces of DPDK builds or the afxdp
+// interfaces of AF_XDP builds.
+type SupportedTypes struct {
+	DatapathTypes  []string
+	InterfaceTypes []string
+}
+
+// GetSupportedTypes returns the datapath and interface types published by
+// ovs-vswitchd in the Open_vSwitch table.
+func (e *Exporter) GetSupportedTypes() (SupportedTypes, error) {
+	query := fmt.Sprintf("SELECT datapath_types, iface_types FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return SupportedTypes{}, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseSupportedTypes(result)
+}
+
+// parseSupportedTypes extracts the supported types from the first row of
+// the Open_vSwitch table.
+func parseSupportedTypes(result ovsdb.Result) (SupportedTypes, error) {
+	if len(result.Rows) == 0 {
+		return SupportedTypes{}, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	var types SupportedTypes
+	for _, column := range []struct {
+		name   string
+		values *[]string
+	}{
+		{"datapath_types", &types.DatapathTypes},
+		{"iface_types", &types.InterfaceTypes},
+	} {
+		r, dt, err := result.Rows[0].GetColumnValue(column.name, result.Columns)
+		if err != nil {
+			return types, fmt.Errorf("parsing '%s' failed: %s", column.name, err)
+		}
+		// A set with a single string is returned as a string.
+		switch dt {
+		case "string":
+			*column.values = []string{r.(string)}
+		case "[]string":
+			*column.values = append(*column.values, r.([]string)...)
+		default:
+			return types, fmt.Errorf("data type '%s' for '%s' column is unexpected in this context", dt, column.name)
+		}
+		sort.Strings(*column.values)
+	}
+	return types, nil
+}
+
+// collectSupportedTypeMetrics exports the supported datapath and interface
+// types, so that the capabilities of a fleet, e.g. the hosts able to run
+// DPDK or AF_XDP ports, can be queried centrally.
+func (e *Exporter) collectSupportedTypeMetrics() {
+	e.IncrementRequestCounter()
+	types, err := e.GetSupportedTypes()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetSupportedTypes() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetSupportedTypes() failed", err)
+		return
+	}
+	for _, datapathType := range types.DatapathTypes {
+		e.emit(e.newConstMetric(
+			datapathTypeSupported,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID, datapathType,
+		))
+	}
+	for _, interfaceType := range types.InterfaceTypes {
+		e.emit(e.newConstMetric(
+			interfaceTypeSupported,
+			prometheus.GaugeValue,
+			1,
+			e.Client.System.ID, interfaceType,
+		))
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseSupportedTypes(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"datapath_types": "[]string", "iface_types": "[]string"},
+		Rows: []ovsdb.Row{
+			{
+				"datapath_types": []interface{}{"set", []interface{}{"system", "netdev"}},
+				"iface_types":    []interface{}{"set", []interface{}{"vxlan", "dpdk", "afxdp", "internal"}},
+			},
+		},
+	}
+
+	expected := SupportedTypes{
+		DatapathTypes:  []string{"netdev", "system"},
+		InterfaceTypes: []string{"afxdp", "dpdk", "internal", "vxlan"},
+	}
+	types, err := parseSupportedTypes(result)
+	if err != nil {
+		t.Fatalf("parseSupportedTypes() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("Expected %v, got %v", expected, types)
+	}
+
+	if _, err := parseSupportedTypes(ovsdb.Result{}); err == nil {
+		t.Error("Expected an error for an empty Open_vSwitch table")
+	}
+}
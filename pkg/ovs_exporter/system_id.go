@@ -18,7 +18,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/go-kit/log/level"
@@ -27,7 +26,7 @@ import (
 // GetSystemIDFromDatabase attempts to retrieve the system-id from the OVS database
 // using ovs-vsctl. This is the preferred method for newer OVS versions.
 func (e *Exporter) GetSystemIDFromDatabase() (string, error) {
-	cmd := exec.Command("ovs-vsctl", "get", "Open_vSwitch", ".", "external-ids:system-id")
+	cmd := e.command("ovs-vsctl", "get", "Open_vSwitch", ".", "external-ids:system-id")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get system-id from database: %w", err)
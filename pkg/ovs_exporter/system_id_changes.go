@@ -0,0 +1,67 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"github.com/go-kit/log/level"
+)
+
+// systemIDTracker detects the changes of the system-id of OVS, e.g. when
+// the host is re-provisioned, which otherwise silently move all series to
+// new system_id labels.
+type systemIDTracker struct {
+	// observed is the last system-id read from OVS.
+	observed string
+	changes  uint64
+}
+
+// getSystemInfo reads the system information of OVS, including its
+// system-id, and tracks the changes of the system-id.
+func (e *Exporter) getSystemInfo() error {
+	if e.systemID.observed != "" {
+		// GetSystemInfo() keeps the current system-id when it cannot read
+		// it, which must not be taken for the pinned one.
+		e.Client.System.ID = e.systemID.observed
+	}
+	err := e.Client.GetSystemInfo()
+	e.observeSystemID()
+	return err
+}
+
+// observeSystemID records the system-id read from OVS, counting and logging
+// its changes, and replaces it with the pinned system-id, if any, so that
+// the series keep their labels.
+func (e *Exporter) observeSystemID() {
+	observed := e.Client.System.ID
+	if observed != "" && observed != "unknown" {
+		if e.systemID.observed != "" && observed != e.systemID.observed {
+			e.systemID.changes++
+			msg := "the system-id of OVS changed, the series move to the new system_id label"
+			if e.pinnedSystemID != "" {
+				msg = "the system-id of OVS changed, the series keep the pinned system_id label"
+			}
+			level.Error(e.logger).Log(
+				"msg", msg,
+				"previous_system_id", e.systemID.observed,
+				"observed_system_id", observed,
+				"pinned_system_id", e.pinnedSystemID,
+			)
+		}
+		e.systemID.observed = observed
+	}
+	if e.pinnedSystemID != "" {
+		e.Client.System.ID = e.pinnedSystemID
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/greenpau/ovsdb"
+)
+
+func TestObserveSystemID(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		pinned          string
+		observed        []string
+		expectedLabel   string
+		expectedChanges uint64
+	}{
+		{"unchanged", "", []string{"a", "a"}, "a", 0},
+		{"changed", "", []string{"a", "b", "b"}, "b", 1},
+		{"unknown", "", []string{"a", "unknown", "a"}, "a", 0},
+		{"pinned", "host1", []string{"a", "b", "a"}, "host1", 2},
+	} {
+		e := &Exporter{Client: ovsdb.NewOvsClient(), logger: log.NewNopLogger(), pinnedSystemID: test.pinned}
+		for _, id := range test.observed {
+			e.Client.System.ID = id
+			e.observeSystemID()
+		}
+		if e.Client.System.ID != test.expectedLabel {
+			t.Errorf("%s: expected system_id %q, got %q", test.name, test.expectedLabel, e.Client.System.ID)
+		}
+		if e.systemID.changes != test.expectedChanges {
+			t.Errorf("%s: expected %d changes, got %d", test.name, test.expectedChanges, e.systemID.changes)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// loadAveragePeriods are the periods of the load averages, in the order of
+// the load_average key.
+var loadAveragePeriods = []string{"1m", "5m", "15m"}
+
+// systemMemoryTypes are the quantities of the memory key, in kilobytes.
+var systemMemoryTypes = []string{"total", "used", "swap_total", "swap_used"}
+
+// ProcessStatistics holds the statistics ovs-vswitchd reports for an OVS
+// daemon. The durations are -1 for daemons not run with --monitor.
+type ProcessStatistics struct {
+	VirtualMemory  float64
+	ResidentMemory float64
+	CPUTime        float64
+	Crashes        float64
+	Uptime         float64
+}
+
+// SystemStatistics holds the statistics of the host published by
+// ovs-vswitchd in the Open_vSwitch:statistics column when
+// other_config:enable-statistics is true. Memory values are in bytes and
+// durations in seconds.
+type SystemStatistics struct {
+	CPUs        float64
+	LoadAverage map[string]float64
+	Memory      map[string]float64
+	Processes   map[string]ProcessStatistics
+}
+
+// GetSystemStatistics returns the statistics of the host published by
+// ovs-vswitchd. The statistics are empty when not enabled.
+func (e *Exporter) GetSystemStatistics() (SystemStatistics, error) {
+	query := fmt.Sprintf("SELECT statistics FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return SystemStatistics{}, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseSystemStatistics(result)
+}
+
+// parseSystemStatistics parses the statistics column of the first row of
+// the Open_vSwitch table, e.g.:
+//
+//	cpu="8"
+//	load_average="0.42,0.35,0.30"
+//	memory="16310852,5302240,2097148,0"
+//	process_ovs-vswitchd="1048576,65536,12340,1,86400000,3600000"
+//
+// Malformed keys are skipped.
+func parseSystemStatistics(result ovsdb.Result) (SystemStatistics, error) {
+	stats := SystemStatistics{
+		CPUs:        -1,
+		LoadAverage: make(map[string]float64),
+		Memory:      make(map[string]float64),
+		Processes:   make(map[string]ProcessStatistics),
+	}
+	if len(result.Rows) == 0 {
+		return stats, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	r, dt, err := result.Rows[0].GetColumnValue("statistics", result.Columns)
+	if err != nil {
+		return stats, fmt.Errorf("parsing 'statistics' failed: %s", err)
+	}
+	// An empty map is returned as an empty set.
+	if dt != "map[string]string" {
+		return stats, nil
+	}
+	for key, value := range r.(map[string]string) {
+		values, err := parseStatisticsValues(value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case key == "cpu" && len(values) == 1:
+			stats.CPUs = values[0]
+		case key == "load_average":
+			for i, v := range values {
+				if i < len(loadAveragePeriods) {
+					stats.LoadAverage[loadAveragePeriods[i]] = v
+				}
+			}
+		case key == "memory":
+			for i, v := range values {
+				if i < len(systemMemoryTypes) {
+					stats.Memory[systemMemoryTypes[i]] = v * 1024
+				}
+			}
+		case strings.HasPrefix(key, "process_") && len(values) >= 4:
+			process := ProcessStatistics{
+				VirtualMemory:  values[0] * 1024,
+				ResidentMemory: values[1] * 1024,
+				CPUTime:        values[2] / 1000,
+				Crashes:        values[3],
+				Uptime:         -1,
+			}
+			if len(values) >= 6 && values[5] >= 0 {
+				process.Uptime = values[5] / 1000
+			}
+			stats.Processes[strings.TrimPrefix(key, "process_")] = process
+		}
+	}
+	return stats, nil
+}
+
+// parseStatisticsValues parses a comma-separated list of numbers.
+func parseStatisticsValues(s string) ([]float64, error) {
+	var values []float64
+	for _, field := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// collectSystemStatisticsMetrics exports the statistics of the host and of
+// the OVS daemons reported by ovs-vswitchd itself, a fallback for
+// environments where the exporter cannot read /proc, e.g. containers
+// without the host PID namespace.
+func (e *Exporter) collectSystemStatisticsMetrics() {
+	e.IncrementRequestCounter()
+	stats, err := e.GetSystemStatistics()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetSystemStatistics() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetSystemStatistics() failed", err)
+		return
+	}
+	if stats.CPUs >= 0 {
+		e.emit(e.newConstMetric(
+			systemStatisticsCPUs,
+			prometheus.GaugeValue,
+			stats.CPUs,
+			e.Client.System.ID,
+		))
+	}
+	for period, value := range stats.LoadAverage {
+		e.emit(e.newConstMetric(
+			systemStatisticsLoadAverage,
+			prometheus.GaugeValue,
+			value,
+			e.Client.System.ID,
+			period,
+		))
+	}
+	for memoryType, value := range stats.Memory {
+		e.emit(e.newConstMetric(
+			systemStatisticsMemory,
+			prometheus.GaugeValue,
+			value,
+			e.Client.System.ID,
+			memoryType,
+		))
+	}
+	for name, process := range stats.Processes {
+		e.emit(e.newConstMetric(
+			systemStatisticsProcessVirtualMemory,
+			prometheus.GaugeValue,
+			process.VirtualMemory,
+			e.Client.System.ID,
+			name,
+		))
+		e.emit(e.newConstMetric(
+			systemStatisticsProcessResidentMemory,
+			prometheus.GaugeValue,
+			process.ResidentMemory,
+			e.Client.System.ID,
+			name,
+		))
+		e.emit(e.newConstMetric(
+			systemStatisticsProcessCPUTime,
+			prometheus.CounterValue,
+			process.CPUTime,
+			e.Client.System.ID,
+			name,
+		))
+		e.emit(e.newConstMetric(
+			systemStatisticsProcessCrashes,
+			prometheus.CounterValue,
+			process.Crashes,
+			e.Client.System.ID,
+			name,
+		))
+		if process.Uptime >= 0 {
+			e.emit(e.newConstMetric(
+				systemStatisticsProcessUptime,
+				prometheus.GaugeValue,
+				process.Uptime,
+				e.Client.System.ID,
+				name,
+			))
+		}
+	}
+}
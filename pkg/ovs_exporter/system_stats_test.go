@@ -0,0 +1,69 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseSystemStatistics(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"statistics": "map[string]string"},
+		Rows: []ovsdb.Row{
+			{"statistics": []interface{}{"map", []interface{}{
+				[]interface{}{"cpu", "8"},
+				[]interface{}{"load_average", "0.42,0.35,0.30"},
+				[]interface{}{"memory", "16000,5000"},
+				[]interface{}{"process_ovs-vswitchd", "2048,1024,12340,1,86400000,3600000"},
+				[]interface{}{"process_ovsdb-server", "512,256,1500,0,-1,-1"},
+				[]interface{}{"file_systems", "/,1000,500"},
+			}}},
+		},
+	}
+
+	stats, err := parseSystemStatistics(result)
+	if err != nil {
+		t.Fatalf("parseSystemStatistics() returned error: %v", err)
+	}
+	expected := SystemStatistics{
+		CPUs:        8,
+		LoadAverage: map[string]float64{"1m": 0.42, "5m": 0.35, "15m": 0.30},
+		Memory:      map[string]float64{"total": 16000 * 1024, "used": 5000 * 1024},
+		Processes: map[string]ProcessStatistics{
+			"ovs-vswitchd": {VirtualMemory: 2048 * 1024, ResidentMemory: 1024 * 1024, CPUTime: 12.34, Crashes: 1, Uptime: 3600},
+			"ovsdb-server": {VirtualMemory: 512 * 1024, ResidentMemory: 256 * 1024, CPUTime: 1.5, Crashes: 0, Uptime: -1},
+		},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestParseSystemStatisticsDisabled(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"statistics": "map[string]string"},
+		Rows:    []ovsdb.Row{{"statistics": []interface{}{"map", []interface{}{}}}},
+	}
+	stats, err := parseSystemStatistics(result)
+	if err != nil {
+		t.Fatalf("parseSystemStatistics() returned error: %v", err)
+	}
+	if stats.CPUs != -1 || len(stats.LoadAverage) != 0 || len(stats.Processes) != 0 {
+		t.Errorf("Expected no statistics, got %+v", stats)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TunnelNeighborCount holds the number of tunnel neighbor cache entries
+// of a bridge for an address family.
+type TunnelNeighborCount struct {
+	Bridge  string
+	Family  string
+	Entries int
+}
+
+// GetTunnelNeighbors returns the number of entries in the tunnel neighbor
+// (ARP/ND) cache of ovs-vswitchd, using ovs-appctl tnl/neigh/show, or
+// tnl/arp/show on older releases.
+func (e *Exporter) GetTunnelNeighbors() ([]TunnelNeighborCount, error) {
+	output, err := e.vswitchdAppctl("tnl/neigh/show").Output()
+	if err != nil {
+		var legacyErr error
+		output, legacyErr = e.vswitchdAppctl("tnl/arp/show").Output()
+		if legacyErr != nil {
+			return nil, fmt.Errorf("failed to execute tnl/neigh/show: %w", err)
+		}
+	}
+	return parseTunnelNeighbors(string(output)), nil
+}
+
+// parseTunnelNeighbors parses the output of tnl/neigh/show, e.g.:
+//
+//	IP                                            MAC                 Bridge
+//	==========================================================================
+//	10.0.0.2                                      52:54:00:aa:bb:cc   br-phy
+func parseTunnelNeighbors(output string) []TunnelNeighborCount {
+	counts := make(map[[2]string]int)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		ip := net.ParseIP(fields[0])
+		if ip == nil {
+			continue
+		}
+		family := "ipv6"
+		if ip.To4() != nil {
+			family = "ipv4"
+		}
+		counts[[2]string{fields[2], family}]++
+	}
+
+	var neighbors []TunnelNeighborCount
+	for key, entries := range counts {
+		neighbors = append(neighbors, TunnelNeighborCount{
+			Bridge:  key[0],
+			Family:  key[1],
+			Entries: entries,
+		})
+	}
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].Bridge != neighbors[j].Bridge {
+			return neighbors[i].Bridge < neighbors[j].Bridge
+		}
+		return neighbors[i].Family < neighbors[j].Family
+	})
+	return neighbors
+}
+
+// collectTunnelNeighborMetrics exports the number of tunnel neighbor cache
+// entries per bridge and address family.
+func (e *Exporter) collectTunnelNeighborMetrics() {
+	e.IncrementRequestCounter()
+	neighbors, err := e.GetTunnelNeighbors()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetTunnelNeighbors() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetTunnelNeighbors() failed", err)
+		return
+	}
+	for _, n := range neighbors {
+		e.emit(e.newConstMetric(
+			tunnelNeighborEntries,
+			prometheus.GaugeValue,
+			float64(n.Entries),
+			e.Client.System.ID,
+			n.Bridge,
+			n.Family,
+		))
+	}
+}
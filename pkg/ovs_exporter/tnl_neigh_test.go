@@ -0,0 +1,45 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import "testing"
+
+func TestParseTunnelNeighbors(t *testing.T) {
+	output := `IP                                            MAC                 Bridge
+==========================================================================
+10.0.0.2                                      52:54:00:aa:bb:cc   br-phy
+10.0.0.3                                      52:54:00:aa:bb:cd   br-phy
+fe80::5054:ff:feaa:bbcc                       52:54:00:aa:bb:cc   br-phy
+192.168.10.1                                  52:54:00:11:22:33   br-ex
+`
+	neighbors := parseTunnelNeighbors(output)
+	expected := []TunnelNeighborCount{
+		{"br-ex", "ipv4", 1},
+		{"br-phy", "ipv4", 2},
+		{"br-phy", "ipv6", 1},
+	}
+	if len(neighbors) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d: %+v", len(expected), len(neighbors), neighbors)
+	}
+	for i := range expected {
+		if neighbors[i] != expected[i] {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, expected[i], neighbors[i])
+		}
+	}
+
+	if neighbors := parseTunnelNeighbors(""); len(neighbors) != 0 {
+		t.Errorf("Expected no entries for empty output, got %+v", neighbors)
+	}
+}
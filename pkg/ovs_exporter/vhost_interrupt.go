@@ -0,0 +1,87 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// vhostNotificationKeyRe matches the keys of the notifications OVS sent to
+// the guest on a queue of a vhost-user interface, e.g.
+// tx_q0_guest_notifications, reported by OVS 3.1 or later.
+var vhostNotificationKeyRe = regexp.MustCompile(`^(rx|tx)_q([0-9]+)_guest_notifications$`)
+
+// parseVhostNotificationKey returns the metric, the queue and the direction
+// of a guest notifications key.
+func parseVhostNotificationKey(key string) (interfaceStat, string, string, bool) {
+	m := vhostNotificationKeyRe.FindStringSubmatch(key)
+	if m == nil {
+		return interfaceStat{}, "", "", false
+	}
+	return interfaceStat{interfaceVhostGuestNotifications, prometheus.CounterValue}, m[2], m[1], true
+}
+
+// vhostInterruptTracker detects vhost-user interfaces whose guest relies on
+// notifications, i.e. whose virtio driver runs in interrupt mode instead of
+// polling the rings, from the increase of the guest notifications between
+// polls.
+type vhostInterruptTracker struct {
+	notifications pollKeys[float64]
+}
+
+// begin starts a poll.
+func (t *vhostInterruptTracker) begin() {
+	t.notifications.begin()
+}
+
+// observe records the total guest notifications of the interface and
+// returns whether the guest was notified since the previous poll. The
+// result is unknown on the first poll of an interface.
+func (t *vhostInterruptTracker) observe(uuid string, notifications float64) (bool, bool) {
+	previous, exists := t.notifications.get(uuid)
+	t.notifications.set(uuid, notifications)
+	if !exists {
+		return false, false
+	}
+	return notifications > previous, true
+}
+
+// end forgets the interfaces not observed during the poll.
+func (t *vhostInterruptTracker) end() {
+	t.notifications.end()
+}
+
+// collectVhostInterruptMode exports whether the guest of the vhost-user
+// interface ran in interrupt mode since the previous poll.
+func (e *Exporter) collectVhostInterruptMode(uuid, name string, notifications float64) {
+	interrupt, known := e.vhostInterrupt.observe(uuid, notifications)
+	if !known {
+		return
+	}
+	var value float64
+	if interrupt {
+		value = 1
+	}
+	e.emit(e.newConstMetric(
+		interfaceVhostInterruptMode,
+		prometheus.GaugeValue,
+		value,
+		e.Client.System.ID,
+		uuid,
+		name,
+	))
+}
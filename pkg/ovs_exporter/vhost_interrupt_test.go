@@ -0,0 +1,59 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+)
+
+func TestParseVhostNotificationKey(t *testing.T) {
+	stat, queue, direction, ok := parseVhostNotificationKey("tx_q3_guest_notifications")
+	if !ok || stat.desc != interfaceVhostGuestNotifications || queue != "3" || direction != "tx" {
+		t.Errorf("Unexpected result %v, %q, %q, %v", stat, queue, direction, ok)
+	}
+	if _, _, _, ok := parseVhostNotificationKey("tx_q3_guest_notifications_error"); ok {
+		t.Error("Expected tx_q3_guest_notifications_error not to match")
+	}
+}
+
+func TestVhostInterruptTracker(t *testing.T) {
+	tracker := vhostInterruptTracker{}
+
+	tracker.begin()
+	if _, known := tracker.observe("vhu0", 100); known {
+		t.Error("Expected the mode to be unknown on the first poll")
+	}
+	tracker.end()
+
+	tracker.begin()
+	if interrupt, known := tracker.observe("vhu0", 150); !known || !interrupt {
+		t.Errorf("Expected interrupt mode, got %v (known: %v)", interrupt, known)
+	}
+	tracker.end()
+
+	tracker.begin()
+	if interrupt, known := tracker.observe("vhu0", 150); !known || interrupt {
+		t.Errorf("Expected polling mode, got %v (known: %v)", interrupt, known)
+	}
+	tracker.end()
+
+	// Interfaces not observed during a poll are forgotten.
+	tracker.begin()
+	tracker.end()
+	tracker.begin()
+	if _, known := tracker.observe("vhu0", 150); known {
+		t.Error("Expected the mode of a forgotten interface to be unknown")
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// vlogLevels maps the log levels of vlog/list to their numeric values,
+// from the least to the most verbose.
+var vlogLevels = map[string]int{
+	"off":  0,
+	"emer": 1,
+	"err":  2,
+	"warn": 3,
+	"info": 4,
+	"dbg":  5,
+}
+
+// vlogComponents are the components whose log levels are collected.
+var vlogComponents = []string{"ovsdb-server", "vswitchd-service"}
+
+// ErrInvalidVlogSpec is returned by SetVlogLevel for specifications other
+// than MODULE[:DESTINATION[:LEVEL]].
+var ErrInvalidVlogSpec = errors.New("invalid log level specification, expected MODULE[:DESTINATION[:LEVEL]]")
+
+// vlogSpecRe matches the specifications accepted by SetVlogLevel. The
+// PATTERN and FACILITY forms of vlog/set, changing the format of the log
+// messages and the syslog facility, are not accepted.
+var vlogSpecRe = regexp.MustCompile(`^[A-Za-z0-9_-]+(:[A-Za-z0-9_-]+){0,2}$`)
+
+// VlogLevel is the log level of a module of an OVS daemon for a log
+// destination, e.g. console, syslog or file.
+type VlogLevel struct {
+	Module      string
+	Destination string
+	Level       string
+}
+
+// appctlTarget returns the control socket of the daemon of a component,
+// passed to ovs-appctl with -t. The control socket embeds the pid of the
+// daemon, refreshed on each poll by the process collector.
+func (e *Exporter) appctlTarget(component string) (string, error) {
+	var program string
+	var pid int
+	switch component {
+	case "ovsdb-server":
+		program, pid = "ovsdb-server", e.Client.Database.Vswitch.Process.ID
+	case "vswitchd-service":
+		program, pid = "ovs-vswitchd", e.Client.Service.Vswitchd.Process.ID
+	default:
+		return "", fmt.Errorf("the '%s' component is unsupported", component)
+	}
+	if pid == 0 {
+		return "", fmt.Errorf("the pid of %s is unknown", program)
+	}
+	return fmt.Sprintf("%s/%s.%d.ctl", e.Client.System.RunDir, program, pid), nil
+}
+
+// vswitchdAppctl returns the ovs-appctl command running a unixctl command of
+// ovs-vswitchd through its control socket. Until the pid of ovs-vswitchd is
+// known, ovs-appctl looks the daemon up in the run directory of the exporter
+// rather than in its compiled-in default.
+func (e *Exporter) vswitchdAppctl(args ...string) *exec.Cmd {
+	target, err := e.appctlTarget("vswitchd-service")
+	if err != nil {
+		cmd := e.command("ovs-appctl", append([]string{"-t", "ovs-vswitchd"}, args...)...)
+		if e.Client.System.RunDir != "" {
+			if cmd.Env == nil {
+				cmd.Env = os.Environ()
+			}
+			cmd.Env = append(cmd.Env, "OVS_RUNDIR="+e.Client.System.RunDir)
+		}
+		return cmd
+	}
+	return e.command("ovs-appctl", append([]string{"-t", target}, args...)...)
+}
+
+// GetVlogLevels returns the log levels of the modules of the daemon of a
+// component, using ovs-appctl vlog/list.
+func (e *Exporter) GetVlogLevels(component string) ([]VlogLevel, error) {
+	target, err := e.appctlTarget(component)
+	if err != nil {
+		return nil, err
+	}
+	output, err := e.command("ovs-appctl", "-t", target, "vlog/list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute vlog/list for %s: %w", component, err)
+	}
+	return parseVlogLevels(string(output))
+}
+
+// SetVlogLevel changes the log levels of the daemon of a component with
+// ovs-appctl vlog/set, e.g. "netdev_dpdk:file:dbg", and returns the output
+// of the command.
+func (e *Exporter) SetVlogLevel(component, spec string) (string, error) {
+	if !vlogSpecRe.MatchString(spec) {
+		return "", ErrInvalidVlogSpec
+	}
+	if module := strings.ToUpper(strings.SplitN(spec, ":", 2)[0]); module == "PATTERN" || module == "FACILITY" {
+		return "", ErrInvalidVlogSpec
+	}
+	target, err := e.appctlTarget(component)
+	if err != nil {
+		return "", err
+	}
+	output, err := e.command("ovs-appctl", "-t", target, "vlog/set", spec).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to execute vlog/set for %s: %s: %s", component, err, strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
+// parseVlogLevels parses the output of vlog/list, e.g.:
+//
+//	                 console    syslog    file
+//	                 -------    ------    ------
+//	backtrace          OFF        ERR       INFO
+//	bfd                OFF        ERR       DBG
+func parseVlogLevels(output string) ([]VlogLevel, error) {
+	var destinations []string
+	var levels []VlogLevel
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "-") {
+			continue
+		}
+		if destinations == nil {
+			destinations = fields
+			continue
+		}
+		if len(fields) != len(destinations)+1 {
+			continue
+		}
+		for i, destination := range destinations {
+			l := strings.ToLower(fields[i+1])
+			if _, exists := vlogLevels[l]; !exists {
+				continue
+			}
+			levels = append(levels, VlogLevel{Module: fields[0], Destination: destination, Level: l})
+		}
+	}
+	if destinations == nil {
+		return nil, fmt.Errorf("no log destinations in %q", output)
+	}
+	return levels, nil
+}
+
+// collectVlogMetrics exports the number of modules of the OVS daemons at
+// each log level and destination and, when enabled, the level of each
+// module, so that debug logging left enabled in production is auditable.
+func (e *Exporter) collectVlogMetrics() {
+	for _, component := range vlogComponents {
+		e.IncrementRequestCounter()
+		levels, err := e.GetVlogLevels(component)
+		if err != nil {
+			level.Error(e.logger).Log(
+				"msg", "GetVlogLevels() failed",
+				"system_id", e.Client.System.ID,
+				"component", component,
+				"error", err.Error(),
+			)
+			e.recordCollectorError("GetVlogLevels() failed", err)
+			continue
+		}
+		counts := make(map[[2]string]float64)
+		for _, l := range levels {
+			counts[[2]string{l.Destination, l.Level}]++
+			if !e.vlogModulesEnabled {
+				continue
+			}
+			e.emit(e.newConstMetric(
+				vlogModuleLevel,
+				prometheus.GaugeValue,
+				float64(vlogLevels[l.Level]),
+				e.Client.System.ID,
+				component,
+				l.Module,
+				l.Destination,
+				l.Level,
+			))
+		}
+		for key, count := range counts {
+			e.emit(e.newConstMetric(
+				vlogModules,
+				prometheus.GaugeValue,
+				count,
+				e.Client.System.ID,
+				component,
+				key[0],
+				key[1],
+			))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseVlogLevels(t *testing.T) {
+	output := `                 console    syslog    file
+                 -------    ------    ------
+backtrace          OFF        ERR       INFO
+bfd                OFF        ERR       DBG
+`
+	levels, err := parseVlogLevels(output)
+	if err != nil {
+		t.Fatalf("parseVlogLevels() returned error: %v", err)
+	}
+	expected := []VlogLevel{
+		{"backtrace", "console", "off"},
+		{"backtrace", "syslog", "err"},
+		{"backtrace", "file", "info"},
+		{"bfd", "console", "off"},
+		{"bfd", "syslog", "err"},
+		{"bfd", "file", "dbg"},
+	}
+	if !reflect.DeepEqual(levels, expected) {
+		t.Errorf("Expected %v, got %v", expected, levels)
+	}
+
+	if _, err := parseVlogLevels(""); err == nil {
+		t.Error("Expected an error for an empty output")
+	}
+}
+
+func TestVswitchdAppctl(t *testing.T) {
+	e := &Exporter{Client: ovsdb.NewOvsClient()}
+	e.Client.System.RunDir = "/run/ovs-sandbox"
+
+	cmd := e.vswitchdAppctl("dpif-netdev/pmd-perf-show")
+	expected := []string{"ovs-appctl", "-t", "ovs-vswitchd", "dpif-netdev/pmd-perf-show"}
+	if !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Expected %v, got %v", expected, cmd.Args)
+	}
+	if len(cmd.Env) == 0 || cmd.Env[len(cmd.Env)-1] != "OVS_RUNDIR=/run/ovs-sandbox" {
+		t.Errorf("Expected OVS_RUNDIR to point to the run directory, got %v", cmd.Env)
+	}
+
+	e.Client.Service.Vswitchd.Process.ID = 4242
+	cmd = e.vswitchdAppctl("dpif-netdev/pmd-stats-show")
+	expected = []string{"ovs-appctl", "-t", "/run/ovs-sandbox/ovs-vswitchd.4242.ctl", "dpif-netdev/pmd-stats-show"}
+	if !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Expected %v, got %v", expected, cmd.Args)
+	}
+}
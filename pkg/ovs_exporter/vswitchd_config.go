@@ -0,0 +1,148 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/greenpau/ovsdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// VswitchdConfigState holds the configuration sequence numbers of the
+// Open_vSwitch table. ovs-vswitchd sets cur_cfg to next_cfg once it has
+// applied a configuration change.
+type VswitchdConfigState struct {
+	CurCfg  int64
+	NextCfg int64
+}
+
+// GetVswitchdConfigState returns the cur_cfg and next_cfg columns of the
+// Open_vSwitch table.
+func (e *Exporter) GetVswitchdConfigState() (VswitchdConfigState, error) {
+	query := fmt.Sprintf("SELECT cur_cfg, next_cfg FROM %s", e.Client.Database.Vswitch.Name)
+	result, err := e.Client.Database.Vswitch.Client.Transact(e.Client.Database.Vswitch.Name, query)
+	if err != nil {
+		return VswitchdConfigState{}, fmt.Errorf("the '%s' query failed: %s", query, err)
+	}
+	return parseVswitchdConfigState(result)
+}
+
+// parseVswitchdConfigState extracts the sequence numbers from the first
+// row of the Open_vSwitch table.
+func parseVswitchdConfigState(result ovsdb.Result) (VswitchdConfigState, error) {
+	state := VswitchdConfigState{}
+	if len(result.Rows) == 0 {
+		return state, fmt.Errorf("no rows found in the Open_vSwitch table")
+	}
+	row := result.Rows[0]
+	for col, value := range map[string]*int64{"cur_cfg": &state.CurCfg, "next_cfg": &state.NextCfg} {
+		r, dt, err := row.GetColumnValue(col, result.Columns)
+		if err != nil {
+			return state, fmt.Errorf("parsing '%s' failed: %s", col, err)
+		}
+		if dt != "integer" {
+			return state, fmt.Errorf("data type '%s' for '%s' column is unexpected in this context", dt, col)
+		}
+		*value = r.(int64)
+	}
+	return state, nil
+}
+
+// observeConfigPending returns the number of configuration changes not
+// yet applied by ovs-vswitchd and the seconds they have been pending,
+// counted from the first poll observing them.
+func (e *Exporter) observeConfigPending(state VswitchdConfigState, now time.Time) (int64, float64) {
+	pending := state.NextCfg - state.CurCfg
+	if pending <= 0 {
+		e.configPendingSince = time.Time{}
+		return 0, 0
+	}
+	if e.configPendingSince.IsZero() {
+		e.configPendingSince = now
+	}
+	return pending, now.Sub(e.configPendingSince).Seconds()
+}
+
+// collectVswitchdConfigMetrics exports the configuration sequence numbers
+// and the time of the last configuration change applied by ovs-vswitchd.
+func (e *Exporter) collectVswitchdConfigMetrics() {
+	if !e.hasSchemaFeature("vswitchd_config") {
+		return
+	}
+	e.IncrementRequestCounter()
+	state, err := e.GetVswitchdConfigState()
+	if err != nil {
+		level.Error(e.logger).Log(
+			"msg", "GetVswitchdConfigState() failed",
+			"system_id", e.Client.System.ID,
+			"error", err.Error(),
+		)
+		e.recordCollectorError("GetVswitchdConfigState() failed", err)
+		return
+	}
+
+	// The exact time of a change is unknown, hence the change is recorded
+	// when it is first observed, including the first poll.
+	if e.lastConfigChange.IsZero() || state.CurCfg != e.lastCurCfg {
+		e.lastCurCfg = state.CurCfg
+		e.lastConfigChange = time.Now()
+	}
+
+	var lagging float64
+	pending, pendingDuration := e.observeConfigPending(state, time.Now())
+	if pending > 0 {
+		lagging = 1
+	}
+
+	e.emit(e.newConstMetric(
+		vswitchdConfigCurCfg,
+		prometheus.GaugeValue,
+		float64(state.CurCfg),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		vswitchdConfigNextCfg,
+		prometheus.GaugeValue,
+		float64(state.NextCfg),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		vswitchdConfigLagging,
+		prometheus.GaugeValue,
+		lagging,
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		vswitchdConfigSeqnoPending,
+		prometheus.GaugeValue,
+		float64(pending),
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		vswitchdConfigPendingDuration,
+		prometheus.GaugeValue,
+		pendingDuration,
+		e.Client.System.ID,
+	))
+	e.emit(e.newConstMetric(
+		vswitchdConfigLastChange,
+		prometheus.GaugeValue,
+		float64(e.lastConfigChange.Unix()),
+		e.Client.System.ID,
+	))
+}
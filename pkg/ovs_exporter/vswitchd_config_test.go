@@ -0,0 +1,71 @@
+// Copyright 2025 OVS Exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovs_exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greenpau/ovsdb"
+)
+
+func TestParseVswitchdConfigState(t *testing.T) {
+	result := ovsdb.Result{
+		Columns: map[string]string{"cur_cfg": "integer", "next_cfg": "integer"},
+		Rows: []ovsdb.Row{
+			{"cur_cfg": float64(41), "next_cfg": float64(43)},
+		},
+	}
+
+	state, err := parseVswitchdConfigState(result)
+	if err != nil {
+		t.Fatalf("parseVswitchdConfigState() returned error: %v", err)
+	}
+	if state.CurCfg != 41 || state.NextCfg != 43 {
+		t.Errorf("Expected cur_cfg=41 next_cfg=43, got %+v", state)
+	}
+
+	if _, err := parseVswitchdConfigState(ovsdb.Result{}); err == nil {
+		t.Error("parseVswitchdConfigState() should return error for empty result")
+	}
+}
+
+func TestObserveConfigPending(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		name        string
+		state       VswitchdConfigState
+		now         time.Time
+		wantPending int64
+		wantSeconds float64
+	}{
+		{"equal", VswitchdConfigState{CurCfg: 17, NextCfg: 17}, start, 0, 0},
+		{"behind", VswitchdConfigState{CurCfg: 17, NextCfg: 19}, start, 2, 0},
+		{"still behind", VswitchdConfigState{CurCfg: 18, NextCfg: 19}, start.Add(30 * time.Second), 1, 30},
+		// Beyond the 120s of the alert in METRICS.md.
+		{"behind beyond threshold", VswitchdConfigState{CurCfg: 18, NextCfg: 20}, start.Add(150 * time.Second), 2, 150},
+		{"caught up", VswitchdConfigState{CurCfg: 20, NextCfg: 20}, start.Add(160 * time.Second), 0, 0},
+		{"behind again", VswitchdConfigState{CurCfg: 20, NextCfg: 21}, start.Add(200 * time.Second), 1, 0},
+	}
+
+	// The cases run in order against the same exporter, as consecutive polls.
+	e := &Exporter{}
+	for _, test := range tests {
+		pending, seconds := e.observeConfigPending(test.state, test.now)
+		if pending != test.wantPending || seconds != test.wantSeconds {
+			t.Errorf("%s: expected %d pending for %vs, got %d for %vs", test.name, test.wantPending, test.wantSeconds, pending, seconds)
+		}
+	}
+}